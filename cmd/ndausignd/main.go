@@ -0,0 +1,84 @@
+package main
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+// ndausignd is the reference implementation of the remote signer protocol.
+//
+// It signs with the account keys in an ndautool config. Production signing services
+// implement the same protocol in front of keys which never leave their hardware.
+import (
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"path"
+	"syscall"
+
+	"github.com/ndau/ndau/pkg/signer"
+	config "github.com/ndau/ndau/pkg/tool.config"
+	log "github.com/sirupsen/logrus"
+)
+
+// passphraseEnv names the environment variable holding the passphrase of an encrypted config.
+const passphraseEnv = "NDAUSIGND_PASSPHRASE"
+
+func main() {
+	configPath := flag.String("config", config.GetConfigPath(), "path to ndautool.toml")
+	listen := flag.String(
+		"listen",
+		"unix://"+path.Join(path.Dir(config.GetConfigPath()), "ndausignd.sock"),
+		"address at which to listen: unix:///path/to/socket, or host:port on the loopback interface",
+	)
+	flag.Parse()
+
+	logger := log.New()
+
+	conf, err := config.Load(*configPath)
+	if err != nil {
+		logger.WithError(err).Fatal("could not load config")
+	}
+	if conf.IsEncrypted() {
+		err = conf.Unlock(os.Getenv(passphraseEnv))
+		os.Unsetenv(passphraseEnv)
+		if err != nil {
+			logger.WithError(err).Fatal("could not unlock config; set " + passphraseEnv)
+		}
+	}
+	keyring, err := conf.Keyring()
+	if err != nil {
+		logger.WithError(err).Fatal("could not collect keys")
+	}
+	err = conf.Lock()
+	if err != nil {
+		logger.WithError(err).Fatal("could not lock config")
+	}
+
+	listener, err := signer.Listen(*listen)
+	if err != nil {
+		logger.WithError(err).Fatal("could not listen")
+	}
+
+	// remove the socket on the way out
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		listener.Close()
+	}()
+
+	logger.WithFields(log.Fields{
+		"listen": *listen,
+		"keys":   keyring.Len(),
+	}).Info("ndausignd started")
+	err = http.Serve(listener, signer.NewServer(keyring))
+	if err != nil && err != http.ErrServerClosed {
+		logger.WithError(err).Info("ndausignd stopped")
+	}
+}
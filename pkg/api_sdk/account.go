@@ -10,6 +10,7 @@ package sdk
 // - -- --- ---- -----
 
 import (
+	"context"
 	"fmt"
 	"sort"
 
@@ -20,15 +21,28 @@ import (
 	"github.com/pkg/errors"
 )
 
-// GetAccount gets the account data associated with a given address
-func (c *Client) GetAccount(addr address.Address) (*backing.AccountData, error) {
+// GetAccountContext gets the account data associated with a given address
+//
+// If the account does not exist, the error is ErrNotFound.
+func (c *Client) GetAccountContext(ctx context.Context, addr address.Address) (*backing.AccountData, error) {
 	ads := make(map[string]*backing.AccountData)
-	err := c.get(&ads, c.URL("account/account/%s", addr))
+	err := c.get(ctx, &ads, c.URL("account/account/%s", addr))
 	if err != nil {
 		return nil, err
 	}
 	ad, ok := ads[addr.String()]
-	if !ok {
+	if !ok || ad == nil {
+		return nil, ErrNotFound
+	}
+	return ad, err
+}
+
+// GetAccount gets the account data associated with a given address
+//
+// If the account does not exist, both the data and the error are nil.
+func (c *Client) GetAccount(addr address.Address) (*backing.AccountData, error) {
+	ad, err := c.GetAccountContext(compat, addr)
+	if err == ErrNotFound {
 		return nil, nil
 	}
 	return ad, err
@@ -39,18 +53,62 @@ func GetAccount(node *Client, addr address.Address) (*backing.AccountData, error
 	return node.GetAccount(addr)
 }
 
-// GetSequence gets the current sequence number of a particular account
-func (c *Client) GetSequence(addr address.Address) (uint64, error) {
-	ad, err := c.GetAccount(addr)
+// GetAccountsContext gets the account data associated with each of the given addresses
+// in a single request
+//
+// Every requested address appears in the result; accounts which don't exist
+// have their Exists flag unset.
+func (c *Client) GetAccountsContext(ctx context.Context, addrs []address.Address) (query.AccountsResponse, error) {
+	strs := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		strs = append(strs, addr.String())
+	}
+	ads := make(map[string]backing.AccountData)
+	err := c.post(ctx, strs, &ads, c.URL("account/accounts"))
+	if err != nil {
+		return nil, errors.Wrap(err, "getting accounts from API")
+	}
+
+	accounts := make(query.AccountsResponse, len(addrs))
+	for _, addr := range addrs {
+		ad, exists := ads[addr.String()]
+		accounts[addr.String()] = query.AccountResult{
+			Exists: exists,
+			Data:   ad,
+		}
+	}
+	return accounts, nil
+}
+
+// GetAccounts gets the account data associated with each of the given addresses
+// in a single request
+func (c *Client) GetAccounts(addrs []address.Address) (query.AccountsResponse, error) {
+	return c.GetAccountsContext(compat, addrs)
+}
+
+// GetAccounts gets the account data associated with each of the given addresses
+// in a single request
+func GetAccounts(node *Client, addrs []address.Address) (query.AccountsResponse, error) {
+	return node.GetAccounts(addrs)
+}
+
+// GetSequenceContext gets the current sequence number of a particular account
+func (c *Client) GetSequenceContext(ctx context.Context, addr address.Address) (uint64, error) {
+	ad, err := c.GetAccountContext(ctx, addr)
+	if err == ErrNotFound {
+		// accounts which don't exist have sequence 0
+		return 0, nil
+	}
 	if err != nil {
 		// highest representable sequence number
 		return ^uint64(0), err
 	}
-	if ad != nil {
-		return ad.Sequence, nil
-	}
-	// accounts which don't exist have sequence 0
-	return 0, nil
+	return ad.Sequence, nil
+}
+
+// GetSequence gets the current sequence number of a particular account
+func (c *Client) GetSequence(addr address.Address) (uint64, error) {
+	return c.GetSequenceContext(compat, addr)
 }
 
 // GetSequence gets the current sequence number of a particular account
@@ -58,12 +116,12 @@ func GetSequence(node *Client, addr address.Address) (uint64, error) {
 	return node.GetSequence(addr)
 }
 
-// GetAccountHistory gets account data history associated with a given address.
-func (c *Client) GetAccountHistory(ahparams search.AccountHistoryParams) (*search.AccountHistoryResponse, error) {
+// GetAccountHistoryContext gets account data history associated with a given address.
+func (c *Client) GetAccountHistoryContext(ctx context.Context, ahparams search.AccountHistoryParams) (*search.AccountHistoryResponse, error) {
 	var response struct {
 		Items []search.AccountTxValueData
 	}
-	err := c.get(&response, c.URLP(
+	err := c.get(ctx, &response, c.URLP(
 		params{"after": ahparams.AfterHeight, "limit": ahparams.Limit},
 		"account/history/%s", ahparams.Address))
 	if err != nil {
@@ -74,17 +132,22 @@ func (c *Client) GetAccountHistory(ahparams search.AccountHistoryParams) (*searc
 	}, nil
 }
 
+// GetAccountHistory gets account data history associated with a given address.
+func (c *Client) GetAccountHistory(ahparams search.AccountHistoryParams) (*search.AccountHistoryResponse, error) {
+	return c.GetAccountHistoryContext(compat, ahparams)
+}
+
 // GetAccountHistory gets account data history associated with a given address.
 func GetAccountHistory(node *Client, params search.AccountHistoryParams) (*search.AccountHistoryResponse, error) {
 	return node.GetAccountHistory(params)
 }
 
-// GetAccountList gets a list of account names, paged according to the params
+// GetAccountListContext gets a list of account names, paged according to the params
 // Pass in after = "" (which is less than all nonempty strings) and limit = 0
 // to get all results. (Note that the ndauapi will enforce a limit of 100 items.)
-func (c *Client) GetAccountList(after string, limit int) (*query.AccountListQueryResponse, error) {
+func (c *Client) GetAccountListContext(ctx context.Context, after string, limit int) (*query.AccountListQueryResponse, error) {
 	resp := new(query.AccountListQueryResponse)
-	err := c.get(resp, c.URLP(
+	err := c.get(ctx, resp, c.URLP(
 		params{"after": after, "limit": limit},
 		"account/list",
 	))
@@ -94,6 +157,13 @@ func (c *Client) GetAccountList(after string, limit int) (*query.AccountListQuer
 	return resp, nil
 }
 
+// GetAccountList gets a list of account names, paged according to the params
+// Pass in after = "" (which is less than all nonempty strings) and limit = 0
+// to get all results. (Note that the ndauapi will enforce a limit of 100 items.)
+func (c *Client) GetAccountList(after string, limit int) (*query.AccountListQueryResponse, error) {
+	return c.GetAccountListContext(compat, after, limit)
+}
+
 // GetAccountList gets a list of account names, paged according to the params
 // Pass in after = "" (which is less than all nonempty strings) and limit = 0
 // to get all results. (Note that the ndauapi will enforce a limit of 100 items.)
@@ -101,7 +171,7 @@ func GetAccountList(node *Client, after string, limit int) (*query.AccountListQu
 	return node.GetAccountList(after, limit)
 }
 
-// GetAccountListBatch abstracts over the process of repeatedly calling
+// GetAccountListBatchContext abstracts over the process of repeatedly calling
 // GetAccountList in order to get a complete list of all known addresses.
 //
 // This function makes a best-effort attempt to return a complete and current
@@ -109,7 +179,7 @@ func GetAccountList(node *Client, after string, limit int) (*query.AccountListQu
 // a sequential paged API; as we cannot lock the node, there may be updates
 // during paging which cause addresses to appear in pages we have already
 // visited. This is unavoidable.
-func (c *Client) GetAccountListBatch() ([]address.Address, error) {
+func (c *Client) GetAccountListBatchContext(ctx context.Context) ([]address.Address, error) {
 	// nearly verbatim from
 	// https://github.com/ndau/ndau/blob/9198f7d7520854e68462de08d59daac93fe8a829/pkg/tool/account.go#L100-L150
 	var (
@@ -122,7 +192,8 @@ func (c *Client) GetAccountListBatch() ([]address.Address, error) {
 	)
 
 	getPage := func() {
-		qaccts, err = c.GetAccountList(
+		qaccts, err = c.GetAccountListContext(
+			ctx,
 			after,
 			limit,
 		)
@@ -164,6 +235,12 @@ func (c *Client) GetAccountListBatch() ([]address.Address, error) {
 	return addrs, nil
 }
 
+// GetAccountListBatch abstracts over the process of repeatedly calling
+// GetAccountList in order to get a complete list of all known addresses.
+func (c *Client) GetAccountListBatch() ([]address.Address, error) {
+	return c.GetAccountListBatchContext(compat)
+}
+
 // GetAccountListBatch abstracts over the process of repeatedly calling
 // GetAccountList in order to get a complete list of all known addresses.
 //
@@ -10,16 +10,17 @@ package sdk
 // - -- --- ---- -----
 
 import (
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
 	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
-// GetBlocksByHeight returns a sequence of block metadata for blocks with heights in the specified range.
+// GetBlocksByHeightContext returns a sequence of block metadata for blocks with heights in the specified range.
 //
 // If noempty is set, exclude blocks containing no transactions
-func (c *Client) GetBlocksByHeight(before, after uint64, noempty bool) (blocks *rpctypes.ResultBlockchainInfo, err error) {
+func (c *Client) GetBlocksByHeightContext(ctx context.Context, before, after uint64, noempty bool) (blocks *rpctypes.ResultBlockchainInfo, err error) {
 	blocks = new(rpctypes.ResultBlockchainInfo)
 	p := params{}
 	if after > 0 {
@@ -28,7 +29,7 @@ func (c *Client) GetBlocksByHeight(before, after uint64, noempty bool) (blocks *
 	if noempty {
 		p["filter"] = "noempty"
 	}
-	err = c.get(blocks, c.URLP(
+	err = c.get(ctx, blocks, c.URLP(
 		p,
 		"block/before/%d", before,
 	))
@@ -36,10 +37,15 @@ func (c *Client) GetBlocksByHeight(before, after uint64, noempty bool) (blocks *
 	return
 }
 
-// GetBlockAt returns a block at a given height
+// GetBlocksByHeight returns a sequence of block metadata for blocks with heights in the specified range.
+func (c *Client) GetBlocksByHeight(before, after uint64, noempty bool) (blocks *rpctypes.ResultBlockchainInfo, err error) {
+	return c.GetBlocksByHeightContext(compat, before, after, noempty)
+}
+
+// GetBlockAtContext returns a block at a given height
 //
 // If height is 0, get the current block
-func (c *Client) GetBlockAt(height uint64) (block *rpctypes.ResultBlock, err error) {
+func (c *Client) GetBlockAtContext(ctx context.Context, height uint64) (block *rpctypes.ResultBlock, err error) {
 	block = new(rpctypes.ResultBlock)
 	var url string
 	if height == 0 {
@@ -47,34 +53,49 @@ func (c *Client) GetBlockAt(height uint64) (block *rpctypes.ResultBlock, err err
 	} else {
 		url = c.URL("block/height/%d", height)
 	}
-	err = c.get(block, url)
+	err = c.get(ctx, block, url)
 	err = errors.Wrap(err, "getting block by height")
 	return
 }
 
+// GetBlockAt returns a block at a given height
+func (c *Client) GetBlockAt(height uint64) (block *rpctypes.ResultBlock, err error) {
+	return c.GetBlockAtContext(compat, height)
+}
+
+// GetCurrentBlockContext returns the current block
+func (c *Client) GetCurrentBlockContext(ctx context.Context) (*rpctypes.ResultBlock, error) {
+	return c.GetBlockAtContext(ctx, 0)
+}
+
 // GetCurrentBlock returns the current block
 func (c *Client) GetCurrentBlock() (*rpctypes.ResultBlock, error) {
-	return c.GetBlockAt(0)
+	return c.GetCurrentBlockContext(compat)
 }
 
-// GetBlock returns a block with a particular hash
-func (c *Client) GetBlock(hash string) (block *rpctypes.ResultBlock, err error) {
+// GetBlockContext returns a block with a particular hash
+func (c *Client) GetBlockContext(ctx context.Context, hash string) (block *rpctypes.ResultBlock, err error) {
 	block = new(rpctypes.ResultBlock)
-	err = c.get(block, c.URL("block/hash/%s", hash))
+	err = c.get(ctx, block, c.URL("block/hash/%s", hash))
 	err = errors.Wrap(err, "getting block by hash")
 	return
 }
 
-// GetBlocksByRange returns a sequence of block metadata for blocks with heights in the specified range.
+// GetBlock returns a block with a particular hash
+func (c *Client) GetBlock(hash string) (block *rpctypes.ResultBlock, err error) {
+	return c.GetBlockContext(compat, hash)
+}
+
+// GetBlocksByRangeContext returns a sequence of block metadata for blocks with heights in the specified range.
 //
 // If noempty is set, exclude blocks containing no transactions
-func (c *Client) GetBlocksByRange(before, after uint64, noempty bool) (blocks *rpctypes.ResultBlockchainInfo, err error) {
+func (c *Client) GetBlocksByRangeContext(ctx context.Context, before, after uint64, noempty bool) (blocks *rpctypes.ResultBlockchainInfo, err error) {
 	blocks = new(rpctypes.ResultBlockchainInfo)
 	p := params{}
 	if noempty {
 		p["noempty"] = "true"
 	}
-	err = c.get(blocks, c.URLP(
+	err = c.get(ctx, blocks, c.URLP(
 		p,
 		"block/range/%d/%d", after, before,
 	))
@@ -82,11 +103,16 @@ func (c *Client) GetBlocksByRange(before, after uint64, noempty bool) (blocks *r
 	return
 }
 
-// GetBlocksByDaterange returns a sequence of block metadata for blocks with block times in the specified range.
+// GetBlocksByRange returns a sequence of block metadata for blocks with heights in the specified range.
+func (c *Client) GetBlocksByRange(before, after uint64, noempty bool) (blocks *rpctypes.ResultBlockchainInfo, err error) {
+	return c.GetBlocksByRangeContext(compat, before, after, noempty)
+}
+
+// GetBlocksByDaterangeContext returns a sequence of block metadata for blocks with block times in the specified range.
 //
 // If noempty is set, exclude blocks containing no transactions.
 // after should be the last value from the previous page, or a zero value to exclude
-func (c *Client) GetBlocksByDaterange(first, last time.Time, noempty bool, after time.Time, limit int) (blocks *rpctypes.ResultBlockchainInfo, err error) {
+func (c *Client) GetBlocksByDaterangeContext(ctx context.Context, first, last time.Time, noempty bool, after time.Time, limit int) (blocks *rpctypes.ResultBlockchainInfo, err error) {
 	blocks = new(rpctypes.ResultBlockchainInfo)
 	p := params{}
 	if after != (time.Time{}) {
@@ -96,6 +122,7 @@ func (c *Client) GetBlocksByDaterange(first, last time.Time, noempty bool, after
 		p["limit"] = limit
 	}
 	err = c.get(
+		ctx,
 		blocks,
 		c.URLP(
 			p,
@@ -105,3 +132,8 @@ func (c *Client) GetBlocksByDaterange(first, last time.Time, noempty bool, after
 	err = errors.Wrap(err, "getting blocks by date range")
 	return
 }
+
+// GetBlocksByDaterange returns a sequence of block metadata for blocks with block times in the specified range.
+func (c *Client) GetBlocksByDaterange(first, last time.Time, noempty bool, after time.Time, limit int) (blocks *rpctypes.ResultBlockchainInfo, err error) {
+	return c.GetBlocksByDaterangeContext(compat, first, last, noempty, after, limit)
+}
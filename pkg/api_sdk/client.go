@@ -11,8 +11,10 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -21,6 +23,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
@@ -29,12 +33,45 @@ import (
 const JSON = "application/json"
 
 // A Client is a client for the ndau REST API.
+//
+// Every method has a Context variant, which can be cancelled and which reports an
+// error response from the API as an *APIError. The methods without a context predate
+// typed errors, and keep their original behavior: an error response is decoded as if
+// it were a result, and only transport and decoding errors are returned.
 type Client struct {
-	addr  *url.URL
-	mutex sync.Mutex
-	http  *http.Client
+	addr          *url.URL
+	mutex         sync.Mutex
+	http          *http.Client
+	retry         RetryPolicy
+	pollInterval  time.Duration
+	commitTimeout time.Duration
+	sequences     *tool.SequenceAllocator
 }
 
+// RetryPolicy controls how requests which fail transiently are retried.
+//
+// Requests which could not be completed, and those answered with a 5xx status other
+// than 501, are made up to Attempts times in all. The first retry waits Backoff; each
+// later retry waits twice as long as the one before, up to MaxBackoff.
+type RetryPolicy struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy of clients created by NewClient
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:   3,
+	Backoff:    250 * time.Millisecond,
+	MaxBackoff: 2 * time.Second,
+}
+
+// compatKey marks the context of the methods without a Context suffix
+type compatKey struct{}
+
+// compat is the context of the methods without a Context suffix
+var compat = context.WithValue(context.Background(), compatKey{}, true)
+
 // NewClient creates a SDKClient.
 func NewClient(node string) (*Client, error) {
 	u, err := url.Parse(node)
@@ -47,6 +84,7 @@ func NewClient(node string) (*Client, error) {
 		http: &http.Client{
 			Timeout: 60 * time.Second, //Nodes have gotten slower, we need a longer timeout
 		},
+		retry: DefaultRetryPolicy,
 	}, nil
 }
 
@@ -62,6 +100,13 @@ func TestClient(t *testing.T, port uint) *Client {
 	}
 }
 
+// SetRetryPolicy updates how requests which fail transiently are retried
+//
+// Clients created by NewClient use DefaultRetryPolicy; test clients don't retry.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
 // SetTimeout updates the node's http timeout
 //
 // The default is 5 seconds
@@ -103,56 +148,108 @@ func (c *Client) URLP(params params, path string, args ...interface{}) string {
 	return u
 }
 
-func (c *Client) get(obj interface{}, url string) error {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (c *Client) get(ctx context.Context, obj interface{}, url string) error {
+	_, err := c.do(ctx, http.MethodGet, url, nil, obj)
+	return err
+}
+
+func (c *Client) post(ctx context.Context, req interface{}, resp interface{}, url string) error {
+	_, err := c.do(ctx, http.MethodPost, url, req, resp)
+	return err
+}
+
+// transient is true when a failed request is worth retrying
+func transient(status int, err error) bool {
 	if err != nil {
-		return errors.Wrap(err, "constructing request")
+		return true
+	}
+	return status >= 500 && status != http.StatusNotImplemented
+}
+
+// roundTrip makes a single request, returning the response status and body
+func (c *Client) roundTrip(ctx context.Context, method, url string, data []byte) (int, []byte, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
 	}
-	req.Header.Set("Accept", JSON)
-	response, err := c.http.Do(req)
+	request, err := http.NewRequest(method, url, body)
 	if err != nil {
-		return errors.Wrap(err, "performing request")
+		return 0, nil, errors.Wrap(err, "constructing request")
 	}
-	defer response.Body.Close()
-	data, err := ioutil.ReadAll(response.Body)
+	request = request.WithContext(ctx)
+	if data != nil {
+		request.Header.Set("Content-Type", JSON)
+	}
+	request.Header.Set("Accept", JSON)
+	response, err := c.http.Do(request)
 	if err != nil {
-		return errors.Wrap(err, "reading response body")
+		return 0, nil, errors.Wrap(err, "performing request")
 	}
-	err = json.Unmarshal(data, obj)
+	defer response.Body.Close()
+	respdata, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return errors.Wrap(err, "unmarshaling response")
+		return response.StatusCode, nil, errors.Wrap(err, "reading response body")
 	}
-	return nil
+	return response.StatusCode, respdata, nil
 }
 
-func (c *Client) post(req interface{}, resp interface{}, url string) error {
+// do performs a request, retrying transient failures, and decodes the response
+// body into resp
+//
+// If req is nil, no request body is sent. An error response is returned as an
+// *APIError, after decoding what it can into resp; some endpoints describe failures
+// in their usual result. Under the compat context, error responses are decoded as
+// results and not reported.
+func (c *Client) do(ctx context.Context, method, url string, req, resp interface{}) (int, error) {
 	var data []byte
-	var err error
 	if req != nil {
+		var err error
 		data, err = json.Marshal(req)
 		if err != nil {
-			return errors.Wrap(err, "marshaling request body")
+			return 0, errors.Wrap(err, "marshaling request body")
 		}
 	}
-	buf := bytes.NewBuffer(data)
-	request, err := http.NewRequest(http.MethodPost, url, buf)
-	if err != nil {
-		return errors.Wrap(err, "constructing request")
+
+	var (
+		status  int
+		body    []byte
+		err     error
+		backoff = c.retry.Backoff
+	)
+	for attempt := 1; ; attempt++ {
+		status, body, err = c.roundTrip(ctx, method, url, data)
+		if attempt >= c.retry.Attempts || !transient(status, err) || ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return status, errors.Wrap(ctx.Err(), "waiting to retry")
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > c.retry.MaxBackoff {
+			backoff = c.retry.MaxBackoff
+		}
 	}
-	request.Header.Set("Content-Type", JSON)
-	request.Header.Set("Accept", JSON)
-	response, err := c.http.Do(request)
 	if err != nil {
-		return errors.Wrap(err, "performing request")
+		return status, err
 	}
-	defer response.Body.Close()
-	data, err = ioutil.ReadAll(response.Body)
-	if err != nil {
-		return errors.Wrap(err, "reading response body")
+
+	if status >= http.StatusBadRequest && ctx.Value(compatKey{}) == nil {
+		apierr := &APIError{Status: status}
+		var eb reqres.ErrorBody
+		if json.Unmarshal(body, &eb) == nil {
+			apierr.Msg = eb.Message
+			apierr.Log = eb.Log
+			apierr.ErrCode = eb.ErrCode
+		}
+		json.Unmarshal(body, resp)
+		return status, apierr
 	}
-	err = json.Unmarshal(data, resp)
+
+	err = json.Unmarshal(body, resp)
 	if err != nil {
-		return errors.Wrap(err, "unmarshaling response")
+		return status, errors.Wrap(err, "unmarshaling response")
 	}
-	return nil
+	return status, nil
 }
@@ -0,0 +1,48 @@
+package sdk
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"context"
+	"encoding/json"
+
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndauapi/routes"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/pkg/errors"
+)
+
+// DebugVMContext re-runs one of the node's chaincode VMs at the current height,
+// returning its disassembly and a trace of its run
+//
+// vm is one of the query.VM constants; see tool.DebugVM for which of tx and node
+// each needs.
+func (c *Client) DebugVMContext(ctx context.Context, vm string, tx metatx.Transactable, node address.Address) (result *query.VMDebugResponse, err error) {
+	req := routes.VMDebugRequest{Node: node.String()}
+	if tx != nil {
+		req.TxType = metatx.NameOf(tx)
+		req.Tx, err = json.Marshal(tx)
+		if err != nil {
+			err = errors.Wrap(err, "marshaling tx")
+			return
+		}
+	}
+	result = new(query.VMDebugResponse)
+	err = c.post(ctx, req, result, c.URL("debug/vm/%s", vm))
+	err = errors.Wrap(err, "debugging vm")
+	return
+}
+
+// DebugVM re-runs one of the node's chaincode VMs at the current height,
+// returning its disassembly and a trace of its run
+func (c *Client) DebugVM(vm string, tx metatx.Transactable, node address.Address) (result *query.VMDebugResponse, err error) {
+	return c.DebugVMContext(compat, vm, tx, node)
+}
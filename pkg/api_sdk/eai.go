@@ -10,17 +10,48 @@ package sdk
 // - -- --- ---- -----
 
 import (
+	"context"
+
 	"github.com/ndau/ndau/pkg/ndauapi/routes"
+	"github.com/ndau/ndaumath/pkg/address"
+	math "github.com/ndau/ndaumath/pkg/types"
 	"github.com/pkg/errors"
 )
 
-// EAIRate returns EAI rates given certain account states
+// EAIRateContext returns EAI rates given certain account states
 //
-// The address field is just to correlate request fields with response fields;
-// account data is not checked.
-func (c *Client) EAIRate(query ...routes.EAIRateRequest) (response []routes.EAIRateResponse, err error) {
+// Unless FromAccount is set, the address field is just to correlate request fields
+// with response fields; account data is not checked.
+func (c *Client) EAIRateContext(ctx context.Context, query ...routes.EAIRateRequest) (response []routes.EAIRateResponse, err error) {
 	response = make([]routes.EAIRateResponse, 0)
-	err = c.post(query, &response, c.URL("system/eai/rate"))
+	err = c.post(ctx, query, &response, c.URL("system/eai/rate"))
 	err = errors.Wrap(err, "getting EAI rates from API")
 	return
 }
+
+// EAIRate returns EAI rates given certain account states
+func (c *Client) EAIRate(query ...routes.EAIRateRequest) (response []routes.EAIRateResponse, err error) {
+	return c.EAIRateContext(compat, query...)
+}
+
+// AccountEAIContext projects the EAI rates of existing accounts, and the EAI they would be
+// credited, at a given time
+//
+// Pass a zero timestamp to use the current time.
+func (c *Client) AccountEAIContext(ctx context.Context, at math.Timestamp, addrs ...address.Address) ([]routes.EAIRateResponse, error) {
+	query := make([]routes.EAIRateRequest, 0, len(addrs))
+	for _, addr := range addrs {
+		query = append(query, routes.EAIRateRequest{
+			Address:     addr.String(),
+			At:          at,
+			FromAccount: true,
+		})
+	}
+	return c.EAIRateContext(ctx, query...)
+}
+
+// AccountEAI projects the EAI rates of existing accounts, and the EAI they would be
+// credited, at a given time
+func (c *Client) AccountEAI(at math.Timestamp, addrs ...address.Address) ([]routes.EAIRateResponse, error) {
+	return c.AccountEAIContext(compat, at, addrs...)
+}
@@ -0,0 +1,139 @@
+package sdk
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ndau/metanode/pkg/meta/app/code"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound is returned when the thing asked for does not exist
+var ErrNotFound = errors.New("not found")
+
+// ErrCommitTimeout is returned when a submitted tx is still pending once the client's
+// commit timeout has passed. It may yet be committed.
+var ErrCommitTimeout = errors.New("tx not committed in time")
+
+// APIError is returned by the Context methods when the API responds with an error
+//
+// ErrCode is the node's return code, when the API reports that the node rejected a tx.
+type APIError struct {
+	Status  int
+	Msg     string
+	Log     string
+	ErrCode int
+}
+
+func (e *APIError) Error() string {
+	msg := e.Msg
+	if msg == "" {
+		msg = http.StatusText(e.Status)
+	}
+	if e.Log != "" {
+		msg += ": " + e.Log
+	}
+	return fmt.Sprintf("API error %d: %s", e.Status, msg)
+}
+
+// Transient is true when the request may succeed if made again
+func (e *APIError) Transient() bool {
+	return transient(e.Status, nil)
+}
+
+// AsAPIError returns the APIError underlying err, if there is one
+func AsAPIError(err error) (*APIError, bool) {
+	apierr, ok := errors.Cause(err).(*APIError)
+	return apierr, ok
+}
+
+// IsNotFound is true when err reports that the thing asked for does not exist,
+// as opposed to a failure to find out
+func IsNotFound(err error) bool {
+	if errors.Cause(err) == ErrNotFound {
+		return true
+	}
+	apierr, ok := AsAPIError(err)
+	return ok && apierr.Status == http.StatusNotFound
+}
+
+// A TxStage is a step of the tx lifecycle at which a tx can be rejected
+type TxStage string
+
+// These are the stages at which a tx can be rejected
+const (
+	StageBuild       TxStage = "build"
+	StagePrevalidate TxStage = "prevalidate"
+	StageSubmit      TxStage = "submit"
+	StageCommit      TxStage = "commit"
+)
+
+// TxError is returned when the API rejects a tx
+//
+// ErrCode is the node's return code, when the API reports one; otherwise it is -1.
+// Dropped is true when the tx was accepted into the mempool, but left it without being
+// committed; such a tx may be submitted again.
+type TxError struct {
+	TxType  string
+	TxHash  string
+	Stage   TxStage
+	ErrCode int
+	Msg     string
+	Dropped bool
+}
+
+func (e *TxError) Error() string {
+	if e.Dropped {
+		return fmt.Sprintf("%s %s dropped: %s", e.TxType, e.TxHash, e.Msg)
+	}
+	return fmt.Sprintf("%s %s rejected at %s: %s", e.TxType, e.TxHash, e.Stage, e.Msg)
+}
+
+// Code returns the node's return code, and whether there was one
+func (e *TxError) Code() (code.ReturnCode, bool) {
+	if e.ErrCode < 0 {
+		return 0, false
+	}
+	return code.ReturnCode(e.ErrCode), true
+}
+
+// rejection describes an error response to a request about a tx
+func rejection(tx metatx.Transactable, txhash string, stage TxStage, apierr *APIError) *TxError {
+	msg := apierr.Msg
+	if apierr.Log != "" {
+		msg += ": " + apierr.Log
+	}
+	errCode := apierr.ErrCode
+	if errCode == 0 {
+		errCode = -1
+	}
+	return &TxError{
+		TxType:  metatx.NameOf(tx),
+		TxHash:  txhash,
+		Stage:   stage,
+		ErrCode: errCode,
+		Msg:     msg,
+	}
+}
+
+// AsTxError returns the TxError underlying err, if there is one
+func AsTxError(err error) (*TxError, bool) {
+	txerr, ok := errors.Cause(err).(*TxError)
+	return txerr, ok
+}
+
+// IsDropped is true when err reports that a tx left the mempool without being committed
+func IsDropped(err error) bool {
+	txerr, ok := AsTxError(err)
+	return ok && txerr.Dropped
+}
@@ -0,0 +1,184 @@
+package sdk
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"context"
+	"time"
+
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/ndauapi/routes"
+	"github.com/ndau/ndau/pkg/signer"
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/pkg/errors"
+)
+
+// DefaultPollInterval is how often Execute asks whether a submitted tx has been committed
+const DefaultPollInterval = time.Second
+
+// SetPollInterval updates how often Execute asks whether a submitted tx has been committed
+func (c *Client) SetPollInterval(interval time.Duration) {
+	c.pollInterval = interval
+}
+
+// DefaultCommitTimeout is how long Execute waits for a submitted tx to be committed
+const DefaultCommitTimeout = 5 * time.Minute
+
+// SetCommitTimeout updates how long Execute waits for a submitted tx to be committed
+func (c *Client) SetCommitTimeout(timeout time.Duration) {
+	c.commitTimeout = timeout
+}
+
+// Execute decides that a tx was dropped once the API has failed to recognize it
+// this many times in a row.
+const maxUnknownPolls = 10
+
+// Execute runs a tx through its whole lifecycle, returning once it is committed.
+//
+// The tx's sequence is filled in if it is 0, from the client's sequence allocator if
+// it has one. It is then signed, prevalidated, submitted, and watched until it is
+// committed. If the API rejects the tx at any stage, the error is a *TxError; if the
+// tx leaves the mempool without being committed, that error satisfies IsDropped. If
+// it's still pending after the client's commit timeout, the error is ErrCommitTimeout.
+// Transient failures are returned as they are.
+func (c *Client) Execute(ctx context.Context, tx metatx.Transactable, s signer.Signer) (*routes.TxStatus, error) {
+	sa := c.sequences
+	seqr, ok := tx.(ndau.Sequencer)
+	if sa == nil || !ok || seqr.GetSequence() != 0 {
+		return c.execute(ctx, tx, s)
+	}
+
+	// the API knows which account a tx comes from
+	built, err := c.BuildContext(ctx, tx)
+	if apierr, ok := AsAPIError(err); ok && !apierr.Transient() {
+		return nil, rejection(tx, "", StageBuild, apierr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	source, err := address.Validate(built.Source)
+	if err != nil {
+		return nil, errors.Wrap(err, "validating tx source")
+	}
+
+	seq, err := sa.Reserve(source)
+	if err != nil {
+		return nil, errors.Wrap(err, "reserving sequence")
+	}
+	err = tool.SetSequence(tx, seq)
+	if err != nil {
+		sa.Release(source, seq)
+		return nil, err
+	}
+	status, err := c.execute(ctx, tx, s)
+	if err != nil {
+		// if the tx did reach the mempool after all, the allocator sees it
+		// there when it resynchronizes
+		sa.Release(source, seq)
+		return nil, err
+	}
+	sa.Confirm(source, seq)
+	return status, nil
+}
+
+func (c *Client) execute(ctx context.Context, tx metatx.Transactable, s signer.Signer) (*routes.TxStatus, error) {
+	signed, err := c.BuildAndSignContext(ctx, tx, s)
+	if apierr, ok := AsAPIError(err); ok && !apierr.Transient() {
+		return nil, rejection(tx, "", StageBuild, apierr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pv, err := c.prevalidate(ctx, signed.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if pv.Code != routes.EndpointResultTxAlreadyCommitted {
+		_, err = c.SendAsyncContext(ctx, signed.Tx)
+		if apierr, ok := AsAPIError(err); ok && !apierr.Transient() {
+			return nil, rejection(signed.Tx, signed.TxHash, StageSubmit, apierr)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.waitForCommit(ctx, signed)
+}
+
+// Execute runs a tx through its whole lifecycle, returning once it is committed.
+func Execute(ctx context.Context, node *Client, tx metatx.Transactable, s signer.Signer) (*routes.TxStatus, error) {
+	return node.Execute(ctx, tx, s)
+}
+
+func (c *Client) waitForCommit(ctx context.Context, signed *routes.AttachResult) (*routes.TxStatus, error) {
+	interval := c.pollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	timeout := c.commitTimeout
+	if timeout <= 0 {
+		timeout = DefaultCommitTimeout
+	}
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	txerr := func(dropped bool, errCode int, msg string) *TxError {
+		if errCode == 0 {
+			errCode = -1
+		}
+		return &TxError{
+			TxType:  metatx.NameOf(signed.Tx),
+			TxHash:  signed.TxHash,
+			Stage:   StageCommit,
+			ErrCode: errCode,
+			Msg:     msg,
+			Dropped: dropped,
+		}
+	}
+
+	unknown := 0
+	for {
+		status, err := c.TxStatusContext(ctx, signed.TxHash)
+		switch {
+		case IsNotFound(err):
+			// keep asking for a while, in case the status request reached a different
+			// API instance than the submission did
+			unknown++
+			if unknown >= maxUnknownPolls {
+				return nil, txerr(true, 0, "the API does not know of it")
+			}
+		case err != nil:
+			return nil, err
+		default:
+			unknown = 0
+			switch status.Status {
+			case routes.TxStatusCommitted:
+				return status, nil
+			case routes.TxStatusRejected:
+				return nil, txerr(false, status.ErrCode, status.Log)
+			case routes.TxStatusDropped:
+				return nil, txerr(true, 0, "it left the mempool without being committed")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "waiting for commit")
+		case <-deadline.C:
+			return nil, errors.Wrapf(ErrCommitTimeout, "%s %s", metatx.NameOf(signed.Tx), signed.TxHash)
+		case <-time.After(interval):
+		}
+	}
+}
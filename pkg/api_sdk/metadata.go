@@ -10,6 +10,8 @@ package sdk
 // - -- --- ---- -----
 
 import (
+	"context"
+
 	"github.com/ndau/ndau/pkg/ndauapi/routes"
 	"github.com/pkg/errors"
 )
@@ -24,14 +26,19 @@ func GetSummary(node *Client) (*routes.PriceInfo, error) {
 	return node.PriceInfo()
 }
 
-// Version delivers version information
-func (c *Client) Version() (version *routes.VersionResult, err error) {
+// VersionContext delivers version information
+func (c *Client) VersionContext(ctx context.Context) (version *routes.VersionResult, err error) {
 	version = new(routes.VersionResult)
-	err = c.get(version, c.URL("version"))
+	err = c.get(ctx, version, c.URL("version"))
 	err = errors.Wrap(err, "getting version from API")
 	return
 }
 
+// Version delivers version information
+func (c *Client) Version() (version *routes.VersionResult, err error) {
+	return c.VersionContext(compat)
+}
+
 // Version delivers version information
 func Version(node *Client) (*routes.VersionResult, error) {
 	return node.Version()
@@ -10,60 +10,154 @@ package sdk
 // - -- --- ---- -----
 
 import (
+	"context"
+
+	"github.com/ndau/ndau/pkg/ndau/search"
 	"github.com/ndau/ndau/pkg/ndauapi/routes"
+	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/pkg/errors"
 	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
-// Info gets the node's current status
-func (c *Client) Info() (status *rpctypes.ResultStatus, err error) {
+// InfoContext gets the node's current status
+func (c *Client) InfoContext(ctx context.Context) (status *rpctypes.ResultStatus, err error) {
 	status = new(rpctypes.ResultStatus)
-	err = c.get(status, c.URL("node/status"))
+	err = c.get(ctx, status, c.URL("node/status"))
 	err = errors.Wrap(err, "fetching node status from API")
 	return
 }
 
+// Info gets the node's current status
+func (c *Client) Info() (status *rpctypes.ResultStatus, err error) {
+	return c.InfoContext(compat)
+}
+
 // Info gets the node's current status
 func Info(node *Client) (status *rpctypes.ResultStatus, err error) {
 	return node.Info()
 }
 
-// Health is a simple check of a node's health
-func (c *Client) Health() (resp *routes.HealthResponse, err error) {
+// HealthContext is a simple check of a node's health
+func (c *Client) HealthContext(ctx context.Context) (resp *routes.HealthResponse, err error) {
 	resp = new(routes.HealthResponse)
-	err = c.get(resp, c.URL("node/health"))
+	err = c.get(ctx, resp, c.URL("node/health"))
 	err = errors.Wrap(err, "fetching node health from API")
 	return
 }
 
-// NetInfo returns the network information of the node
-func (c *Client) NetInfo() (ni *rpctypes.ResultNetInfo, err error) {
+// Health is a simple check of a node's health
+func (c *Client) Health() (resp *routes.HealthResponse, err error) {
+	return c.HealthContext(compat)
+}
+
+// NetInfoContext returns the network information of the node
+func (c *Client) NetInfoContext(ctx context.Context) (ni *rpctypes.ResultNetInfo, err error) {
 	ni = new(rpctypes.ResultNetInfo)
-	err = c.get(ni, c.URL("node/net"))
+	err = c.get(ctx, ni, c.URL("node/net"))
 	err = errors.Wrap(err, "fetching node net info from API")
 	return
 }
 
-// Genesis returns the genesis document of the node
-func (c *Client) Genesis() (g *rpctypes.ResultGenesis, err error) {
+// NetInfo returns the network information of the node
+func (c *Client) NetInfo() (ni *rpctypes.ResultNetInfo, err error) {
+	return c.NetInfoContext(compat)
+}
+
+// GenesisContext returns the genesis document of the node
+func (c *Client) GenesisContext(ctx context.Context) (g *rpctypes.ResultGenesis, err error) {
 	g = new(rpctypes.ResultGenesis)
-	err = c.get(g, c.URL("node/genesis"))
+	err = c.get(ctx, g, c.URL("node/genesis"))
 	err = errors.Wrap(err, "fetching node genesis document from API")
 	return
 }
 
-// ABCIInfo returns the node's ABCI data
-func (c *Client) ABCIInfo() (abci *rpctypes.ResultABCIInfo, err error) {
+// Genesis returns the genesis document of the node
+func (c *Client) Genesis() (g *rpctypes.ResultGenesis, err error) {
+	return c.GenesisContext(compat)
+}
+
+// ABCIInfoContext returns the node's ABCI data
+func (c *Client) ABCIInfoContext(ctx context.Context) (abci *rpctypes.ResultABCIInfo, err error) {
 	abci = new(rpctypes.ResultABCIInfo)
-	err = c.get(abci, c.URL("node/abci"))
+	err = c.get(ctx, abci, c.URL("node/abci"))
 	err = errors.Wrap(err, "fetching node ABCI info from API")
 	return
 }
 
-// Consensus return's the node's current Tendermint consensus state
-func (c *Client) Consensus() (cs *rpctypes.ResultConsensusState, err error) {
+// ABCIInfo returns the node's ABCI data
+func (c *Client) ABCIInfo() (abci *rpctypes.ResultABCIInfo, err error) {
+	return c.ABCIInfoContext(compat)
+}
+
+// ConsensusContext return's the node's current Tendermint consensus state
+func (c *Client) ConsensusContext(ctx context.Context) (cs *rpctypes.ResultConsensusState, err error) {
 	cs = new(rpctypes.ResultConsensusState)
-	err = c.get(cs, c.URL("node/consensus"))
+	err = c.get(ctx, cs, c.URL("node/consensus"))
 	err = errors.Wrap(err, "fetching node consensus state from API")
 	return
 }
+
+// Consensus return's the node's current Tendermint consensus state
+func (c *Client) Consensus() (cs *rpctypes.ResultConsensusState, err error) {
+	return c.ConsensusContext(compat)
+}
+
+// MempoolContext returns the number of txs waiting in the node's mempool
+//
+// If txs is set, the first of those txs are also described.
+func (c *Client) MempoolContext(ctx context.Context, txs bool) (mp *routes.MempoolResult, err error) {
+	mp = new(routes.MempoolResult)
+	p := params{}
+	if txs {
+		p["txs"] = "true"
+	}
+	err = c.get(ctx, mp, c.URLP(p, "node/mempool"))
+	err = errors.Wrap(err, "fetching mempool from API")
+	return
+}
+
+// Mempool returns the number of txs waiting in the node's mempool
+func (c *Client) Mempool(txs bool) (mp *routes.MempoolResult, err error) {
+	return c.MempoolContext(compat, txs)
+}
+
+// PendingTxsContext describes the txs from an account which are waiting in the node's mempool
+func (c *Client) PendingTxsContext(ctx context.Context, addr address.Address) (mp *routes.MempoolResult, err error) {
+	mp = new(routes.MempoolResult)
+	err = c.get(ctx, mp, c.URLP(params{"source": addr.String()}, "node/mempool"))
+	err = errors.Wrap(err, "fetching pending txs from API")
+	return
+}
+
+// PendingTxs describes the txs from an account which are waiting in the node's mempool
+func (c *Client) PendingTxs(addr address.Address) (mp *routes.MempoolResult, err error) {
+	return c.PendingTxsContext(compat, addr)
+}
+
+// NodeRewardHistoryContext returns the node reward nominations won by a node, and whether
+// and when each was claimed
+func (c *Client) NodeRewardHistoryContext(ctx context.Context, nrhparams search.NodeRewardHistoryParams) (*search.NodeRewardHistoryResponse, error) {
+	response := new(routes.NodeRewardHistory)
+	err := c.get(ctx, response, c.URLP(
+		params{"after": nrhparams.AfterHeight, "limit": nrhparams.Limit},
+		"node/rewards/%s", nrhparams.Address))
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching node reward history from API")
+	}
+	return &search.NodeRewardHistoryResponse{
+		Rewards: response.Items,
+		More:    response.Next != "",
+	}, nil
+}
+
+// NodeRewardHistory returns the node reward nominations won by a node, and whether
+// and when each was claimed
+func (c *Client) NodeRewardHistory(nrhparams search.NodeRewardHistoryParams) (*search.NodeRewardHistoryResponse, error) {
+	return c.NodeRewardHistoryContext(compat, nrhparams)
+}
+
+// NodeRewardHistory returns the node reward nominations won by a node, and whether
+// and when each was claimed
+func NodeRewardHistory(node *Client, params search.NodeRewardHistoryParams) (*search.NodeRewardHistoryResponse, error) {
+	return node.NodeRewardHistory(params)
+}
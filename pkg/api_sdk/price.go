@@ -10,6 +10,7 @@ package sdk
 // - -- --- ---- -----
 
 import (
+	"context"
 	"encoding/json"
 
 	srch "github.com/ndau/ndau/pkg/ndau/search"
@@ -17,25 +18,87 @@ import (
 	"github.com/pkg/errors"
 )
 
-// PriceInfo returns current price data for key parameters
-func (c *Client) PriceInfo() (info *routes.PriceInfo, err error) {
+// PriceInfoContext returns current price data for key parameters
+func (c *Client) PriceInfoContext(ctx context.Context) (info *routes.PriceInfo, err error) {
 	info = new(routes.PriceInfo)
-	err = c.get(info, c.URL("price/current"))
+	err = c.get(ctx, info, c.URL("price/current"))
 	err = errors.Wrap(err, "fetching price info from API")
 	return
 }
 
+// PriceInfo returns current price data for key parameters
+func (c *Client) PriceInfo() (info *routes.PriceInfo, err error) {
+	return c.PriceInfoContext(compat)
+}
+
+// TargetPriceHistoryContext returns historical target price data
+func (c *Client) TargetPriceHistoryContext(ctx context.Context, params srch.PriceQueryParams) ([]srch.PriceQueryResult, error) {
+	return c.priceHistory(ctx, c.URL("price/target/history"), params)
+}
+
 // TargetPriceHistory returns historical target price data
 func (c *Client) TargetPriceHistory(params srch.PriceQueryParams) ([]srch.PriceQueryResult, error) {
-	return c.priceHistory(c.URL("price/target/history"), params)
+	return c.TargetPriceHistoryContext(compat, params)
+}
+
+// MarketPriceHistoryContext returns historical market price data
+func (c *Client) MarketPriceHistoryContext(ctx context.Context, params srch.PriceQueryParams) ([]srch.PriceQueryResult, error) {
+	return c.priceHistory(ctx, c.URL("price/market/history"), params)
 }
 
 // MarketPriceHistory returns historical market price data
 func (c *Client) MarketPriceHistory(params srch.PriceQueryParams) ([]srch.PriceQueryResult, error) {
-	return c.priceHistory(c.URL("price/market/history"), params)
+	return c.MarketPriceHistoryContext(compat, params)
+}
+
+// EndowmentNAVHistoryContext returns historical endowment NAV data
+func (c *Client) EndowmentNAVHistoryContext(ctx context.Context, params srch.PriceQueryParams) ([]srch.PriceQueryResult, error) {
+	return c.priceHistory(ctx, c.URL("price/nav/history"), params)
+}
+
+// EndowmentNAVHistory returns historical endowment NAV data
+func (c *Client) EndowmentNAVHistory(params srch.PriceQueryParams) ([]srch.PriceQueryResult, error) {
+	return c.EndowmentNAVHistoryContext(compat, params)
+}
+
+// SIBHistoryContext returns historical SIB and floor price data
+func (c *Client) SIBHistoryContext(ctx context.Context, params srch.PriceQueryParams) ([]srch.SIBQueryResult, error) {
+	var history []srch.SIBQueryResult
+
+	// iteration proceeds while response next field is encoded params
+	pdata, err := json.Marshal(params)
+	if err != nil {
+		return history, errors.Wrap(err, "marshaling initial params")
+	}
+
+	response := routes.SIBHistoryResults{
+		Next: string(pdata),
+	}
+
+	for response.Next != "" {
+		// unmarshal params to get next page
+		err = json.Unmarshal([]byte(response.Next), &params)
+		if err != nil {
+			return history, errors.Wrap(err, "unmarshaling next params from api")
+		}
+
+		// perform next query
+		err = c.post(ctx, params, &response, c.URL("price/sib/history"))
+		history = append(history, response.Items...)
+		if err != nil {
+			return history, errors.Wrap(err, "fetching history from api")
+		}
+	}
+
+	return history, nil
+}
+
+// SIBHistory returns historical SIB and floor price data
+func (c *Client) SIBHistory(params srch.PriceQueryParams) ([]srch.SIBQueryResult, error) {
+	return c.SIBHistoryContext(compat, params)
 }
 
-func (c *Client) priceHistory(endpoint string, params srch.PriceQueryParams) ([]srch.PriceQueryResult, error) {
+func (c *Client) priceHistory(ctx context.Context, endpoint string, params srch.PriceQueryParams) ([]srch.PriceQueryResult, error) {
 	var history []srch.PriceQueryResult
 
 	// iteration proceeds while response next field is encoded params
@@ -58,7 +121,7 @@ func (c *Client) priceHistory(endpoint string, params srch.PriceQueryParams) ([]
 		}
 
 		// perform next query
-		err := c.post(params, &response, c.URL("price/target/history"))
+		err := c.post(ctx, params, &response, endpoint)
 		history = append(history, response.Items...)
 		if err != nil {
 			return history, errors.Wrap(err, "fetching history from api")
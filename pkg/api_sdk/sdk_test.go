@@ -11,25 +11,33 @@ package sdk_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/ndau/metanode/pkg/meta/app/code"
 	metast "github.com/ndau/metanode/pkg/meta/state"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
 	sdk "github.com/ndau/ndau/pkg/api_sdk"
 	"github.com/ndau/ndau/pkg/ndau"
 	"github.com/ndau/ndau/pkg/ndau/backing"
 	"github.com/ndau/ndau/pkg/ndau/search"
 	"github.com/ndau/ndau/pkg/ndauapi/mock"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
 	"github.com/ndau/ndau/pkg/ndauapi/routes"
 	"github.com/ndau/ndau/pkg/ndauapi/svc"
+	"github.com/ndau/ndau/pkg/signer"
 	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/ndau/ndaumath/pkg/key"
 	"github.com/ndau/ndaumath/pkg/signature"
 	math "github.com/ndau/ndaumath/pkg/types"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 )
@@ -136,6 +144,27 @@ func TestGetAccount(t *testing.T) {
 	}, addr)
 }
 
+func TestGetAccounts(t *testing.T) {
+	addr := makeAddress(t)
+	setup(t, func(client *sdk.Client) {
+		accounts, err := client.GetAccounts([]address.Address{addr})
+		require.NoError(t, err)
+		require.Contains(t, accounts, addr.String())
+	}, addr)
+}
+
+func TestGetAccountNotFound(t *testing.T) {
+	setup(t, func(client *sdk.Client) {
+		_, err := client.GetAccountContext(context.Background(), makeAddress(t))
+		require.True(t, sdk.IsNotFound(err), "expected not found; got %v", err)
+
+		// the method without a context reports a missing account as nil data
+		ad, err := client.GetAccount(makeAddress(t))
+		require.NoError(t, err)
+		require.Nil(t, ad)
+	})
+}
+
 func TestGetAccountHistory(t *testing.T) {
 	setup(t, func(client *sdk.Client) {
 		_, err := client.GetAccountHistory(search.AccountHistoryParams{
@@ -208,6 +237,13 @@ func TestGetCurrencySeats(t *testing.T) {
 	})
 }
 
+func TestGetCurrencySeatDetails(t *testing.T) {
+	setup(t, func(client *sdk.Client) {
+		_, err := client.GetCurrencySeatDetails()
+		require.NoError(t, err)
+	})
+}
+
 func TestGetCurrentBlock(t *testing.T) {
 	setup(t, func(client *sdk.Client) {
 		_, err := client.GetCurrentBlock()
@@ -243,6 +279,19 @@ func TestInfo(t *testing.T) {
 	})
 }
 
+func TestMempool(t *testing.T) {
+	setup(t, func(client *sdk.Client) {
+		mp, err := client.MempoolContext(context.Background(), true)
+		require.NoError(t, err)
+		require.Equal(t, 0, mp.Count)
+		require.Empty(t, mp.Txs)
+
+		mp, err = client.PendingTxsContext(context.Background(), makeAddress(t))
+		require.NoError(t, err)
+		require.Empty(t, mp.Txs)
+	})
+}
+
 func TestNetInfo(t *testing.T) {
 	setup(t, func(client *sdk.Client) {
 		_, err := client.NetInfo()
@@ -257,6 +306,136 @@ func TestPrevalidate(t *testing.T) {
 	})
 }
 
+func TestExecuteRejected(t *testing.T) {
+	source := makeAddress(t)
+	_, private, err := signature.Generate(signature.Ed25519, nil)
+	require.NoError(t, err)
+	setup(t, func(client *sdk.Client) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		// the source has no validation keys, so no signature can satisfy it
+		_, err := client.Transfer(ctx, source, makeAddress(t), 1, signer.NewLocal(private))
+		require.Error(t, err)
+		_, ok := sdk.AsTxError(err)
+		require.True(t, ok, "expected a TxError; got %v", err)
+	}, source)
+}
+
+// fakeLifecycleAPI accepts SetValidation txs, and then reports their status as given.
+// A zero status means the API doesn't know of the tx. If submitCode isn't OK, the node
+// rejects the txs when they're submitted instead.
+func fakeLifecycleAPI(t *testing.T, status string, submitCode code.ReturnCode) *httptest.Server {
+	mux := http.NewServeMux()
+	respond := func(w http.ResponseWriter, sts int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(sts)
+		require.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+	mux.HandleFunc("/tx/build/SetValidation", func(w http.ResponseWriter, r *http.Request) {
+		tx := new(ndau.SetValidation)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(tx))
+		respond(w, http.StatusOK, routes.BuildResult{
+			TxType:        "SetValidation",
+			Tx:            tx,
+			Source:        tx.Target.String(),
+			SignableBytes: base64.StdEncoding.EncodeToString(tx.SignableBytes()),
+		})
+	})
+	mux.HandleFunc("/tx/prevalidate/SetValidation", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, http.StatusOK, routes.PrevalidateResult{Code: routes.EndpointResultOK})
+	})
+	mux.HandleFunc("/tx/submitasync/SetValidation", func(w http.ResponseWriter, r *http.Request) {
+		if submitCode != code.OK {
+			respond(w, http.StatusBadRequest, reqres.ErrorBody{
+				Message: "error from checktx",
+				Log:     "nope",
+				ErrCode: int(submitCode),
+			})
+			return
+		}
+		respond(w, http.StatusAccepted, routes.SubmitResult{Code: routes.EndpointResultOK})
+	})
+	mux.HandleFunc("/tx/status/", func(w http.ResponseWriter, r *http.Request) {
+		if status == "" {
+			respond(w, http.StatusNotFound, map[string]string{"msg": "unknown tx"})
+			return
+		}
+		respond(w, http.StatusOK, routes.TxStatus{Status: status})
+	})
+	return httptest.NewServer(mux)
+}
+
+// executeSetValidation executes a SetValidation tx through a fake lifecycle API
+func executeSetValidation(t *testing.T, server *httptest.Server) error {
+	target := makeAddress(t)
+	ownership, ownershipPrivate, err := signature.Generate(signature.Ed25519, nil)
+	require.NoError(t, err)
+
+	client, err := sdk.NewClient(server.URL)
+	require.NoError(t, err)
+	client.SetPollInterval(time.Millisecond)
+	client.SetCommitTimeout(time.Second)
+	_, err = client.SetValidation(
+		context.Background(), target, ownership, nil, nil,
+		signer.NewLocal(ownershipPrivate),
+	)
+	return err
+}
+
+func TestExecuteRejectedOnSubmission(t *testing.T) {
+	server := fakeLifecycleAPI(t, routes.TxStatusPending, code.InvalidTransaction)
+	defer server.Close()
+	err := executeSetValidation(t, server)
+	txerr, ok := sdk.AsTxError(err)
+	require.True(t, ok, "expected a TxError; got %v", err)
+	require.Equal(t, sdk.StageSubmit, txerr.Stage)
+
+	// with the node's code
+	rc, ok := txerr.Code()
+	require.True(t, ok)
+	require.Equal(t, code.InvalidTransaction, rc)
+}
+
+func TestExecuteDropped(t *testing.T) {
+	t.Run("dropped", func(t *testing.T) {
+		server := fakeLifecycleAPI(t, routes.TxStatusDropped, code.OK)
+		defer server.Close()
+		err := executeSetValidation(t, server)
+		require.True(t, sdk.IsDropped(err), "expected a dropped tx; got %v", err)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		server := fakeLifecycleAPI(t, "", code.OK)
+		defer server.Close()
+		err := executeSetValidation(t, server)
+		require.True(t, sdk.IsDropped(err), "expected a dropped tx; got %v", err)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		server := fakeLifecycleAPI(t, routes.TxStatusPending, code.OK)
+		defer server.Close()
+		err := executeSetValidation(t, server)
+		require.Equal(t, sdk.ErrCommitTimeout, errors.Cause(err))
+	})
+}
+
+func TestBuildAndSignSetValidation(t *testing.T) {
+	target := makeAddress(t)
+	ownership, ownershipPrivate, err := signature.Generate(signature.Ed25519, nil)
+	require.NoError(t, err)
+	validation, _, err := signature.Generate(signature.Ed25519, nil)
+	require.NoError(t, err)
+	setup(t, func(client *sdk.Client) {
+		tx := ndau.NewSetValidation(target, ownership, []signature.PublicKey{validation}, nil, 0)
+		signed, err := client.BuildAndSignContext(context.Background(), tx, signer.NewLocal(ownershipPrivate))
+		require.NoError(t, err)
+		sv, ok := signed.Tx.(*ndau.SetValidation)
+		require.True(t, ok, "expected a SetValidation; got %T", signed.Tx)
+		require.True(t, sv.Signature.Verify(sv.SignableBytes(), ownership))
+		require.Equal(t, metatx.Hash(sv), signed.TxHash)
+	}, target)
+}
+
 func TestPriceInfo(t *testing.T) {
 	setup(t, func(client *sdk.Client) {
 		_, err := client.PriceInfo()
@@ -267,6 +446,41 @@ func TestPriceInfo(t *testing.T) {
 // price history is not tested here because it requires a full stack, which
 // is not available in this testing context.
 
+func TestRetry(t *testing.T) {
+	failures := 2
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"msg":"try again"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := sdk.NewClient(server.URL)
+	require.NoError(t, err)
+	client.SetRetryPolicy(sdk.RetryPolicy{Attempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	_, err = client.HealthContext(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	// give up after the configured attempts, reporting the API's error
+	attempts = 0
+	failures = 3
+	client.SetRetryPolicy(sdk.RetryPolicy{Attempts: 2, Backoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	_, err = client.HealthContext(context.Background())
+	require.Error(t, err)
+	require.Equal(t, 2, attempts)
+	apierr, ok := sdk.AsAPIError(err)
+	require.True(t, ok, "expected an APIError; got %v", err)
+	require.Equal(t, http.StatusServiceUnavailable, apierr.Status)
+	require.Equal(t, "try again", apierr.Msg)
+}
+
 func TestSend(t *testing.T) {
 	setup(t, func(client *sdk.Client) {
 		_, err := client.Send(ndau.NewIssue(1, 1))
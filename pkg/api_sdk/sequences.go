@@ -0,0 +1,58 @@
+package sdk
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"context"
+
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/address"
+)
+
+// clientSequences is a tool.SequenceSource which asks the API
+type clientSequences struct {
+	c *Client
+}
+
+// Sequences implements tool.SequenceSource
+func (cs clientSequences) Sequences(addr address.Address) (committed, pending uint64, err error) {
+	ctx := context.Background()
+	committed, err = cs.c.GetSequenceContext(ctx, addr)
+	if err != nil {
+		return 0, 0, err
+	}
+	mp, err := cs.c.PendingTxsContext(ctx, addr)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, tx := range mp.Txs {
+		if tx.Sequence > pending {
+			pending = tx.Sequence
+		}
+	}
+	return committed, pending, nil
+}
+
+// SequenceSource returns a tool.SequenceSource which learns sequences from the API
+func (c *Client) SequenceSource() tool.SequenceSource {
+	return clientSequences{c: c}
+}
+
+// SetSequenceAllocator makes Execute take sequences from sa, so that several txs
+// from the same account can be executed at once.
+//
+// Without an allocator, Execute lets the API fill in the next sequence, which is the
+// same for every tx built before the previous one is committed. A nil sa restores
+// that behavior. An allocator may be shared between clients of the same network:
+//
+//	client.SetSequenceAllocator(tool.NewSequenceAllocator(client.SequenceSource()))
+func (c *Client) SetSequenceAllocator(sa *tool.SequenceAllocator) {
+	c.sequences = sa
+}
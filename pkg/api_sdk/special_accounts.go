@@ -10,31 +10,65 @@ package sdk
 // - -- --- ---- -----
 
 import (
+	"context"
+
+	"github.com/ndau/ndau/pkg/query"
 	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/pkg/errors"
 )
 
-// GetCurrencySeats gets a list of ndau currency seats
-func (c *Client) GetCurrencySeats() (seats []address.Address, err error) {
+// GetCurrencySeatsContext gets a list of ndau currency seats, oldest first
+func (c *Client) GetCurrencySeatsContext(ctx context.Context) (seats []address.Address, err error) {
 	seats = make([]address.Address, 0)
-	err = c.get(&seats, c.URL("account/currencyseats"))
+	err = c.get(ctx, &seats, c.URL("account/currencyseats"))
 	err = errors.Wrap(err, "getting currency seats from API")
 	return
 }
 
-// GetCurrencySeats gets a list of ndau currency seats
+// GetCurrencySeats gets a list of ndau currency seats, oldest first
+func (c *Client) GetCurrencySeats() (seats []address.Address, err error) {
+	return c.GetCurrencySeatsContext(compat)
+}
+
+// GetCurrencySeats gets a list of ndau currency seats, oldest first
 func GetCurrencySeats(node *Client) ([]address.Address, error) {
 	return node.GetCurrencySeats()
 }
 
-// GetDelegates gets the set of nodes with delegates, and the list of accounts delegated to each
-func (c *Client) GetDelegates() (delegates map[address.Address][]address.Address, err error) {
+// GetCurrencySeatDetailsContext gets a list of ndau currency seats, oldest first, with
+// the dates they were acquired and their balances
+func (c *Client) GetCurrencySeatDetailsContext(ctx context.Context) (seats query.CurrencySeatsResponse, err error) {
+	seats = make(query.CurrencySeatsResponse, 0)
+	err = c.get(ctx, &seats, c.URL("account/currencyseats/details"))
+	err = errors.Wrap(err, "getting currency seat details from API")
+	return
+}
+
+// GetCurrencySeatDetails gets a list of ndau currency seats, oldest first, with
+// the dates they were acquired and their balances
+func (c *Client) GetCurrencySeatDetails() (seats query.CurrencySeatsResponse, err error) {
+	return c.GetCurrencySeatDetailsContext(compat)
+}
+
+// GetCurrencySeatDetails gets a list of ndau currency seats, oldest first, with
+// the dates they were acquired and their balances
+func GetCurrencySeatDetails(node *Client) (query.CurrencySeatsResponse, error) {
+	return node.GetCurrencySeatDetails()
+}
+
+// GetDelegatesContext gets the set of nodes with delegates, and the list of accounts delegated to each
+func (c *Client) GetDelegatesContext(ctx context.Context) (delegates map[address.Address][]address.Address, err error) {
 	delegates = make(map[address.Address][]address.Address)
-	err = c.get(&delegates, c.URL("state/delegates"))
+	err = c.get(ctx, &delegates, c.URL("state/delegates"))
 	err = errors.Wrap(err, "getting delegates from API")
 	return
 }
 
+// GetDelegates gets the set of nodes with delegates, and the list of accounts delegated to each
+func (c *Client) GetDelegates() (delegates map[address.Address][]address.Address, err error) {
+	return c.GetDelegatesContext(compat)
+}
+
 // GetDelegates gets the set of nodes with delegates, and the list of accounts delegated to each
 func GetDelegates(node *Client) (map[address.Address][]address.Address, error) {
 	return node.GetDelegates()
@@ -0,0 +1,111 @@
+package sdk
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"context"
+	"encoding/json"
+
+	srch "github.com/ndau/ndau/pkg/ndau/search"
+	"github.com/ndau/ndau/pkg/ndauapi/routes"
+	"github.com/pkg/errors"
+)
+
+// SupplyHistoryContext returns historical supply data
+func (c *Client) SupplyHistoryContext(ctx context.Context, params srch.SupplyQueryParams) ([]srch.SupplyQueryResult, error) {
+	var history []srch.SupplyQueryResult
+
+	// iteration proceeds while response next field is encoded params
+	pdata, err := json.Marshal(params)
+	if err != nil {
+		return history, errors.Wrap(err, "marshaling initial params")
+	}
+
+	response := routes.SupplyHistoryResults{
+		Next: string(pdata),
+	}
+
+	for response.Next != "" {
+		// unmarshal params to get next page
+		err = json.Unmarshal([]byte(response.Next), &params)
+		if err != nil {
+			return history, errors.Wrap(err, "unmarshaling next params from api")
+		}
+
+		// perform next query
+		err = c.post(ctx, params, &response, c.URL("state/supply/history"))
+		history = append(history, response.Items...)
+		if err != nil {
+			return history, errors.Wrap(err, "fetching history from api")
+		}
+	}
+
+	return history, nil
+}
+
+// SupplyHistory returns historical supply data
+func (c *Client) SupplyHistory(params srch.SupplyQueryParams) ([]srch.SupplyQueryResult, error) {
+	return c.SupplyHistoryContext(compat, params)
+}
+
+// SupplyHistory returns historical supply data
+func SupplyHistory(node *Client, params srch.SupplyQueryParams) ([]srch.SupplyQueryResult, error) {
+	return node.SupplyHistory(params)
+}
+
+// UnlocksContext returns the scheduled unlocks within a range of unlock times, along with
+// their per-day totals
+func (c *Client) UnlocksContext(ctx context.Context, params srch.UnlocksQueryParams) ([]srch.UnlockValueData, []srch.UnlockDayTotal, error) {
+	var (
+		unlocks []srch.UnlockValueData
+		days    []srch.UnlockDayTotal
+	)
+
+	// iteration proceeds while response next field is encoded params
+	pdata, err := json.Marshal(params)
+	if err != nil {
+		return unlocks, days, errors.Wrap(err, "marshaling initial params")
+	}
+
+	response := routes.UnlocksResults{
+		Next: string(pdata),
+	}
+
+	for response.Next != "" {
+		// unmarshal params to get next page
+		err = json.Unmarshal([]byte(response.Next), &params)
+		if err != nil {
+			return unlocks, days, errors.Wrap(err, "unmarshaling next params from api")
+		}
+
+		// perform next query; every page has the same daily totals
+		response = routes.UnlocksResults{}
+		err = c.post(ctx, params, &response, c.URL("state/unlocks"))
+		unlocks = append(unlocks, response.Items...)
+		days = response.Days
+		if err != nil {
+			return unlocks, days, errors.Wrap(err, "fetching unlocks from api")
+		}
+	}
+
+	return unlocks, days, nil
+}
+
+// Unlocks returns the scheduled unlocks within a range of unlock times, along with
+// their per-day totals
+func (c *Client) Unlocks(params srch.UnlocksQueryParams) ([]srch.UnlockValueData, []srch.UnlockDayTotal, error) {
+	return c.UnlocksContext(compat, params)
+}
+
+// Unlocks returns the scheduled unlocks within a range of unlock times, along with
+// their per-day totals
+func Unlocks(node *Client, params srch.UnlocksQueryParams) ([]srch.UnlockValueData, []srch.UnlockDayTotal, error) {
+	return node.Unlocks(params)
+}
@@ -10,6 +10,7 @@ package sdk
 // - -- --- ---- -----
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -19,8 +20,8 @@ import (
 	"github.com/tinylib/msgp/msgp"
 )
 
-// Sysvars gets the list of requested system variables, marshaled
-func (c *Client) Sysvars(vars ...string) (svs map[string][]byte, err error) {
+// SysvarsContext gets the list of requested system variables, marshaled
+func (c *Client) SysvarsContext(ctx context.Context, vars ...string) (svs map[string][]byte, err error) {
 	svsj := make(map[string]interface{})
 	var url string
 	if len(vars) == 0 {
@@ -28,7 +29,7 @@ func (c *Client) Sysvars(vars ...string) (svs map[string][]byte, err error) {
 	} else {
 		url = c.URL("system/get/%s", strings.Join(vars, ","))
 	}
-	err = c.get(&svsj, url)
+	err = c.get(ctx, &svsj, url)
 	if err != nil {
 		err = errors.Wrap(err, "getting sysvars")
 		return
@@ -46,16 +47,21 @@ func (c *Client) Sysvars(vars ...string) (svs map[string][]byte, err error) {
 	return
 }
 
+// Sysvars gets the list of requested system variables, marshaled
+func (c *Client) Sysvars(vars ...string) (svs map[string][]byte, err error) {
+	return c.SysvarsContext(compat, vars...)
+}
+
 // Sysvars gets the list of requested system variables, marshaled
 func Sysvars(node *Client, vars ...string) (map[string][]byte, error) {
 	return node.Sysvars(vars...)
 }
 
-// Sysvar gets a single system variable given its name and an example of its type
+// SysvarContext gets a single system variable given its name and an example of its type
 //
 // The example is populated with the appropriate data
-func (c *Client) Sysvar(name string, example msgp.Unmarshaler) error {
-	svs, err := c.Sysvars(name)
+func (c *Client) SysvarContext(ctx context.Context, name string, example msgp.Unmarshaler) error {
+	svs, err := c.SysvarsContext(ctx, name)
 	if err != nil {
 		return errors.Wrap(err, "getting system variable")
 	}
@@ -67,6 +73,11 @@ func (c *Client) Sysvar(name string, example msgp.Unmarshaler) error {
 	return errors.Wrap(err, "unmarshaling")
 }
 
+// Sysvar gets a single system variable given its name and an example of its type
+func (c *Client) Sysvar(name string, example msgp.Unmarshaler) error {
+	return c.SysvarContext(compat, name, example)
+}
+
 // Sysvar gets a single system variable given its name and an example of its type
 //
 // The example is populated with the appropriate data
@@ -74,12 +85,12 @@ func Sysvar(node *Client, name string, example msgp.Unmarshaler) error {
 	return node.Sysvar(name, example)
 }
 
-// SysvarHistory gets the value history of the given sysvar.
+// SysvarHistoryContext gets the value history of the given sysvar.
 //
 // Pass in 0,0 for the paging params to get the entire history.
-func (c *Client) SysvarHistory(name string, after uint64, limit int) (resp *query.SysvarHistoryResponse, err error) {
+func (c *Client) SysvarHistoryContext(ctx context.Context, name string, after uint64, limit int) (resp *query.SysvarHistoryResponse, err error) {
 	resp = new(query.SysvarHistoryResponse)
-	err = c.get(resp, c.URLP(
+	err = c.get(ctx, resp, c.URLP(
 		params{"after": after, "limit": limit},
 		"system/history/%s", name,
 	))
@@ -87,6 +98,11 @@ func (c *Client) SysvarHistory(name string, after uint64, limit int) (resp *quer
 	return
 }
 
+// SysvarHistory gets the value history of the given sysvar.
+func (c *Client) SysvarHistory(name string, after uint64, limit int) (resp *query.SysvarHistoryResponse, err error) {
+	return c.SysvarHistoryContext(compat, name, after, limit)
+}
+
 // SysvarHistory gets the value history of the given sysvar.
 //
 // Pass in 0,0 for the paging params to get the entire history.
@@ -10,39 +10,230 @@ package sdk
 // - -- --- ---- -----
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
 	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
 	"github.com/ndau/ndau/pkg/ndauapi/routes"
+	"github.com/ndau/ndau/pkg/signer"
+	"github.com/ndau/ndaumath/pkg/signature"
 	math "github.com/ndau/ndaumath/pkg/types"
 	"github.com/pkg/errors"
 )
 
-// Prevalidate prevalidates the provided transactable
-func (c *Client) Prevalidate(tx metatx.Transactable) (fee math.Ndau, sib math.Ndau, err error) {
+// prevalidate returns the API's prevalidation result
+//
+// A tx which fails prevalidation is reported as a *TxError.
+func (c *Client) prevalidate(ctx context.Context, tx metatx.Transactable) (*routes.PrevalidateResult, error) {
 	result := new(routes.PrevalidateResult)
-	err = c.post(tx, result, c.URL("tx/prevalidate/%s", metatx.NameOf(tx)))
-	if err != nil {
-		err = errors.Wrap(err, "prevalidating")
-		return
+	err := c.post(ctx, tx, result, c.URL("tx/prevalidate/%s", metatx.NameOf(tx)))
+	if result.Err != "" {
+		return result, &TxError{
+			TxType:  metatx.NameOf(tx),
+			TxHash:  result.TxHash,
+			Stage:   StagePrevalidate,
+			ErrCode: result.ErrCode,
+			Msg:     result.Err,
+		}
 	}
+	return result, errors.Wrap(err, "prevalidating")
+}
+
+// PrevalidateContext prevalidates the provided transactable
+//
+// The fee and SIB are returned even if the tx fails prevalidation, so long as the
+// node could compute them.
+func (c *Client) PrevalidateContext(ctx context.Context, tx metatx.Transactable) (fee math.Ndau, sib math.Ndau, err error) {
+	result, err := c.prevalidate(ctx, tx)
 	fee = math.Ndau(result.FeeNapu)
 	sib = math.Ndau(result.SibNapu)
 	return
 }
 
+// Prevalidate prevalidates the provided transactable
+func (c *Client) Prevalidate(tx metatx.Transactable) (fee math.Ndau, sib math.Ndau, err error) {
+	fee, sib, err = c.PrevalidateContext(compat, tx)
+	if _, ok := AsTxError(err); ok {
+		err = nil
+	}
+	return
+}
+
 // Prevalidate prevalidates the provided transactable
 func Prevalidate(node *Client, tx metatx.Transactable) (fee math.Ndau, sib math.Ndau, err error) {
 	return node.Prevalidate(tx)
 }
 
-// Send broadcasts and commits a transaction
-func (c *Client) Send(tx metatx.Transactable) (result *routes.SubmitResult, err error) {
+// SendContext broadcasts and commits a transaction
+func (c *Client) SendContext(ctx context.Context, tx metatx.Transactable) (result *routes.SubmitResult, err error) {
 	result = new(routes.SubmitResult)
-	err = c.post(tx, result, c.URL("tx/submit/%s", metatx.NameOf(tx)))
+	err = c.post(ctx, tx, result, c.URL("tx/submit/%s", metatx.NameOf(tx)))
 	err = errors.Wrap(err, "submitting")
 	return
 }
 
+// Send broadcasts and commits a transaction
+func (c *Client) Send(tx metatx.Transactable) (result *routes.SubmitResult, err error) {
+	return c.SendContext(compat, tx)
+}
+
 // SendCommit broadcasts and commits a transaction
 func SendCommit(node *Client, tx metatx.Transactable) (result *routes.SubmitResult, err error) {
 	return node.Send(tx)
 }
+
+// SendAsyncContext broadcasts a transaction without waiting for it to be committed
+func (c *Client) SendAsyncContext(ctx context.Context, tx metatx.Transactable) (result *routes.SubmitResult, err error) {
+	result = new(routes.SubmitResult)
+	err = c.post(ctx, tx, result, c.URL("tx/submitasync/%s", metatx.NameOf(tx)))
+	err = errors.Wrap(err, "submitting async")
+	return
+}
+
+// SendAsync broadcasts a transaction without waiting for it to be committed
+func (c *Client) SendAsync(tx metatx.Transactable) (result *routes.SubmitResult, err error) {
+	return c.SendAsyncContext(compat, tx)
+}
+
+// SendAsync broadcasts a transaction without waiting for it to be committed
+func SendAsync(node *Client, tx metatx.Transactable) (result *routes.SubmitResult, err error) {
+	return node.SendAsync(tx)
+}
+
+// TxStatusContext reports whether a transaction is pending, committed, or rejected
+func (c *Client) TxStatusContext(ctx context.Context, txhash string) (status *routes.TxStatus, err error) {
+	status = new(routes.TxStatus)
+	err = c.get(ctx, status, c.URL("tx/status/%s", txhash))
+	err = errors.Wrap(err, "getting tx status")
+	return
+}
+
+// TxStatus reports whether a transaction is pending, committed, or rejected
+func (c *Client) TxStatus(txhash string) (status *routes.TxStatus, err error) {
+	return c.TxStatusContext(compat, txhash)
+}
+
+// TxStatus reports whether a transaction is pending, committed, or rejected
+func TxStatus(node *Client, txhash string) (status *routes.TxStatus, err error) {
+	return node.TxStatus(txhash)
+}
+
+// BuildContext completes a partial transaction, filling in its sequence, and returns the
+// completed transaction, its estimated fee and SIB, and the bytes to sign
+func (c *Client) BuildContext(ctx context.Context, tx metatx.Transactable) (result *routes.BuildResult, err error) {
+	// decode the completed tx into a fresh tx of the same type
+	completed, err := ndau.TxFromName(metatx.NameOf(tx))
+	if err != nil {
+		return
+	}
+	result = &routes.BuildResult{Tx: completed}
+	err = c.post(ctx, tx, result, c.URL("tx/build/%s", metatx.NameOf(tx)))
+	err = errors.Wrap(err, "building tx")
+	return
+}
+
+// Build completes a partial transaction, filling in its sequence, and returns the
+// completed transaction, its estimated fee and SIB, and the bytes to sign
+func (c *Client) Build(tx metatx.Transactable) (result *routes.BuildResult, err error) {
+	return c.BuildContext(compat, tx)
+}
+
+// Build completes a partial transaction, filling in its sequence, and returns the
+// completed transaction, its estimated fee and SIB, and the bytes to sign
+func Build(node *Client, tx metatx.Transactable) (result *routes.BuildResult, err error) {
+	return node.Build(tx)
+}
+
+// AttachContext adds detached signatures to a transaction, returning a submit-ready transaction
+func (c *Client) AttachContext(ctx context.Context, tx metatx.Transactable, sigs []signature.Signature) (result *routes.AttachResult, err error) {
+	txj, err := json.Marshal(tx)
+	if err != nil {
+		err = errors.Wrap(err, "marshaling tx")
+		return
+	}
+	req := routes.AttachRequest{
+		TxType:     metatx.NameOf(tx),
+		Tx:         txj,
+		Signatures: sigs,
+	}
+	// decode the signed tx into a fresh tx of the same type
+	signed, err := ndau.TxFromName(req.TxType)
+	if err != nil {
+		return
+	}
+	result = &routes.AttachResult{Tx: signed}
+	err = c.post(ctx, req, result, c.URL("tx/attach"))
+	err = errors.Wrap(err, "attaching signatures")
+	return
+}
+
+// Attach adds detached signatures to a transaction, returning a submit-ready transaction
+func (c *Client) Attach(tx metatx.Transactable, sigs []signature.Signature) (result *routes.AttachResult, err error) {
+	return c.AttachContext(compat, tx, sigs)
+}
+
+// Attach adds detached signatures to a transaction, returning a submit-ready transaction
+func Attach(node *Client, tx metatx.Transactable, sigs []signature.Signature) (result *routes.AttachResult, err error) {
+	return node.Attach(tx, sigs)
+}
+
+// BuildAndSignContext completes a partial transaction and signs it with the given signer,
+// returning a submit-ready transaction
+//
+// The signer only ever sees the tx's signable bytes, so it may be remote.
+func (c *Client) BuildAndSignContext(ctx context.Context, tx metatx.Transactable, s signer.Signer) (result *routes.AttachResult, err error) {
+	built, err := c.BuildContext(ctx, tx)
+	if err != nil {
+		return
+	}
+
+	// sign what we can see we're signing, not just what the API says to
+	signable := built.Tx.SignableBytes()
+	claimed, err := base64.StdEncoding.DecodeString(built.SignableBytes)
+	if err != nil {
+		err = errors.Wrap(err, "decoding signable bytes")
+		return
+	}
+	if !bytes.Equal(signable, claimed) {
+		err = errors.New("API returned signable bytes which don't match the built tx")
+		return
+	}
+
+	// a SetValidation tx is signed by its ownership key alone, in a field of its own,
+	// so the API can't attach its signature
+	if sv, ok := built.Tx.(*ndau.SetValidation); ok {
+		sv.Signature, err = signer.SignOne(signable, s)
+		if err != nil {
+			err = errors.Wrap(err, "signing tx")
+			return
+		}
+		result = &routes.AttachResult{
+			TxType: metatx.NameOf(sv),
+			Tx:     sv,
+			TxHash: metatx.Hash(sv),
+		}
+		return
+	}
+
+	sigs, err := s.Sign(signable)
+	if err != nil {
+		err = errors.Wrap(err, "signing tx")
+		return
+	}
+	return c.AttachContext(ctx, built.Tx, sigs)
+}
+
+// BuildAndSign completes a partial transaction and signs it with the given signer,
+// returning a submit-ready transaction
+func (c *Client) BuildAndSign(tx metatx.Transactable, s signer.Signer) (result *routes.AttachResult, err error) {
+	return c.BuildAndSignContext(compat, tx, s)
+}
+
+// BuildAndSign completes a partial transaction and signs it with the given signer,
+// returning a submit-ready transaction
+func BuildAndSign(node *Client, tx metatx.Transactable, s signer.Signer) (result *routes.AttachResult, err error) {
+	return node.BuildAndSign(tx, s)
+}
@@ -0,0 +1,304 @@
+package sdk
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+// These methods run every tx in ndau.TxIDs through Execute. Each leaves the
+// sequence for the API to fill in.
+
+import (
+	"context"
+
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/ndauapi/routes"
+	"github.com/ndau/ndau/pkg/signer"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/pricecurve"
+	"github.com/ndau/ndaumath/pkg/signature"
+	math "github.com/ndau/ndaumath/pkg/types"
+)
+
+// Transfer builds, signs and submits a Transfer tx, returning once it is committed
+func (c *Client) Transfer(
+	ctx context.Context,
+	source address.Address,
+	destination address.Address,
+	qty math.Ndau,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewTransfer(source, destination, qty, 0), s)
+}
+
+// ChangeValidation builds, signs and submits a ChangeValidation tx, returning once it is committed
+func (c *Client) ChangeValidation(
+	ctx context.Context,
+	target address.Address,
+	newkeys []signature.PublicKey,
+	validationscript []byte,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewChangeValidation(target, newkeys, validationscript, 0), s)
+}
+
+// ReleaseFromEndowment builds, signs and submits a ReleaseFromEndowment tx, returning once it is committed
+func (c *Client) ReleaseFromEndowment(
+	ctx context.Context,
+	destination address.Address,
+	qty math.Ndau,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewReleaseFromEndowment(destination, qty, 0), s)
+}
+
+// ChangeRecoursePeriod builds, signs and submits a ChangeRecoursePeriod tx, returning once it is committed
+func (c *Client) ChangeRecoursePeriod(
+	ctx context.Context,
+	target address.Address,
+	period math.Duration,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewChangeRecoursePeriod(target, period, 0), s)
+}
+
+// Delegate builds, signs and submits a Delegate tx, returning once it is committed
+func (c *Client) Delegate(
+	ctx context.Context,
+	target address.Address,
+	node address.Address,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewDelegate(target, node, 0), s)
+}
+
+// CreditEAI builds, signs and submits a CreditEAI tx, returning once it is committed
+func (c *Client) CreditEAI(
+	ctx context.Context,
+	node address.Address,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewCreditEAI(node, 0), s)
+}
+
+// Lock builds, signs and submits a Lock tx, returning once it is committed
+func (c *Client) Lock(
+	ctx context.Context,
+	target address.Address,
+	period math.Duration,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewLock(target, period, 0), s)
+}
+
+// Notify builds, signs and submits a Notify tx, returning once it is committed
+func (c *Client) Notify(
+	ctx context.Context,
+	target address.Address,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewNotify(target, 0), s)
+}
+
+// SetRewardsDestination builds, signs and submits a SetRewardsDestination tx, returning once it is committed
+func (c *Client) SetRewardsDestination(
+	ctx context.Context,
+	target address.Address,
+	destination address.Address,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewSetRewardsDestination(target, destination, 0), s)
+}
+
+// SetValidation builds, signs and submits a SetValidation tx, returning once it is committed
+func (c *Client) SetValidation(
+	ctx context.Context,
+	target address.Address,
+	ownership signature.PublicKey,
+	validationkeys []signature.PublicKey,
+	validationscript []byte,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewSetValidation(target, ownership, validationkeys, validationscript, 0), s)
+}
+
+// Stake builds, signs and submits a Stake tx, returning once it is committed
+func (c *Client) Stake(
+	ctx context.Context,
+	target address.Address,
+	rules address.Address,
+	staketo address.Address,
+	qty math.Ndau,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewStake(target, rules, staketo, qty, 0), s)
+}
+
+// RegisterNode builds, signs and submits a RegisterNode tx, returning once it is committed
+func (c *Client) RegisterNode(
+	ctx context.Context,
+	node address.Address,
+	distributionscript []byte,
+	ownership signature.PublicKey,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewRegisterNode(node, distributionscript, ownership, 0), s)
+}
+
+// NominateNodeReward builds, signs and submits a NominateNodeReward tx, returning once it is committed
+func (c *Client) NominateNodeReward(
+	ctx context.Context,
+	random int64,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewNominateNodeReward(random, 0), s)
+}
+
+// ClaimNodeReward builds, signs and submits a ClaimNodeReward tx, returning once it is committed
+func (c *Client) ClaimNodeReward(
+	ctx context.Context,
+	node address.Address,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewClaimNodeReward(node, 0), s)
+}
+
+// TransferAndLock builds, signs and submits a TransferAndLock tx, returning once it is committed
+func (c *Client) TransferAndLock(
+	ctx context.Context,
+	source address.Address,
+	destination address.Address,
+	qty math.Ndau,
+	period math.Duration,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewTransferAndLock(source, destination, qty, period, 0), s)
+}
+
+// CommandValidatorChange builds, signs and submits a CommandValidatorChange tx, returning once it is committed
+func (c *Client) CommandValidatorChange(
+	ctx context.Context,
+	node address.Address,
+	power int64,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewCommandValidatorChange(node, power, 0), s)
+}
+
+// UnregisterNode builds, signs and submits an UnregisterNode tx, returning once it is committed
+func (c *Client) UnregisterNode(
+	ctx context.Context,
+	node address.Address,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewUnregisterNode(node, 0), s)
+}
+
+// Unstake builds, signs and submits an Unstake tx, returning once it is committed
+func (c *Client) Unstake(
+	ctx context.Context,
+	target address.Address,
+	rules address.Address,
+	staketo address.Address,
+	qty math.Ndau,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewUnstake(target, rules, staketo, qty, 0), s)
+}
+
+// Issue builds, signs and submits an Issue tx, returning once it is committed
+func (c *Client) Issue(
+	ctx context.Context,
+	qty math.Ndau,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewIssue(qty, 0), s)
+}
+
+// CreateChildAccount builds, signs and submits a CreateChildAccount tx, returning once it is committed
+func (c *Client) CreateChildAccount(
+	ctx context.Context,
+	target address.Address,
+	child address.Address,
+	childownership signature.PublicKey,
+	childsignature signature.Signature,
+	childrecourseperiod math.Duration,
+	childvalidationkeys []signature.PublicKey,
+	childvalidationscript []byte,
+	childdelegationnode address.Address,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewCreateChildAccount(target, child, childownership, childsignature, childrecourseperiod, childvalidationkeys, childvalidationscript, childdelegationnode, 0), s)
+}
+
+// RecordPrice builds, signs and submits a RecordPrice tx, returning once it is committed
+func (c *Client) RecordPrice(
+	ctx context.Context,
+	marketprice pricecurve.Nanocent,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewRecordPrice(marketprice, 0), s)
+}
+
+// SetSysvar builds, signs and submits a SetSysvar tx, returning once it is committed
+func (c *Client) SetSysvar(
+	ctx context.Context,
+	name string,
+	value []byte,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewSetSysvar(name, value, 0), s)
+}
+
+// SetStakeRules builds, signs and submits a SetStakeRules tx, returning once it is committed
+func (c *Client) SetStakeRules(
+	ctx context.Context,
+	target address.Address,
+	stakerules []byte,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewSetStakeRules(target, stakerules, 0), s)
+}
+
+// RecordEndowmentNAV builds, signs and submits a RecordEndowmentNAV tx, returning once it is committed
+func (c *Client) RecordEndowmentNAV(
+	ctx context.Context,
+	nav pricecurve.Nanocent,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewRecordEndowmentNAV(nav, 0), s)
+}
+
+// ResolveStake builds, signs and submits a ResolveStake tx, returning once it is committed
+func (c *Client) ResolveStake(
+	ctx context.Context,
+	target address.Address,
+	rules address.Address,
+	burn uint8,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewResolveStake(target, rules, burn, 0), s)
+}
+
+// Burn builds, signs and submits a Burn tx, returning once it is committed
+func (c *Client) Burn(
+	ctx context.Context,
+	target address.Address,
+	qty math.Ndau,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewBurn(target, qty, 0), s)
+}
+
+// ChangeSchema builds, signs and submits a ChangeSchema tx, returning once it is committed
+func (c *Client) ChangeSchema(
+	ctx context.Context,
+	schemaversion string,
+	s signer.Signer,
+) (*routes.TxStatus, error) {
+	return c.Execute(ctx, ndau.NewChangeSchema(schemaversion, 0), s)
+}
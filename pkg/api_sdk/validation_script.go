@@ -0,0 +1,88 @@
+package sdk
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"context"
+	"encoding/json"
+
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/ndauapi/routes"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/pkg/errors"
+)
+
+// DryRunValidationScriptContext runs a validation script against a sample tx, without
+// changing anything
+//
+// The script sees the current state of the tx's source account, and the tx as though
+// it had been signed by the validation keys whose bits are set in signatures. If keys
+// is not nil, it replaces the account's validation keys.
+func (c *Client) DryRunValidationScriptContext(
+	ctx context.Context,
+	script []byte,
+	tx metatx.Transactable,
+	signatures uint64,
+	keys []signature.PublicKey,
+) (result *query.ValidationScriptResponse, err error) {
+	txj, err := json.Marshal(tx)
+	if err != nil {
+		err = errors.Wrap(err, "marshaling tx")
+		return
+	}
+	req := routes.ValidationScriptRequest{
+		Script:         script,
+		TxType:         metatx.NameOf(tx),
+		Tx:             txj,
+		Signatures:     signatures,
+		ValidationKeys: keys,
+	}
+	result = new(query.ValidationScriptResponse)
+	err = c.post(ctx, req, result, c.URL("tx/validationscript"))
+	err = errors.Wrap(err, "running validation script")
+	return
+}
+
+// DryRunValidationScript runs a validation script against a sample tx, without
+// changing anything
+func (c *Client) DryRunValidationScript(
+	script []byte,
+	tx metatx.Transactable,
+	signatures uint64,
+	keys []signature.PublicKey,
+) (result *query.ValidationScriptResponse, err error) {
+	return c.DryRunValidationScriptContext(compat, script, tx, signatures, keys)
+}
+
+// CheckChangeValidationContext runs the validation script a ChangeValidation would set
+// against its owner's next tx, as for tool.CheckChangeValidation
+//
+// If the script would reject it, the error's cause is tool.ErrLockout. The API's
+// build endpoint makes the same check, so Execute never submits such a tx.
+func (c *Client) CheckChangeValidationContext(ctx context.Context, tx *ndau.ChangeValidation) (*query.ValidationScriptResponse, error) {
+	if len(tx.ValidationScript) == 0 {
+		return nil, nil
+	}
+	probe, signatures := tool.ValidationProbe(tx)
+	result, err := c.DryRunValidationScriptContext(ctx, tx.ValidationScript, probe, signatures, tx.NewKeys)
+	if err != nil {
+		return nil, err
+	}
+	return result, tool.Lockout(result)
+}
+
+// CheckChangeValidation runs the validation script a ChangeValidation would set
+// against its owner's next tx, as for tool.CheckChangeValidation
+func (c *Client) CheckChangeValidation(tx *ndau.ChangeValidation) (*query.ValidationScriptResponse, error) {
+	return c.CheckChangeValidationContext(compat, tx)
+}
@@ -23,25 +23,38 @@ import (
 	"github.com/ndau/ndau/pkg/query"
 	"github.com/ndau/ndau/pkg/version"
 	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/bitset256"
 	"github.com/ndau/ndaumath/pkg/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 func init() {
 	meta.RegisterQueryHandler(query.AccountEndpoint, accountQuery)
+	meta.RegisterQueryHandler(query.AccountsEndpoint, accountsQuery)
 	meta.RegisterQueryHandler(query.AccountHistoryEndpoint, accountHistoryQuery)
 	meta.RegisterQueryHandler(query.AccountListEndpoint, accountListQuery)
+	meta.RegisterQueryHandler(query.CurrencySeatsEndpoint, currencySeatsQuery)
 	meta.RegisterQueryHandler(query.DateRangeEndpoint, dateRangeQuery)
+	meta.RegisterQueryHandler(query.DebugVMEndpoint, debugVMQuery)
 	meta.RegisterQueryHandler(query.DelegatesEndpoint, delegatesQuery)
+	meta.RegisterQueryHandler(query.FeatureEndpoint, featureQuery)
+	meta.RegisterQueryHandler(query.IndexStatusEndpoint, indexStatusQuery)
 	meta.RegisterQueryHandler(query.NodesEndpoint, nodesQuery)
+	meta.RegisterQueryHandler(query.NodeRewardsEndpoint, nodeRewardsQuery)
 	meta.RegisterQueryHandler(query.PrevalidateEndpoint, prevalidateQuery)
 	meta.RegisterQueryHandler(query.PriceMarketEndpoint, priceQuery)
 	meta.RegisterQueryHandler(query.PriceTargetEndpoint, priceQuery)
+	meta.RegisterQueryHandler(query.PriceNAVEndpoint, priceQuery)
+	meta.RegisterQueryHandler(query.PriceSIBEndpoint, sibHistoryQuery)
 	meta.RegisterQueryHandler(query.SearchEndpoint, searchQuery)
 	meta.RegisterQueryHandler(query.SIBEndpoint, sibQuery)
 	meta.RegisterQueryHandler(query.SummaryEndpoint, summaryQuery)
+	meta.RegisterQueryHandler(query.SupplyHistoryEndpoint, supplyHistoryQuery)
 	meta.RegisterQueryHandler(query.SysvarHistoryEndpoint, sysvarHistoryQuery)
 	meta.RegisterQueryHandler(query.SysvarsEndpoint, sysvarsQuery)
+	meta.RegisterQueryHandler(query.TxSourceEndpoint, txSourceQuery)
+	meta.RegisterQueryHandler(query.UnlocksEndpoint, unlocksQuery)
+	meta.RegisterQueryHandler(query.ValidationScriptEndpoint, validationScriptQuery)
 	meta.RegisterQueryHandler(query.VersionEndpoint, versionQuery)
 }
 
@@ -54,12 +67,9 @@ func accountQuery(appI interface{}, request abci.RequestQuery, response *abci.Re
 		return
 	}
 
-	ad, exists := app.getAccount(address)
+	ad, exists := app.queryAccount(address)
 	// we use the Info field in the response to indicate whether the account exists
 	response.Info = fmt.Sprintf(query.AccountInfoFmt, exists)
-	ad.UpdateRecourses(app.BlockTime())
-	// update the WAA field to get up-to-the-microsecond values
-	ad.WeightedAverageAge += app.BlockTime().Since(ad.LastWAAUpdate)
 	adBytes, err := ad.MarshalMsg(nil)
 	if err != nil {
 		app.QueryError(err, response, "serializing account data")
@@ -69,17 +79,74 @@ func accountQuery(appI interface{}, request abci.RequestQuery, response *abci.Re
 	response.Value = adBytes
 }
 
-func accountHistoryQuery(
-	appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery,
-) {
+// queryAccount gets an account's data as of the current block time
+func (app *App) queryAccount(addr address.Address) (backing.AccountData, bool) {
+	ad, exists := app.getAccount(addr)
+	ad.UpdateRecourses(app.BlockTime())
+	// update the WAA field to get up-to-the-microsecond values
+	ad.WeightedAverageAge += app.BlockTime().Since(ad.LastWAAUpdate)
+	return ad, exists
+}
+
+func accountsQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
 	app := appI.(*App)
 
+	var addrs query.AccountsRequest
+	_, err := addrs.UnmarshalMsg(request.GetData())
+	if err != nil {
+		app.QueryError(err, response, "deserializing addresses")
+		return
+	}
+
+	ar := make(query.AccountsResponse, len(addrs))
+	for _, addr := range addrs {
+		ad, exists := app.queryAccount(addr)
+		ar[addr.String()] = query.AccountResult{
+			Exists: exists,
+			Data:   ad,
+		}
+	}
+
+	arBytes, err := ar.MarshalMsg(nil)
+	if err != nil {
+		app.QueryError(err, response, "serializing accounts data")
+		return
+	}
+
+	response.Value = arBytes
+}
+
+// searchClient returns the app's search client, or nil after reporting a query error if
+// there isn't one. If initial indexing is still catching up, the response says so.
+func (app *App) searchClient(response *abci.ResponseQuery) *srch.Client {
 	search := app.GetSearch()
 	if search == nil {
 		app.QueryError(errors.New("must call SetSearch()"), response, "search not available")
-		return
+		return nil
 	}
 	client := search.(*srch.Client)
+	if client.IndexBehind() {
+		response.Info = query.IndexBehindInfo
+	}
+	return client
+}
+
+// indexStatusQuery has no value: its Info is query.IndexBehindInfo while initial indexing is
+// catching up, and empty otherwise.
+func indexStatusQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
+	app := appI.(*App)
+	app.searchClient(response)
+}
+
+func accountHistoryQuery(
+	appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery,
+) {
+	app := appI.(*App)
+
+	client := app.searchClient(response)
+	if client == nil {
+		return
+	}
 
 	var params srch.AccountHistoryParams
 	err := json.Unmarshal(request.GetData(), &params)
@@ -100,6 +167,34 @@ func accountHistoryQuery(
 	response.Value = ahBytes
 }
 
+func nodeRewardsQuery(
+	appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery,
+) {
+	app := appI.(*App)
+
+	client := app.searchClient(response)
+	if client == nil {
+		return
+	}
+
+	var params srch.NodeRewardHistoryParams
+	err := json.Unmarshal(request.GetData(), &params)
+	if err != nil {
+		app.QueryError(
+			errors.New("cannot decode search params json"), response, "invalid search query")
+		return
+	}
+
+	// The address was already validated by the caller.
+	nrhr, err := client.SearchNodeRewardHistory(params.Address, params.AfterHeight, params.Limit)
+	if err != nil {
+		app.QueryError(err, response, "node reward history search fail")
+		return
+	}
+
+	response.Value = []byte(nrhr.Marshal())
+}
+
 func accountListQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
 	app := appI.(*App)
 
@@ -155,12 +250,10 @@ func accountListQuery(appI interface{}, request abci.RequestQuery, response *abc
 func dateRangeQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
 	app := appI.(*App)
 
-	search := app.GetSearch()
-	if search == nil {
-		app.QueryError(errors.New("must call SetSearch()"), response, "search not available")
+	client := app.searchClient(response)
+	if client == nil {
 		return
 	}
-	client := search.(*srch.Client)
 
 	paramsString := string(request.GetData())
 	var req metasrch.DateRangeRequest
@@ -219,15 +312,114 @@ func prevalidateQuery(appI interface{}, request abci.RequestQuery, response *abc
 	}
 }
 
+// txSourceQuery reports the source address of a tx, and that account's current sequence.
+func txSourceQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
+	app := appI.(*App)
+
+	mtx, err := metatx.Unmarshal(request.GetData(), TxIDs)
+	if err != nil {
+		app.QueryError(err, response, "deserializing transactable")
+		return
+	}
+
+	tx, ok := mtx.(NTransactable)
+	if !ok {
+		app.QueryError(
+			fmt.Errorf("tx %s not an NTransactable", metatx.NameOf(mtx)),
+			response,
+			"converting metatx.Transactable to NTransactable",
+		)
+		return
+	}
+
+	source, err := tx.GetSource(app)
+	if err != nil {
+		app.QueryError(err, response, "getting tx source")
+		return
+	}
+
+	acct, _ := app.getAccount(source)
+	// we use the Info field to communicate the source's sequence
+	response.Info = fmt.Sprintf(query.TxSourceInfoFmt, acct.Sequence)
+	response.Value = []byte(source.String())
+}
+
+func validationScriptQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
+	app := appI.(*App)
+
+	var req query.ValidationScriptRequest
+	_, err := req.UnmarshalMsg(request.GetData())
+	if err != nil {
+		app.QueryError(err, response, "deserializing request")
+		return
+	}
+	if !IsChaincode(req.Script) {
+		app.QueryError(errors.New("not chaincode"), response, "validating script")
+		return
+	}
+
+	mtx, err := metatx.Unmarshal(req.Tx, TxIDs)
+	if err != nil {
+		app.QueryError(err, response, "deserializing transactable")
+		return
+	}
+	tx, ok := mtx.(NTransactable)
+	if !ok {
+		app.QueryError(
+			fmt.Errorf("tx %s not an NTransactable", metatx.NameOf(mtx)),
+			response,
+			"converting metatx.Transactable to NTransactable",
+		)
+		return
+	}
+	source, err := tx.GetSource(app)
+	if err != nil {
+		app.QueryError(err, response, "getting tx source")
+		return
+	}
+
+	acct, _ := app.getAccount(source)
+	acct.ValidationScript = req.Script
+	if req.ValidationKeys != nil {
+		acct.ValidationKeys = req.ValidationKeys
+	}
+	sigset := bitset256.New()
+	for i := byte(0); i < backing.MaxKeysInAccount; i++ {
+		if req.Signatures&(1<<i) != 0 {
+			sigset.Set(i)
+		}
+	}
+
+	vm, err := BuildVMForTxValidation(req.Script, acct, tx, sigset, app)
+	if err != nil {
+		app.QueryError(err, response, "building validation vm")
+		return
+	}
+	var result query.ValidationScriptResponse
+	result.Trace, err = runTraced(vm)
+	if err == nil {
+		// this is how getTxAccount judges the outcome
+		result.ExitCode, err = vm.Stack().PopAsInt64()
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	resultBytes, err := result.MarshalMsg(nil)
+	if err != nil {
+		app.QueryError(err, response, "serializing validation script result")
+		return
+	}
+	response.Value = resultBytes
+}
+
 func searchQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
 	app := appI.(*App)
 
-	search := app.GetSearch()
-	if search == nil {
-		app.QueryError(errors.New("must call SetSearch()"), response, "search not available")
+	client := app.searchClient(response)
+	if client == nil {
 		return
 	}
-	client := search.(*srch.Client)
 
 	var params srch.QueryParams
 	err := json.Unmarshal(request.GetData(), &params)
@@ -272,39 +464,46 @@ var lastSummary query.Summary
 func getLastSummary(app *App) query.Summary {
 	// cache the last-read value for the duration of a block in case we get multiple queries
 	if lastSummary.BlockHeight != app.Height() {
-		state := app.GetState().(*backing.State)
-
-		var total types.Ndau
-		for _, acct := range state.Accounts {
-			total += acct.Balance
-		}
-		lastSummary.TotalNdau = total
-		lastSummary.NumAccounts = len(state.Accounts)
-		lastSummary.BlockHeight = app.Height()
-		lastSummary.TotalRFE = state.TotalRFE
-		lastSummary.TotalIssue = state.TotalIssue
-		lastSummary.TotalBurned = state.TotalBurned
-
-		// Tracking TotalNdau and TotalCirculation together is a bad idea, especially when queries
-		// return TotalCirculation when TotalNdau is requested. But cleaning that up makes the
-		// implementation of a height gate (which is necessary) very messy, so I'm leaving it.
-
-		// TotalBurned should never have been subtracted from TotalNdau - that's an old bug that's being fixed. But it's inside
-		// the height gate because the calculation of the floor price - and, therefore, SIB - depends on it. And this calculation
-		// changes the rules for RFE. All ndau released from the endowment are immediately in circulation. The TotalIssued value
-		// is only used to calculate the current target price.
-
-		if app.IsFeatureActive("AllRFEInCirculation") {
-			lastSummary.TotalCirculation = lastSummary.TotalNdau
-		} else {
-			// the total ndau in circulation is the total in all accounts, excluding
-			// the amount of ndau that have been released but not issued
-			lastSummary.TotalCirculation = lastSummary.TotalNdau - ((lastSummary.TotalRFE - lastSummary.TotalIssue) + lastSummary.TotalBurned)
-		}
+		lastSummary = computeSummary(app)
 	}
 	return lastSummary
 }
 
+// computeSummary computes the summary of the current app state without any caching.
+func computeSummary(app *App) query.Summary {
+	var summary query.Summary
+	state := app.GetState().(*backing.State)
+
+	var total types.Ndau
+	for _, acct := range state.Accounts {
+		total += acct.Balance
+	}
+	summary.TotalNdau = total
+	summary.NumAccounts = len(state.Accounts)
+	summary.BlockHeight = app.Height()
+	summary.TotalRFE = state.TotalRFE
+	summary.TotalIssue = state.TotalIssue
+	summary.TotalBurned = state.TotalBurned
+
+	// Tracking TotalNdau and TotalCirculation together is a bad idea, especially when queries
+	// return TotalCirculation when TotalNdau is requested. But cleaning that up makes the
+	// implementation of a height gate (which is necessary) very messy, so I'm leaving it.
+
+	// TotalBurned should never have been subtracted from TotalNdau - that's an old bug that's being fixed. But it's inside
+	// the height gate because the calculation of the floor price - and, therefore, SIB - depends on it. And this calculation
+	// changes the rules for RFE. All ndau released from the endowment are immediately in circulation. The TotalIssued value
+	// is only used to calculate the current target price.
+
+	if app.IsFeatureActive("AllRFEInCirculation") {
+		summary.TotalCirculation = summary.TotalNdau
+	} else {
+		// the total ndau in circulation is the total in all accounts, excluding
+		// the amount of ndau that have been released but not issued
+		summary.TotalCirculation = summary.TotalNdau - ((summary.TotalRFE - summary.TotalIssue) + summary.TotalBurned)
+	}
+	return summary
+}
+
 func summaryQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
 	app := appI.(*App)
 
@@ -371,12 +570,10 @@ func sysvarHistoryQuery(
 ) {
 	app := appI.(*App)
 
-	search := app.GetSearch()
-	if search == nil {
-		app.QueryError(errors.New("must call SetSearch()"), response, "search not available")
+	client := app.searchClient(response)
+	if client == nil {
 		return
 	}
-	client := search.(*srch.Client)
 
 	var params srch.SysvarHistoryParams
 	err := json.Unmarshal(request.GetData(), &params)
@@ -434,6 +631,59 @@ func delegatesQuery(appI interface{}, _ abci.RequestQuery, response *abci.Respon
 	response.Value = bytes
 }
 
+func currencySeatsQuery(appI interface{}, _ abci.RequestQuery, response *abci.ResponseQuery) {
+	app := appI.(*App)
+	state := app.GetState().(*backing.State)
+
+	seats := make(query.CurrencySeatsResponse, 0)
+	for addrS, acct := range state.Accounts {
+		if acct.CurrencySeatDate == nil {
+			continue
+		}
+		addr, err := address.Validate(addrS)
+		if err != nil {
+			response.Info += fmt.Sprintf("bad acct address: %q\n", addrS)
+			continue
+		}
+		seats = append(seats, query.CurrencySeat{
+			Address:  addr,
+			SeatDate: *acct.CurrencySeatDate,
+			Balance:  acct.Balance,
+		})
+	}
+
+	// sort seats by currency seat date, oldest first
+	// seats which date from the same block are ordered by address so the result is stable
+	sort.Slice(seats, func(i, j int) bool {
+		if seats[i].SeatDate != seats[j].SeatDate {
+			return seats[i].SeatDate < seats[j].SeatDate
+		}
+		return seats[i].Address.String() < seats[j].Address.String()
+	})
+
+	bytes, err := seats.MarshalMsg(nil)
+	if err != nil {
+		app.QueryError(err, response, "failed to marshal currency seats response")
+		return
+	}
+
+	response.Log = fmt.Sprintf("%d currency seats", len(seats))
+	response.Value = bytes
+}
+
+func featureQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
+	app := appI.(*App)
+
+	feature := string(request.GetData())
+	if feature == "" {
+		app.QueryError(errors.New("feature name required"), response, "reading feature")
+		return
+	}
+
+	// we use the Info field in the response to indicate whether the feature is active
+	response.Info = fmt.Sprintf(query.FeatureInfoFmt, app.IsFeatureActive(feature))
+}
+
 func sibQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
 	var err error
 	app := appI.(*App)
@@ -477,7 +727,10 @@ func nodesQuery(appI interface{}, request abci.RequestQuery, response *abci.Resp
 
 func priceQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
 	app := appI.(*App)
-	search := app.GetSearch().(*srch.Client)
+	search := app.searchClient(response)
+	if search == nil {
+		return
+	}
 
 	// chose the appropriate search function
 	var sf func(params srch.PriceQueryParams) (srch.PriceQueryResults, error)
@@ -486,12 +739,14 @@ func priceQuery(appI interface{}, request abci.RequestQuery, response *abci.Resp
 		sf = search.SearchMarketPrice
 	case query.PriceTargetEndpoint:
 		sf = search.SearchTargetPrice
+	case query.PriceNAVEndpoint:
+		sf = search.SearchEndowmentNAV
 	}
 
 	// unpack params
 	var pqp srch.PriceQueryParams
 	if len(request.Data) > 0 {
-		_, err := pqp.After.Timestamp.UnmarshalMsg(request.Data)
+		_, err := pqp.UnmarshalMsg(request.Data)
 		if err != nil {
 			app.QueryError(err, response, "unmarshaling query params")
 			return
@@ -511,3 +766,104 @@ func priceQuery(appI interface{}, request abci.RequestQuery, response *abci.Resp
 		app.QueryError(err, response, "marshaling price data results")
 	}
 }
+
+func sibHistoryQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
+	app := appI.(*App)
+
+	client := app.searchClient(response)
+	if client == nil {
+		return
+	}
+
+	// unpack params
+	var pqp srch.PriceQueryParams
+	if len(request.Data) > 0 {
+		_, err := pqp.UnmarshalMsg(request.Data)
+		if err != nil {
+			app.QueryError(err, response, "unmarshaling query params")
+			return
+		}
+	}
+
+	// perform search
+	sqr, err := client.SearchSIB(pqp)
+	if err != nil {
+		app.QueryError(err, response, "searching for sib data")
+		return
+	}
+
+	// pack response
+	response.Value, err = sqr.MarshalMsg(nil)
+	if err != nil {
+		app.QueryError(err, response, "marshaling sib data results")
+	}
+}
+
+func supplyHistoryQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
+	app := appI.(*App)
+
+	client := app.searchClient(response)
+	if client == nil {
+		return
+	}
+
+	// unpack params
+	var sqp srch.SupplyQueryParams
+	if len(request.Data) > 0 {
+		_, err := sqp.UnmarshalMsg(request.Data)
+		if err != nil {
+			app.QueryError(err, response, "unmarshaling query params")
+			return
+		}
+	}
+
+	// perform search
+	sqr, err := client.SearchSupply(sqp)
+	if err != nil {
+		app.QueryError(err, response, "searching for supply data")
+		return
+	}
+
+	// pack response
+	response.Value, err = sqr.MarshalMsg(nil)
+	if err != nil {
+		app.QueryError(err, response, "marshaling supply data results")
+	}
+}
+
+func unlocksQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
+	app := appI.(*App)
+
+	client := app.searchClient(response)
+	if client == nil {
+		return
+	}
+
+	// unpack params
+	var uqp srch.UnlocksQueryParams
+	if len(request.Data) > 0 {
+		_, err := uqp.UnmarshalMsg(request.Data)
+		if err != nil {
+			app.QueryError(err, response, "unmarshaling query params")
+			return
+		}
+	}
+
+	// unlocks which have already happened are rarely of interest; hide them unless asked for
+	if uqp.After.Height == 0 && uqp.After.Timestamp == 0 {
+		uqp.After.Timestamp = app.BlockTime()
+	}
+
+	// perform search
+	uqr, err := client.SearchUnlocks(uqp)
+	if err != nil {
+		app.QueryError(err, response, "searching for unlocks")
+		return
+	}
+
+	// pack response
+	response.Value, err = uqr.MarshalMsg(nil)
+	if err != nil {
+		app.QueryError(err, response, "marshaling unlocks results")
+	}
+}
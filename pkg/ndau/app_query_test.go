@@ -62,6 +62,34 @@ func TestCanQueryAccountStatusDest(t *testing.T) {
 	require.Equal(t, math.Ndau(0), accountData.Balance)
 }
 
+func TestCanQueryAccounts(t *testing.T) {
+	app, _ := initAppTx(t)
+
+	sourceAddr, err := address.Validate(source)
+	require.NoError(t, err)
+	destAddr, err := address.Validate(dest)
+	require.NoError(t, err)
+
+	req, err := query.AccountsRequest{sourceAddr, destAddr}.MarshalMsg(nil)
+	require.NoError(t, err)
+	resp := app.Query(abci.RequestQuery{
+		Path: query.AccountsEndpoint,
+		Data: req,
+	})
+	require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+
+	var accounts query.AccountsResponse
+	leftover, err := accounts.UnmarshalMsg(resp.Value)
+	require.NoError(t, err)
+	require.Empty(t, leftover)
+	require.Len(t, accounts, 2)
+
+	require.True(t, accounts[source].Exists)
+	require.Equal(t, math.Ndau(10000*constants.QuantaPerUnit), accounts[source].Data.Balance)
+	require.False(t, accounts[dest].Exists)
+	require.Equal(t, math.Ndau(0), accounts[dest].Data.Balance)
+}
+
 func TestQueryRunsUpdateBalance(t *testing.T) {
 	app, _, ts := initAppRecourse(t)
 	t.Log("timestamp of end of recourse period", ts)
@@ -183,6 +211,71 @@ func TestPrevalidateInvalidTx(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestQueryValidationScript(t *testing.T) {
+	app, private := initAppTx(t)
+	tr := generateTransfer(t, 50, 1, []signature.PrivateKey{private})
+	trb, err := metatx.Marshal(tr, TxIDs)
+	require.NoError(t, err)
+
+	for script, accepted := range map[string]bool{
+		"handler 0 zero enddef": true,
+		"handler 0 one enddef":  false,
+	} {
+		t.Run(script, func(t *testing.T) {
+			req, err := query.ValidationScriptRequest{
+				Script:     vm.MiniAsm(script).Bytes(),
+				Tx:         trb,
+				Signatures: 1,
+			}.MarshalMsg(nil)
+			require.NoError(t, err)
+
+			resp := app.Query(abci.RequestQuery{
+				Path: query.ValidationScriptEndpoint,
+				Data: req,
+			})
+			require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+
+			var result query.ValidationScriptResponse
+			_, err = result.UnmarshalMsg(resp.Value)
+			require.NoError(t, err)
+			require.Empty(t, result.Error)
+			require.Equal(t, accepted, result.Accepted())
+			require.NotEmpty(t, result.Trace)
+		})
+	}
+}
+
+func TestQueryDebugVM(t *testing.T) {
+	app, private := initAppTx(t)
+	tr := generateTransfer(t, 50, 1, []signature.PrivateKey{private})
+	trb, err := metatx.Marshal(tr, TxIDs)
+	require.NoError(t, err)
+
+	req, err := query.VMDebugRequest{VM: query.VMTxFees, Tx: trb}.MarshalMsg(nil)
+	require.NoError(t, err)
+	resp := app.Query(abci.RequestQuery{
+		Path: query.DebugVMEndpoint,
+		Data: req,
+	})
+	require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+
+	var result query.VMDebugResponse
+	_, err = result.UnmarshalMsg(resp.Value)
+	require.NoError(t, err)
+	require.Empty(t, result.Error)
+	require.NotEmpty(t, result.Disassembly)
+	require.NotEmpty(t, result.Trace)
+	require.NotEmpty(t, result.Stack)
+
+	req, err = query.VMDebugRequest{VM: "nonesuch", Tx: trb}.MarshalMsg(nil)
+	require.NoError(t, err)
+	resp = app.Query(abci.RequestQuery{
+		Path: query.DebugVMEndpoint,
+		Data: req,
+	})
+	require.Equal(t, code.QueryError, code.ReturnCode(resp.Code))
+}
+
 func TestPrevalidateReportsCorrectFee(t *testing.T) {
 	app, private := initAppTx(t)
 	tr := generateTransfer(t, 50, 1, []signature.PrivateKey{private})
@@ -297,3 +390,62 @@ func TestQueryNodes(t *testing.T) {
 	require.Contains(t, nodes, targetAddress.String())
 	require.NotZero(t, nodes[targetAddress.String()])
 }
+
+func TestQueryCurrencySeats(t *testing.T) {
+	app, _ := initAppTx(t)
+
+	newAddr := func() address.Address {
+		public, _, err := signature.Generate(signature.Ed25519, nil)
+		require.NoError(t, err)
+		addr, err := address.Generate(address.KindUser, public.KeyBytes())
+		require.NoError(t, err)
+		return addr
+	}
+
+	older := newAddr()
+	newer := newAddr()
+	seatDate := func(ts math.Timestamp, qty math.Ndau) func(*backing.AccountData) {
+		return func(ad *backing.AccountData) {
+			ad.Balance = qty
+			ad.CurrencySeatDate = &ts
+		}
+	}
+	modify(t, newer.String(), app, seatDate(2000, 1500*constants.QuantaPerUnit))
+	modify(t, older.String(), app, seatDate(1000, 2000*constants.QuantaPerUnit))
+	modifySource(t, app, func(ad *backing.AccountData) {
+		ad.CurrencySeatDate = nil
+	})
+
+	resp := app.Query(abci.RequestQuery{
+		Path: query.CurrencySeatsEndpoint,
+	})
+	require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+
+	var seats query.CurrencySeatsResponse
+	leftover, err := seats.UnmarshalMsg(resp.Value)
+	require.NoError(t, err)
+	require.Empty(t, leftover)
+	require.Equal(t, query.CurrencySeatsResponse{
+		{Address: older, SeatDate: 1000, Balance: 2000 * constants.QuantaPerUnit},
+		{Address: newer, SeatDate: 2000, Balance: 1500 * constants.QuantaPerUnit},
+	}, seats)
+}
+
+func TestQueryFeature(t *testing.T) {
+	app, _ := initAppTx(t)
+	app.config.Features = map[string]uint64{"Future": app.Height() + 100}
+
+	for feature, expectActive := range map[string]bool{
+		"Future":  false,
+		"Unknown": true,
+	} {
+		t.Run(feature, func(t *testing.T) {
+			resp := app.Query(abci.RequestQuery{
+				Path: query.FeatureEndpoint,
+				Data: []byte(feature),
+			})
+			require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+			require.Equal(t, fmt.Sprintf(query.FeatureInfoFmt, expectActive), resp.Info)
+		})
+	}
+}
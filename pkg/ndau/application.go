@@ -88,6 +88,40 @@ func NewAppWithLogger(dbSpec string, indexAddr string, indexVersion int, config
 			return nil, errors.Wrap(err, "NewApp unable to init search client")
 		}
 
+		workers := 0
+		if config.IndexWorkers != nil {
+			workers = *config.IndexWorkers
+		}
+		checkpointInterval := uint64(0)
+		if config.IndexCheckpointInterval != nil {
+			checkpointInterval = *config.IndexCheckpointInterval
+		}
+		search.ConfigureInitialIndexing(workers, checkpointInterval)
+
+		if config.IndexInBackground != nil && *config.IndexInBackground {
+			// Searching and incremental indexing can proceed while we catch up.
+			metaapp.SetSearch(search)
+
+			metaapp.GetLogger().WithFields(log.Fields{
+				"search.indexVersion": indexVersion,
+			}).Info("ndau starting initial indexing in the background")
+
+			search.IndexBlockchainInBackground(
+				metaapp.GetDB(), metaapp.GetDS(),
+				func(updateCount, insertCount int, err error) {
+					if err != nil {
+						metaapp.GetLogger().WithError(err).Error("ndau background initial indexing failed; retrying")
+						return
+					}
+					metaapp.GetLogger().WithFields(log.Fields{
+						"search.updateCount": updateCount,
+						"search.insertCount": insertCount,
+					}).Info("ndau background initial indexing complete")
+				},
+			)
+			return &app, nil
+		}
+
 		// Log initial indexing in case it takes a long time, people can see why.
 		metaapp.GetLogger().WithFields(log.Fields{
 			"search.indexVersion": indexVersion,
@@ -255,3 +289,28 @@ func (app *App) CalculateTxSIBNapu(tx metatx.Transactable) (uint64, error) {
 	sib, err := app.calculateSIB(ntx)
 	return uint64(sib), err
 }
+
+// GetSupply implements AppIndexable
+//
+// Unlike the summary query, this never uses the cached summary, which may have been
+// computed partway through a block: the indexer calls it once each block's transactions
+// have been applied, so the totals are those the block ended with.
+func (app *App) GetSupply() (search.SupplyValueData, error) {
+	summary := computeSummary(app)
+	state := app.GetState().(*backing.State)
+	nav := state.GetEndowmentNAV()
+	fp, err := floorPriceFor(nav, summary.TotalCirculation)
+	if err != nil {
+		return search.SupplyValueData{}, err
+	}
+	return search.SupplyValueData{
+		TotalNdau:        summary.TotalNdau,
+		TotalCirculation: summary.TotalCirculation,
+		TotalRFE:         summary.TotalRFE,
+		TotalIssue:       summary.TotalIssue,
+		TotalBurned:      summary.TotalBurned,
+		SIB:              state.SIB,
+		EndowmentNAV:     nav,
+		FloorPrice:       fp,
+	}, nil
+}
@@ -64,6 +64,26 @@ type Config struct {
 	// are treated as 0: no delay.
 	NodeRewardWebhookDelay *float64
 
+	// IndexInBackground, if true, lets the node start serving consensus before
+	// initial indexing of the blockchain has completed. Searches report that the
+	// index is behind until the background indexer catches up.
+	//
+	// Missing values are treated as false: the node waits for initial indexing.
+	IndexInBackground *bool
+
+	// IndexWorkers is the number of workers decoding blocks during initial indexing.
+	// Their results are still indexed one block at a time, in order.
+	//
+	// Missing values are treated as 1: blocks are decoded one at a time.
+	IndexWorkers *int
+
+	// IndexCheckpointInterval is the number of blocks initial indexing crawls between
+	// checkpoints. If the node stops during initial indexing, it resumes from the last
+	// checkpoint on restart.
+	//
+	// Missing values are treated as 0: use the indexer's default interval.
+	IndexCheckpointInterval *uint64
+
 	// Map whose keys are features,
 	// and whose values are the mainnet block height at which the feature becomes active.
 	Features map[string]uint64
@@ -0,0 +1,184 @@
+package ndau
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+
+	"github.com/ndau/ndau/pkg/ndau/backing"
+	"github.com/ndau/ndaumath/pkg/constants"
+	"github.com/ndau/ndaumath/pkg/eai"
+	"github.com/ndau/ndaumath/pkg/signed"
+	math "github.com/ndau/ndaumath/pkg/types"
+	sv "github.com/ndau/system_vars/pkg/system_vars"
+	"github.com/pkg/errors"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// EAIParams are the system variables and features which determine how much EAI a
+// CreditEAI tx credits to each account.
+type EAIParams struct {
+	UnlockedTable eai.RateTable
+	// LockedTable is only set when UseCurrentLockBonus is
+	LockedTable eai.RateTable
+	FeeTable    sv.EAIFeeTable
+	// AwardPerNdau is what remains of each ndau of EAI once the fees are taken
+	AwardPerNdau math.Ndau
+	// Overtime is the most time a single CreditEAI tx credits EAI for, if set
+	Overtime *math.Duration
+
+	UseCurrentLockBonus bool
+	FixEAIUnlockBug     bool
+}
+
+// NewEAIParams reads the EAI parameters from a chain's system variables, and from
+// isFeatureActive, which reports whether the chain has activated a feature.
+func NewEAIParams(sysvars map[string][]byte, isFeatureActive func(string) bool) (*EAIParams, error) {
+	p := EAIParams{
+		UseCurrentLockBonus: isFeatureActive("UseCurrentLockBonus"),
+		FixEAIUnlockBug:     isFeatureActive("FixEAIUnlockBug"),
+	}
+
+	err := unmarshalSysvar(sysvars, sv.UnlockedRateTableName, &p.UnlockedTable)
+	if err != nil {
+		return nil, err
+	}
+	// 2022-05-01 Change to use current lock bonus table, not the bonus value stored in the account.
+	// The rate table could change at any time, so we always have to check.
+	if p.UseCurrentLockBonus {
+		err = unmarshalSysvar(sysvars, sv.LockedRateTableName, &p.LockedTable)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = unmarshalSysvar(sysvars, sv.EAIFeeTableName, &p.FeeTable)
+	if err != nil {
+		return nil, err
+	}
+
+	// calculate the actual award per ndau of EAI, so we can reduce each account's
+	// award appropriately
+	p.AwardPerNdau = math.Ndau(constants.QuantaPerUnit)
+	for _, fee := range p.FeeTable {
+		p.AwardPerNdau -= fee.Fee
+	}
+
+	// without an overtime limit, EAI accrues from the last update however long ago it was
+	overtime := new(math.Duration)
+	if unmarshalSysvar(sysvars, sv.EAIOvertime, overtime) == nil {
+		p.Overtime = overtime
+	}
+
+	return &p, nil
+}
+
+// unmarshalSysvar is App.System for a map of system variables
+func unmarshalSysvar(sysvars map[string][]byte, name string, value msgp.Unmarshaler) error {
+	bytes, exists := sysvars[name]
+	if !exists {
+		return fmt.Errorf("sysvar %s does not exist", name)
+	}
+	leftovers, err := value.UnmarshalMsg(bytes)
+	if err == nil && len(leftovers) > 0 {
+		err = fmt.Errorf("sysvar %s has extra trailing bytes; this is suspicious", name)
+	}
+	return errors.Wrap(err, fmt.Sprintf("Error fetching %s system variable", name))
+}
+
+// CreditedEAI is the EAI a CreditEAI tx credits to an account
+type CreditedEAI struct {
+	// Table is the age/rate table which applies to the account
+	Table eai.RateTable
+	// Award is the EAI before fees, including any the account had uncredited
+	Award math.Ndau
+	// Net is what the account receives once the fees are taken
+	Net math.Ndau
+}
+
+// CalculateCreditEAI calculates the EAI a CreditEAI tx applied at blockTime credits to
+// an account.
+//
+// Like the tx, it first brings the account's weighted average age up to blockTime, and
+// its lock bonus up to date if the chain uses the current bonus; acct is updated to match.
+// exchangeRate calculates the flat rate which applies to exchange accounts; it must be nil
+// for other accounts.
+func (p *EAIParams) CalculateCreditEAI(
+	acct *backing.AccountData,
+	blockTime math.Timestamp,
+	exchangeRate func(backing.AccountData) (eai.Rate, error),
+) (CreditedEAI, error) {
+	var out CreditedEAI
+	err := acct.WeightedAverageAge.UpdateWeightedAverageAge(
+		blockTime.Since(acct.LastWAAUpdate),
+		0,
+		acct.Balance,
+	)
+	if err != nil {
+		return out, err
+	}
+	acct.LastWAAUpdate = blockTime
+
+	// Exchange accounts get a flat rate for EAI.  To accomplish this, we make a 1-element rate
+	// table using the exchange account Rate (dependent on account) with a zero From field.
+	out.Table = p.UnlockedTable
+	if exchangeRate != nil {
+		out.Table = make(eai.RateTable, 1)
+		out.Table[0].Rate, err = exchangeRate(*acct)
+		if err != nil {
+			return out, err
+		}
+	}
+
+	// we have to add the uncredited EAI to the balance before calculating
+	// new EAI so that we grant the full amount. Failure to do so
+	// means that people won't earn EAI on what is currently uncredited.
+	pending, err := acct.Balance.Add(acct.UncreditedEAI)
+	if err != nil {
+		return out, err
+	}
+
+	// when the EAI overtime duration is set, this is the maximum amount
+	// of EAI which can be applied by a CreditEAI transaction. This
+	// encourages node operators to issue the tx regularly.
+	lastUpdate := acct.LastEAIUpdate
+	if p.Overtime != nil && lastUpdate.Add(*p.Overtime) < blockTime {
+		lastUpdate = blockTime.Sub(*p.Overtime)
+	}
+
+	if acct.Lock != nil && p.UseCurrentLockBonus {
+		acct.Lock.Bonus = p.LockedTable.RateAt(acct.Lock.NoticePeriod)
+	}
+
+	out.Award, err = eai.Calculate(
+		pending, blockTime, lastUpdate,
+		acct.WeightedAverageAge, acct.Lock,
+		out.Table, p.FixEAIUnlockBug,
+	)
+	if err != nil {
+		return out, err
+	}
+	out.Award, err = out.Award.Add(acct.UncreditedEAI)
+	if err != nil {
+		return out, err
+	}
+
+	// now reduce the award to account for the fees
+	reduced, err := p.AfterFees(int64(out.Award))
+	if err != nil {
+		return out, err
+	}
+	out.Net = math.Ndau(reduced)
+	return out, nil
+}
+
+// AfterFees reduces an amount of EAI, or an EAI rate, by the EAI fees.
+func (p *EAIParams) AfterFees(qty int64) (int64, error) {
+	return signed.MulDiv(qty, int64(p.AwardPerNdau), constants.QuantaPerUnit)
+}
@@ -0,0 +1,141 @@
+package ndau
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+
+	"github.com/ndau/chaincode/pkg/vm"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau/backing"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/bitset256"
+	sv "github.com/ndau/system_vars/pkg/system_vars"
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func debugVMQuery(appI interface{}, request abci.RequestQuery, response *abci.ResponseQuery) {
+	app := appI.(*App)
+
+	var req query.VMDebugRequest
+	_, err := req.UnmarshalMsg(request.GetData())
+	if err != nil {
+		app.QueryError(err, response, "deserializing request")
+		return
+	}
+
+	theVM, code, err := app.debugVM(req)
+	if err != nil {
+		app.QueryError(err, response, "building "+req.VM+" vm")
+		return
+	}
+
+	result := query.VMDebugResponse{
+		VM:          req.VM,
+		Disassembly: disassemble(theVM, code),
+	}
+	result.Trace, err = runTraced(theVM)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.Stack = stackValues(theVM)
+
+	resultBytes, err := result.MarshalMsg(nil)
+	if err != nil {
+		app.QueryError(err, response, "serializing vm debug result")
+		return
+	}
+	response.Value = resultBytes
+}
+
+// debugVM builds a VM as the app does at the current height, returning it with its code
+func (app *App) debugVM(req query.VMDebugRequest) (*vm.ChaincodeVM, []byte, error) {
+	switch req.VM {
+	case query.VMNodeGoodness:
+		return app.goodnessVM(req.Node)
+	case query.VMSIB:
+		theVM, _, script, err := app.sibVM(app.GetState().(*backing.State), -1, -1)
+		return theVM, script, err
+	}
+
+	// everything else is about a tx
+	tx, err := metatx.Unmarshal(req.Tx, TxIDs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "deserializing transactable")
+	}
+
+	switch req.VM {
+	case query.VMTxFees:
+		return app.txFeeVM(tx)
+	case query.VMTxValidation:
+		return app.txValidationVM(tx)
+	case query.VMRulesValidation:
+		return app.rulesValidationVM(tx)
+	}
+	return nil, nil, fmt.Errorf("unknown vm: %q", req.VM)
+}
+
+// txValidationVM builds the VM which runs the validation script of tx's source, as
+// getTxAccount does
+func (app *App) txValidationVM(mtx metatx.Transactable) (*vm.ChaincodeVM, []byte, error) {
+	tx, ok := mtx.(NTransactable)
+	if !ok {
+		return nil, nil, fmt.Errorf("tx %s not an NTransactable", metatx.NameOf(mtx))
+	}
+	source, err := tx.GetSource(app)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "getting tx source")
+	}
+	acct, _ := app.getAccount(source)
+	if len(acct.ValidationScript) == 0 {
+		return nil, nil, errors.New("source account has no validation script")
+	}
+
+	sigset := bitset256.New()
+	if signed, isSigned := tx.(Signeder); isSigned {
+		_, sigset = acct.ValidateSignatures(tx.SignableBytes(), signed.GetSignatures())
+	}
+
+	theVM, err := BuildVMForTxValidation(acct.ValidationScript, acct, tx, sigset, app)
+	return theVM, acct.ValidationScript, err
+}
+
+// rulesValidationVM builds the VM which runs the stake rules governing tx
+func (app *App) rulesValidationVM(tx metatx.Transactable) (*vm.ChaincodeVM, []byte, error) {
+	state := app.GetState().(*backing.State)
+
+	var rules address.Address
+	var rulesAcct []address.Address
+	switch t := tx.(type) {
+	case *Stake:
+		rules = t.Rules
+	case *Unstake:
+		rules = t.Rules
+	case *ResolveStake:
+		rules = t.Rules
+	case *RegisterNode:
+		err := app.System(sv.NodeRulesAccountAddressName, &rules)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "getting node rules sysvar")
+		}
+		rulesAcct = append(rulesAcct, rules)
+	default:
+		return nil, nil, fmt.Errorf("%s txs are not governed by stake rules", metatx.NameOf(tx))
+	}
+
+	theVM, err := BuildVMForRulesValidation(tx, state, rulesAcct...)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the builder has checked that these exist
+	return theVM, state.Accounts[rules.String()].StakeRules.Script, nil
+}
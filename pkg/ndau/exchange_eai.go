@@ -24,7 +24,13 @@ func (app *App) calculateExchangeEAIRate(exchangeAccount backing.AccountData) (e
 		return 0, errors.Wrap(err, "Could not fetch ExchangeEAIScript system variable")
 	}
 
-	vm, err := BuildVMForExchangeEAI(script, exchangeAccount, app.GetState().(*backing.State).SIB)
+	return ExchangeEAIRate(script, exchangeAccount, app.GetState().(*backing.State).SIB)
+}
+
+// ExchangeEAIRate runs the exchange EAI script to calculate the flat EAI rate of an
+// exchange account, given the current SIB rate.
+func ExchangeEAIRate(script []byte, exchangeAccount backing.AccountData, sib eai.Rate) (eai.Rate, error) {
+	vm, err := BuildVMForExchangeEAI(script, exchangeAccount, sib)
 	if err != nil {
 		return 0, errors.Wrap(err, "Could not build vm for exchange EAI script")
 	}
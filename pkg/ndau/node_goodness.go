@@ -12,6 +12,7 @@ package ndau
 import (
 	"sort"
 
+	"github.com/ndau/chaincode/pkg/vm"
 	"github.com/ndau/msgp-well-known-types/wkt"
 	"github.com/ndau/ndau/pkg/ndau/backing"
 	"github.com/ndau/ndaumath/pkg/address"
@@ -23,39 +24,72 @@ import (
 )
 
 func (app *App) goodnessOf(addrS string) (int64, error) {
+	addr, err := address.Validate(addrS)
+	if err != nil {
+		return 0, err
+	}
+
+	theVM, _, err := app.goodnessVM(addrS)
+	if err != nil {
+		return 0, err
+	}
+
+	err = theVM.Run(nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "running goodness vm")
+	}
+
+	goodness, err := theVM.Stack().PopAsInt64()
+	if err != nil {
+		return goodness, errors.Wrap(err, "goodness stack top not numeric")
+	}
+
+	// dump goodness value after chaincode is called
+	logger := app.DecoratedLogger().WithFields(log.Fields{
+		"address": addr,
+		"value":   goodness,
+	})
+	logger.Info("nodegoodness value")
+
+	return goodness, nil
+}
+
+// goodnessVM builds the VM which calculates the goodness of a node, returning it
+// with the goodness script
+func (app *App) goodnessVM(addrS string) (*vm.ChaincodeVM, wkt.Bytes, error) {
 	state := app.GetState().(*backing.State)
 
 	addr, err := address.Validate(addrS)
 	if err != nil {
-		return 0, err
+		return nil, nil, err
 	}
 
 	acct, hasAcct := app.getAccount(addr)
 	if !hasAcct {
-		return 0, errors.New("no such account")
+		return nil, nil, errors.New("no such account")
 	}
 
 	node, hasNode := state.Nodes[addrS]
 	if !hasNode {
-		return 0, errors.New("no such node")
+		return nil, nil, errors.New("no such node")
 	}
 
 	var script wkt.Bytes
 	err = app.System(sv.NodeGoodnessFuncName, &script)
 	if err != nil {
-		return 0, errors.Wrap(err, "getting goodness script")
+		return nil, nil, errors.Wrap(err, "getting goodness script")
 	}
 
 	totalStake := math.Ndau(0)
 	costakers, err := app.NodeStakers(addr)
 	if err != nil {
-		return 0, errors.Wrap(err, "getting node stakers")
+		return nil, script, errors.Wrap(err, "getting node stakers")
 	}
 	for _, v := range costakers {
 		totalStake += v
 	}
 
-	vm, err := BuildVMForNodeGoodness(
+	theVM, err := BuildVMForNodeGoodness(
 		script,
 		addr,
 		node.TMAddress,
@@ -67,27 +101,9 @@ func (app *App) goodnessOf(addrS string) (int64, error) {
 		app,
 	)
 	if err != nil {
-		return 0, errors.Wrap(err, "building goodness vm")
-	}
-
-	err = vm.Run(nil)
-	if err != nil {
-		return 0, errors.Wrap(err, "running goodness vm")
-	}
-
-	goodness, err := vm.Stack().PopAsInt64()
-	if err != nil {
-		return goodness, errors.Wrap(err, "goodness stack top not numeric")
+		return nil, script, errors.Wrap(err, "building goodness vm")
 	}
-
-	// dump goodness value after chaincode is called
-	logger := app.DecoratedLogger().WithFields(log.Fields{
-		"address": addr,
-		"value":   goodness,
-	})
-	logger.Info("nodegoodness value")
-
-	return goodness, nil
+	return theVM, script, nil
 }
 
 type goodnessPair struct {
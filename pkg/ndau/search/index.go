@@ -23,14 +23,28 @@ import (
 	math "github.com/ndau/ndaumath/pkg/types"
 )
 
-// This is used to be able to give transactions a float64 score in a sorted set where the integer
-// part of the score is the block height, and the fractional part contains the tx offset within
-// the block.  Typically there are zero or one transactions in a block.  If we ever had anything
-// close to this many transactions in a block, it will be a good problem to have.
-// For example, the 3rd transaction (tx offset = 2) in block 10 would have a score of 10.002.
-// Float determinism is not a concern here since we just want each transaction to have a unique
-// and well-defined order on the blockchain when compared to other transactions.
-const maxTxsPerBlock = 1000
+// Transactions are given a score in a sorted set which combines the block height and the tx
+// offset within the block into a single integer: the height occupies the high bits, and the tx
+// offset the low txOffsetBits bits.  Redis scores are float64s, which represent integers exactly
+// up to 2^53, so this gives an exact ordering for up to 2^20 transactions per block at heights
+// below 2^33.  Beyond those limits we refuse to index rather than silently misorder.
+const (
+	txOffsetBits     = 20
+	maxTxsPerBlock   = 1 << txOffsetBits
+	maxTxScoreHeight = 1 << (53 - txOffsetBits)
+)
+
+// txScore returns the exact sorted set score for the tx at the given height and offset.
+// Use a tx offset of zero to get the lowest score of any tx in the block.
+func txScore(blockHeight uint64, txOffset int) (float64, error) {
+	if txOffset < 0 || txOffset >= maxTxsPerBlock {
+		return 0, fmt.Errorf("tx offset out of range: %d >= %d", txOffset, maxTxsPerBlock)
+	}
+	if blockHeight >= maxTxScoreHeight {
+		return 0, fmt.Errorf("block height out of range: %d >= %d", blockHeight, maxTxScoreHeight)
+	}
+	return float64(blockHeight<<txOffsetBits | uint64(txOffset)), nil
+}
 
 // Client is a search Client that implements IncrementalIndexer.
 type Client struct {
@@ -57,14 +71,41 @@ type Client struct {
 	marketPrice pricecurve.Nanocent
 	targetPrice pricecurve.Nanocent
 
+	// Used for indexing node reward nominations, and claims against them, in this block.
+	// A claim made against a nomination from an earlier block causes that nomination to be
+	// loaded from the index and appended here so that it gets re-indexed as claimed.
+	nodeRewards []*NodeRewardValueData
+
+	// The most recently indexed supply totals.  We only index supply at blocks where it changed,
+	// so we keep this around across blocks to compare against.  It's loaded from the index the
+	// first time we need it.
+	lastSupply *SupplyValueData
+
 	// These pertain to the current block we're indexing.
 	blockTime   math.Timestamp
 	blockHeight uint64
 
 	// The next height we will index after the current incremental/initial indexing completes.
 	nextHeight uint64
+
+	// Initial indexing fans out the extraction of data from blocks among this many workers,
+	// and checkpoints its progress after crawling this many blocks.
+	workers            int
+	checkpointInterval uint64
+
+	// Nonzero while initial indexing is catching up in the background.  Accessed atomically.
+	behind int32
+
+	// Scheduled unlocks are part of the current state rather than its history, so initial
+	// indexing can't crawl them.  Instead it sets unlocksPending, along with the height the index
+	// had reached, and the next block we index incrementally picks them up from the state.
+	unlocksPending bool
+	unlocksFrom    uint64
 }
 
+// The default number of blocks initial indexing crawls between checkpoints of its progress.
+const defaultCheckpointInterval = 1000
+
 // NewClient is a factory method for Client.
 func NewClient(address string, version int, app AppIndexable) (search *Client, err error) {
 	search = &Client{}
@@ -73,6 +114,11 @@ func NewClient(address string, version int, app AppIndexable) (search *Client, e
 		return nil, err
 	}
 
+	err = search.migrate()
+	if err != nil {
+		return nil, err
+	}
+
 	search.sysvarKeyToValueData = nil
 	search.app = app
 	search.txs = nil
@@ -80,10 +126,24 @@ func NewClient(address string, version int, app AppIndexable) (search *Client, e
 	search.blockHash = ""
 	search.blockHeight = 0
 	search.nextHeight = 0
+	search.workers = 1
+	search.checkpointInterval = defaultCheckpointInterval
 
 	return search, nil
 }
 
+// ConfigureInitialIndexing sets the number of workers among which initial indexing fans out the
+// extraction of data from each block, and the number of blocks it crawls between checkpoints of
+// its progress.  Zero values leave the current settings in place.
+func (search *Client) ConfigureInitialIndexing(workers int, checkpointInterval uint64) {
+	if workers > 0 {
+		search.workers = workers
+	}
+	if checkpointInterval > 0 {
+		search.checkpointInterval = checkpointInterval
+	}
+}
+
 // Index all the key-value pairs in the search's sysvarKeyToValueData mapping, then clear the map.
 // checkForDupes is used for merging any duplicate keys we find in the mapping.
 func (search *Client) onIndexingComplete(
@@ -167,9 +227,13 @@ func (search *Client) onIndexingComplete(
 	search.blockHeight = 0
 	search.marketPrice = 0
 	search.targetPrice = 0
+	search.nodeRewards = nil
 
-	// Save this off so the next initial scan will only go this far.
-	search.Client.SetNextHeight(search.nextHeight)
+	// Save this off so the next initial scan will only go this far.  Initial indexing in the
+	// background may complete after incremental indexing has moved past it; never go backward.
+	if search.nextHeight > search.Client.GetNextHeight() {
+		search.Client.SetNextHeight(search.nextHeight)
+	}
 
 	return updateCount, insertCount, nil
 }
@@ -223,15 +287,12 @@ func (search *Client) indexKeyValueWithHistory(searchKey, searchValue string) (
 func (search *Client) indexTxType(txType, txHash string, blockHeight uint64, txOffset int) (
 	updateCount int, insertCount int, err error,
 ) {
-	if txOffset < 0 || txOffset >= maxTxsPerBlock {
-		// If this happens, we either have to increase maxTxsPerBlock or compute height-txoffset
-		// scores in a different way that doesn't have this limitation.  Either way, a full re-
-		// index will be necessary once solved.
-		return 0, 0, fmt.Errorf("Tx offset out of range: %d >= %d", txOffset, maxTxsPerBlock)
+	score, err := txScore(blockHeight, txOffset)
+	if err != nil {
+		return 0, 0, err
 	}
 
 	searchKey := fmtTxTypeToHeight(txType)
-	score := float64(blockHeight) + float64(txOffset)/float64(maxTxsPerBlock)
 	count, err := search.Client.ZAdd(searchKey, score, txHash)
 	if err != nil {
 		return 0, 0, err
@@ -248,18 +309,25 @@ func (search *Client) indexTxType(txType, txHash string, blockHeight uint64, txO
 	return updateCount, insertCount, nil
 }
 
-// Index all the sysvar key-value pairs in the given state at the current search.blockHeight.
+// Extract the sysvar search keys and base64 values to index from the given state.
+// This doesn't touch the Client, so it's safe to call concurrently.
+func sysvarValues(st *backing.State) map[string]string {
+	values := make(map[string]string, len(st.Sysvars))
+	for key, value := range st.Sysvars {
+		values[fmtSysvarKeyToValue(key)] = base64.StdEncoding.EncodeToString(value)
+	}
+	return values
+}
+
+// Index all the sysvar key-value pairs extracted by sysvarValues() from the state at the current
+// search.blockHeight.
 func (search *Client) indexState(
-	st *backing.State,
+	values map[string]string,
 ) (updateCount int, insertCount int, err error) {
 	updateCount = 0
 	insertCount = 0
 
-	for key, value := range st.Sysvars {
-		valueBase64 := base64.StdEncoding.EncodeToString(value)
-
-		searchKey := fmtSysvarKeyToValue(key)
-
+	for searchKey, valueBase64 := range values {
 		// Detect the first time we've encountered this key.
 		data, hasValue := search.sysvarKeyToValueData[searchKey]
 		if !hasValue {
@@ -395,53 +463,425 @@ func (search *Client) index() (updateCount int, insertCount int, err error) {
 				if err != nil {
 					return updateCount, insertCount, err
 				}
+
+				updCount, insCount, err = search.indexUnlock(addr, acct)
+				updateCount += updCount
+				insertCount += insCount
+				if err != nil {
+					return updateCount, insertCount, err
+				}
 			}
 		}
 	}
 
 	// record the market price at this block, if any
 	if search.marketPrice != 0 {
-		k := fmtMarketPriceKey(search.blockHeight, search.blockTime)
-		v := fmt.Sprint(int64(search.marketPrice))
-		// record the price with the appropriate key
-		updCount, insCount, err := search.indexKeyValue(k, v)
+		updCount, insCount, err := search.indexAtBlockTime(
+			marketPriceKeysetKey,
+			fmtMarketPriceKey(search.blockHeight, search.blockTime),
+			fmt.Sprint(int64(search.marketPrice)),
+		)
+		updateCount += updCount
+		insertCount += insCount
+		if err != nil {
+			return updateCount, insertCount, err
+		}
+	}
+
+	// record the target price at this block, if any
+	if search.targetPrice != 0 {
+		updCount, insCount, err := search.indexAtBlockTime(
+			targetPriceKeysetKey,
+			fmtTargetPriceKey(search.blockHeight, search.blockTime),
+			fmt.Sprint(int64(search.targetPrice)),
+		)
 		updateCount += updCount
 		insertCount += insCount
 		if err != nil {
 			return updateCount, insertCount, err
 		}
-		// add to the list of price keys
-		// using the timestamp as score means we can search by timestamp
-		// ranges directly, and also by block height range by going throguh
-		// the height to timestamp indirection
-		insCnt, err := search.Client.ZAdd(marketPriceKeysetKey, float64(search.blockTime), k)
-		insertCount += int(insCnt)
+	}
+
+	// record node reward nominations and claims at this block, if any
+	for _, reward := range search.nodeRewards {
+		updCount, insCount, err := search.indexNodeReward(reward)
+		updateCount += updCount
+		insertCount += insCount
 		if err != nil {
 			return updateCount, insertCount, err
 		}
 	}
 
-	// record the target price at this block, if any
-	if search.targetPrice != 0 {
-		k := fmtTargetPriceKey(search.blockHeight, search.blockTime)
-		v := fmt.Sprint(int64(search.targetPrice))
-		// record the price with the appropriate key
-		updCount, insCount, err := search.indexKeyValue(k, v)
+	// record the supply at this block; only transactions can change it
+	if len(search.txs) > 0 {
+		updCount, insCount, err := search.indexSupply()
+		updateCount += updCount
+		insertCount += insCount
+		if err != nil {
+			return updateCount, insertCount, err
+		}
+	}
+
+	return updateCount, insertCount, nil
+}
+
+// Index a single key-value pair at the current search.blockTime.
+//
+// The key is also added to the given keyset.  Using the timestamp as score means we can search
+// by timestamp ranges directly, and also by block height range by going through the height to
+// timestamp indirection.
+func (search *Client) indexAtBlockTime(keyset, searchKey, searchValue string) (
+	updateCount int, insertCount int, err error,
+) {
+	updateCount, insertCount, err = search.indexKeyValue(searchKey, searchValue)
+	if err != nil {
+		return updateCount, insertCount, err
+	}
+	insCnt, err := search.Client.ZAdd(keyset, float64(search.blockTime), searchKey)
+	insertCount += int(insCnt)
+	return updateCount, insertCount, err
+}
+
+// Index the supply totals at the current search.blockHeight, if they differ from the most
+// recently indexed supply totals.  The totals are those of the state the block ended with.
+// The SIB and endowment NAV histories are indexed from the same data, at the blocks where
+// they changed.
+//
+// This is only done by incremental indexing.  Initial indexing doesn't backfill these
+// histories: totaling the supply means walking every account, which is affordable once per
+// block but not at every historical height, so they begin at the block where incremental
+// indexing began.
+func (search *Client) indexSupply() (updateCount int, insertCount int, err error) {
+	supply, err := search.app.GetSupply()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if search.lastSupply == nil {
+		search.lastSupply, err = search.latestSupply()
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	// compare against the zero value if nothing has been indexed yet
+	var last SupplyValueData
+	if search.lastSupply != nil {
+		last = *search.lastSupply
+	}
+	if last == supply {
+		return 0, 0, nil
+	}
+
+	updateCount, insertCount, err = search.indexAtBlockTime(
+		supplyKeysetKey,
+		fmtSupplyKey(search.blockHeight, search.blockTime),
+		supply.Marshal(),
+	)
+	if err != nil {
+		return updateCount, insertCount, err
+	}
+
+	if supply.SIB != last.SIB || supply.FloorPrice != last.FloorPrice {
+		sib := SIBValueData{
+			SIB:        supply.SIB,
+			FloorPrice: supply.FloorPrice,
+		}
+		updCount, insCount, err := search.indexAtBlockTime(
+			sibKeysetKey,
+			fmtSIBKey(search.blockHeight, search.blockTime),
+			sib.Marshal(),
+		)
 		updateCount += updCount
 		insertCount += insCount
 		if err != nil {
 			return updateCount, insertCount, err
 		}
-		// add to the list of price keys
-		// using the timestamp as score means we can search by timestamp
-		// ranges directly, and also by block height range by going throguh
-		// the height to timestamp indirection
-		insCnt, err := search.Client.ZAdd(targetPriceKeysetKey, float64(search.blockTime), k)
-		insertCount += int(insCnt)
+	}
+
+	if supply.EndowmentNAV != last.EndowmentNAV {
+		updCount, insCount, err := search.indexAtBlockTime(
+			navKeysetKey,
+			fmtNAVKey(search.blockHeight, search.blockTime),
+			fmt.Sprint(int64(supply.EndowmentNAV)),
+		)
+		updateCount += updCount
+		insertCount += insCount
 		if err != nil {
 			return updateCount, insertCount, err
 		}
 	}
 
+	search.lastSupply = &supply
 	return updateCount, insertCount, nil
 }
+
+// Get the most recently indexed supply totals.
+// Returns nil and no error if no supply totals have been indexed yet.
+func (search *Client) latestSupply() (*SupplyValueData, error) {
+	ks, err := search.Client.ZRevRange(supplyKeysetKey, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(ks) == 0 {
+		return nil, nil
+	}
+
+	searchValue, err := search.Client.Get(ks[0])
+	if err != nil {
+		return nil, err
+	}
+	if searchValue == "" {
+		return nil, nil
+	}
+
+	supply := new(SupplyValueData)
+	err = supply.Unmarshal(searchValue)
+	if err != nil {
+		return nil, err
+	}
+	return supply, nil
+}
+
+// Index a node reward nomination, along with its claim if any.
+func (search *Client) indexNodeReward(reward *NodeRewardValueData) (
+	updateCount int, insertCount int, err error,
+) {
+	searchKey := fmtNodeRewardKey(reward.Height)
+	updateCount, insertCount, err = search.indexKeyValue(searchKey, reward.Marshal())
+	if err != nil {
+		return updateCount, insertCount, err
+	}
+
+	insCnt, err := search.Client.ZAdd(
+		fmtNodeToRewards(reward.Winner), float64(reward.Height), searchKey,
+	)
+	insertCount += int(insCnt)
+	if err != nil {
+		return updateCount, insertCount, err
+	}
+
+	if reward.Height == search.blockHeight {
+		updCount, insCount, err := search.indexKeyValue(nodeRewardLatestKey, searchKey)
+		updateCount += updCount
+		insertCount += insCount
+		if err != nil {
+			return updateCount, insertCount, err
+		}
+	}
+
+	return updateCount, insertCount, nil
+}
+
+// Record a node reward claim by the given node against the most recent nomination.
+//
+// Only the winner of the most recent nomination can claim, so if the nomination isn't one we've
+// seen in the current block, it's the latest one in the index.  Repeated claims are ignored; only
+// the first one actually received the reward.
+func (search *Client) claimNodeReward(node, txHash string) error {
+	var reward *NodeRewardValueData
+	if len(search.nodeRewards) > 0 {
+		reward = search.nodeRewards[len(search.nodeRewards)-1]
+	} else {
+		var err error
+		reward, err = search.latestNodeReward()
+		if err != nil {
+			return err
+		}
+		if reward == nil || reward.Claimed || reward.Winner != node {
+			return nil
+		}
+		search.nodeRewards = append(search.nodeRewards, reward)
+	}
+
+	if reward.Claimed || reward.Winner != node {
+		return nil
+	}
+
+	reward.Claimed = true
+	reward.ClaimHeight = search.blockHeight
+	reward.ClaimTimestamp = search.blockTime
+	reward.ClaimTxHash = txHash
+	return nil
+}
+
+// Get the most recently indexed node reward nomination.
+// Returns nil and no error if no nominations have been indexed yet.
+func (search *Client) latestNodeReward() (*NodeRewardValueData, error) {
+	searchKey, err := search.Client.Get(nodeRewardLatestKey)
+	if err != nil {
+		return nil, err
+	}
+	if searchKey == "" {
+		return nil, nil
+	}
+
+	searchValue, err := search.Client.Get(searchKey)
+	if err != nil {
+		return nil, err
+	}
+	if searchValue == "" {
+		return nil, nil
+	}
+
+	reward := new(NodeRewardValueData)
+	err = reward.Unmarshal(searchValue)
+	if err != nil {
+		return nil, err
+	}
+	return reward, nil
+}
+
+// Index the scheduled unlock, if any, of the given account as of the current search.blockTime.
+//
+// Only notified locks have an unlock time.  Relocking a notified account before it unlocks
+// cancels the scheduled unlock, so we remove it from the index.  Once an unlock time has passed,
+// we leave its entry alone as history.
+func (search *Client) indexUnlock(addr string, acct backing.AccountData) (
+	updateCount int, insertCount int, err error,
+) {
+	var unlocksOn *math.Timestamp
+	if acct.Lock != nil {
+		unlocksOn = acct.Lock.UnlocksOn
+	}
+	if unlocksOn != nil && unlocksOn.Compare(search.blockTime) <= 0 {
+		return 0, 0, nil
+	}
+
+	addrKey := fmtAddressToUnlock(addr)
+	existingKey, err := search.Client.Get(addrKey)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var searchKey string
+	if unlocksOn != nil {
+		searchKey = fmtUnlockKey(addr, *unlocksOn)
+	}
+
+	if existingKey != "" && existingKey != searchKey {
+		err = search.removeUnlock(existingKey)
+		if err != nil {
+			return 0, 0, err
+		}
+		if unlocksOn == nil {
+			err = search.Client.Inner().Del(addrKey).Err()
+			return 0, 0, err
+		}
+	}
+	if unlocksOn == nil {
+		return 0, 0, nil
+	}
+
+	// keep the day's totals in step with what we're replacing
+	accounts, balance := int64(1), acct.Balance
+	if existingKey == searchKey {
+		existing, err := search.Client.Get(searchKey)
+		if err != nil {
+			return 0, 0, err
+		}
+		if existing != "" {
+			prev := UnlockValueData{}
+			err = prev.Unmarshal(existing)
+			if err != nil {
+				return 0, 0, err
+			}
+			accounts, balance = 0, acct.Balance-prev.Balance
+		}
+	}
+
+	unlock := UnlockValueData{
+		Address:   addr,
+		UnlocksOn: *unlocksOn,
+		Balance:   acct.Balance,
+		Height:    search.blockHeight,
+	}
+	updateCount, insertCount, err = search.indexKeyValue(searchKey, unlock.Marshal())
+	if err != nil {
+		return updateCount, insertCount, err
+	}
+	err = search.addToUnlockDay(*unlocksOn, accounts, balance)
+	if err != nil {
+		return updateCount, insertCount, err
+	}
+	insCnt, err := search.Client.ZAdd(unlockKeysetKey, float64(*unlocksOn), searchKey)
+	insertCount += int(insCnt)
+	if err != nil {
+		return updateCount, insertCount, err
+	}
+	updCount, insCount, err := search.indexKeyValue(addrKey, searchKey)
+	updateCount += updCount
+	insertCount += insCount
+	return updateCount, insertCount, err
+}
+
+// Index the scheduled unlocks of every account in the given state, as of the current
+// search.blockHeight and search.blockTime.
+func (search *Client) indexUnlocks(st *backing.State) (
+	updateCount int, insertCount int, err error,
+) {
+	for addr, acct := range st.Accounts {
+		updCount, insCount, err := search.indexUnlock(addr, acct)
+		updateCount += updCount
+		insertCount += insCount
+		if err != nil {
+			return updateCount, insertCount, err
+		}
+	}
+	return updateCount, insertCount, nil
+}
+
+// Remove a scheduled unlock from the index, unless its unlock time has already passed.
+func (search *Client) removeUnlock(searchKey string) error {
+	searchValue, err := search.Client.Get(searchKey)
+	if err != nil {
+		return err
+	}
+	if searchValue != "" {
+		unlock := UnlockValueData{}
+		err = unlock.Unmarshal(searchValue)
+		if err != nil {
+			return err
+		}
+		if unlock.UnlocksOn.Compare(search.blockTime) <= 0 {
+			return nil
+		}
+		err = search.addToUnlockDay(unlock.UnlocksOn, -1, -unlock.Balance)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = search.Client.Inner().ZRem(unlockKeysetKey, searchKey).Err()
+	if err != nil {
+		return err
+	}
+	return search.Client.Inner().Del(searchKey).Err()
+}
+
+// Adjust the totals of the day containing the given unlock time.  A day with no accounts left
+// unlocking on it is removed.
+func (search *Client) addToUnlockDay(
+	unlocksOn math.Timestamp, accounts int64, balance math.Ndau,
+) error {
+	day := unlockDay(unlocksOn)
+	dayKey := fmtUnlockDayKey(day)
+
+	remaining, err := search.Client.Inner().HIncrBy(dayKey, unlockDayAccounts, accounts).Result()
+	if err != nil {
+		return err
+	}
+	err = search.Client.Inner().HIncrBy(dayKey, unlockDayBalance, int64(balance)).Err()
+	if err != nil {
+		return err
+	}
+
+	if remaining <= 0 {
+		err = search.Client.Inner().ZRem(unlockDayKeysetKey, dayKey).Err()
+		if err != nil {
+			return err
+		}
+		return search.Client.Inner().Del(dayKey).Err()
+	}
+	_, err = search.Client.ZAdd(unlockDayKeysetKey, float64(day), dayKey)
+	return err
+}
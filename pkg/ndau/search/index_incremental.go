@@ -17,17 +17,37 @@ import (
 	metatx "github.com/ndau/metanode/pkg/meta/transaction"
 	"github.com/ndau/ndau/pkg/ndau/backing"
 	math "github.com/ndau/ndaumath/pkg/types"
+	"github.com/pkg/errors"
 )
 
 // OnBeginBlock resets our local cache for incrementally indexing the block at the given height.
+//
+// If initial indexing left scheduled unlocks for us, we index them here from the state of the
+// previous block.  Doing it in step with incremental indexing means nothing else can be
+// indexing the same accounts' unlocks at the same time.  Unlocks which have passed by the time
+// of this block are skipped, as they would have been had we indexed every block as it came.
 func (search *Client) OnBeginBlock(height uint64, blockTime math.Timestamp, tmHash string) error {
 	// There's only one block to consider for incremental indexing.
 	search.sysvarKeyToValueData = make(map[string]*ValueData)
 	search.txs = nil
+	search.nodeRewards = nil
 	search.blockTime = blockTime
 	search.blockHash = tmHash
-	search.blockHeight = height
 	search.nextHeight = height + 1
+
+	if search.unlocksPending {
+		search.unlocksPending = false
+		// If the index was already up to date, so are its unlocks.
+		if height > search.unlocksFrom {
+			search.blockHeight = height - 1
+			_, _, err := search.indexUnlocks(search.app.GetState().(*backing.State))
+			if err != nil {
+				return errors.Wrap(err, "indexing scheduled unlocks")
+			}
+		}
+	}
+
+	search.blockHeight = height
 	return nil
 }
 
@@ -64,6 +84,26 @@ func (search *Client) OnDeliverTx(appI interface{}, tx metatx.Transactable) erro
 		search.targetPrice = state.TargetPrice
 	}
 
+	if indexable, ok := tx.(NodeRewardNominationIndexable); ok {
+		state := app.GetState().(*backing.State)
+		if state.NodeRewardWinner != nil {
+			search.nodeRewards = append(search.nodeRewards, &NodeRewardValueData{
+				Height:    search.blockHeight,
+				Timestamp: search.blockTime,
+				Random:    indexable.GetRandom(),
+				Winner:    state.NodeRewardWinner.String(),
+				Amount:    state.UnclaimedNodeReward,
+			})
+		}
+	}
+
+	if indexable, ok := tx.(NodeRewardClaimIndexable); ok {
+		err := search.claimNodeReward(indexable.GetClaimingNode().String(), metatx.Hash(tx))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
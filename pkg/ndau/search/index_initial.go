@@ -13,23 +13,159 @@ package search
 
 import (
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ndau/noms/go/datas"
 	"github.com/ndau/metanode/pkg/meta/state"
 	"github.com/ndau/ndau/pkg/ndau/backing"
 	math "github.com/ndau/ndaumath/pkg/types"
+	"github.com/pkg/errors"
 )
 
+// A block crawled by initial indexing, along with the data we extract from it to index.
+type crawledBlock struct {
+	height uint64
+	st     *backing.State
+
+	// Filled in by extract().  When workers are in use, ready is closed once it has been.
+	values map[string]string
+	ready  chan struct{}
+}
+
+func (b *crawledBlock) extract() {
+	b.values = sysvarValues(b.st)
+	// We've got what we need; don't hang onto the rest of the state while it waits its turn.
+	b.st = nil
+}
+
 // IndexBlockchain fills the index with data from the blockchain,
 // from the head block down to just before the last block we indexed.
+//
+// Progress is checkpointed in the index along the way.  If initial indexing is interrupted,
+// the next call resumes from the last checkpoint rather than starting over.
 func (search *Client) IndexBlockchain(
 	db datas.Database, ds datas.Dataset,
+) (updateCount int, insertCount int, err error) {
+	minHeightToIndex := search.Client.GetNextHeight()
+	checkpoint, err := search.beginCrawl(minHeightToIndex)
+	if err != nil {
+		return 0, 0, err
+	}
+	search.unlocksPending = true
+	search.unlocksFrom = minHeightToIndex
+	return search.crawl(db, ds, checkpoint)
+}
+
+// IndexBlockchainInBackground is like IndexBlockchain, but returns immediately, calling done
+// with the results of each attempt at initial indexing.
+//
+// A failed attempt is retried from its last checkpoint, after a delay which doubles with each
+// consecutive failure.  Meanwhile, the client can incrementally index new blocks and serve
+// searches, and IndexBehind() reports true until an attempt completes successfully.
+func (search *Client) IndexBlockchainInBackground(
+	db datas.Database, ds datas.Dataset,
+	done func(updateCount int, insertCount int, err error),
+) {
+	// Initial indexing must not share per-block state with incremental indexing,
+	// so it gets a client of its own on the same index.
+	crawler := &Client{
+		Client:             search.Client,
+		app:                search.app,
+		workers:            search.workers,
+		checkpointInterval: search.checkpointInterval,
+	}
+
+	// Record where we're starting before incremental indexing has a chance to move on.
+	minHeightToIndex := search.Client.GetNextHeight()
+	search.unlocksPending = true
+	search.unlocksFrom = minHeightToIndex
+
+	atomic.StoreInt32(&search.behind, 1)
+	go func() {
+		delay := minBackgroundRetryDelay
+		for {
+			var updateCount, insertCount int
+			checkpoint, err := crawler.beginCrawl(minHeightToIndex)
+			if err == nil {
+				updateCount, insertCount, err = crawler.crawl(db, ds, checkpoint)
+			}
+			if err == nil {
+				atomic.StoreInt32(&search.behind, 0)
+			}
+			if done != nil {
+				done(updateCount, insertCount, err)
+			}
+			if err == nil {
+				return
+			}
+
+			time.Sleep(delay)
+			delay *= 2
+			if delay > maxBackgroundRetryDelay {
+				delay = maxBackgroundRetryDelay
+			}
+		}
+	}()
+}
+
+// Background initial indexing waits this long to retry after its first failure, doubling the
+// delay after each consecutive failure up to the max.
+const (
+	minBackgroundRetryDelay = 10 * time.Second
+	maxBackgroundRetryDelay = 10 * time.Minute
+)
+
+// IndexBehind returns true while initial indexing is catching up in the background.
+// Searches may return incomplete results until it's done.
+func (search *Client) IndexBehind() bool {
+	return atomic.LoadInt32(&search.behind) != 0
+}
+
+// Load the checkpoint of an interrupted crawl, or start a new one down to the given height.
+func (search *Client) beginCrawl(minHeightToIndex uint64) (*InitialIndexCheckpoint, error) {
+	searchValue, err := search.Client.Get(initialIndexCheckpointKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting initial indexing checkpoint")
+	}
+	checkpoint := new(InitialIndexCheckpoint)
+	if searchValue != "" {
+		_, err = checkpoint.UnmarshalMsg([]byte(searchValue))
+		return checkpoint, errors.Wrap(err, "decoding initial indexing checkpoint")
+	}
+
+	checkpoint.MinHeight = minHeightToIndex
+	return checkpoint, search.saveCheckpoint(checkpoint)
+}
+
+func (search *Client) saveCheckpoint(checkpoint *InitialIndexCheckpoint) error {
+	checkpoint.Sysvars = make(map[string]ValueData, len(search.sysvarKeyToValueData))
+	for searchKey, data := range search.sysvarKeyToValueData {
+		checkpoint.Sysvars[searchKey] = *data
+	}
+	bytes, err := checkpoint.MarshalMsg(nil)
+	if err != nil {
+		return errors.Wrap(err, "encoding initial indexing checkpoint")
+	}
+	err = search.Client.Set(initialIndexCheckpointKey, string(bytes))
+	return errors.Wrap(err, "saving initial indexing checkpoint")
+}
+
+// Crawl the blockchain from the head block down to the checkpoint's min height,
+// skipping whatever the checkpoint says we've already indexed.
+func (search *Client) crawl(
+	db datas.Database, ds datas.Dataset, checkpoint *InitialIndexCheckpoint,
 ) (updateCount int, insertCount int, err error) {
 	updateCount = 0
 	insertCount = 0
 
-	// Start fresh.  It should already be zero'd out upon entry.
-	search.sysvarKeyToValueData = make(map[string]*ValueData)
+	// Start fresh, or from where we left off.
+	search.sysvarKeyToValueData = make(map[string]*ValueData, len(checkpoint.Sysvars))
+	for searchKey, data := range checkpoint.Sysvars {
+		data := data
+		search.sysvarKeyToValueData[searchKey] = &data
+	}
 	search.txs = nil
 	// TODO: We really should be using block time when indexing below, but we don't store block
 	// timestamps in noms.  So we must eventually write the external ndauindexer app.  Then we
@@ -37,22 +173,136 @@ func (search *Client) IndexBlockchain(
 	search.blockTime = math.Timestamp(0)
 	search.blockHash = ""
 	search.blockHeight = 0
-	search.nextHeight = 0
+	search.nextHeight = checkpoint.NextHeight
 
 	// The height encountered on the previous iteration of the loop below.
-	lastHeight := search.nextHeight
+	// It's set from the head block, which we always see first.
+	lastHeight := uint64(0)
+	sawHead := false
 
 	// One more than the height we indexed to the last time we indexed the blockchain.
 	// In other words, it's the height we want to index to this time.
-	minHeightToIndex := search.Client.GetNextHeight()
+	minHeightToIndex := checkpoint.MinHeight
+
+	// Every height at or above this one has already been indexed, if nonzero.
+	resumeHeight := checkpoint.Height
+
+	// Index a single block.  Blocks must be processed in the order they're crawled.
+	processed := uint64(0)
+	process := func(b *crawledBlock) error {
+		// The indexing code below uses this to know the current height.
+		search.blockHeight = b.height
+
+		// NOTE: This is currently a no-op since we didn't pull anything out of noms to put into
+		// the search client struct before calling index().  We keep this here in case we do find
+		// more that we want to index that also can be pulled from noms before this line.
+		updCount, insCount, err := search.index()
+		updateCount += updCount
+		insertCount += insCount
+		if err != nil {
+			return err
+		}
+
+		// Index sysvar key-value history, which we pull from noms.
+		updCount, insCount, err = search.indexState(b.values)
+		updateCount += updCount
+		insertCount += insCount
+		if err != nil {
+			return err
+		}
+
+		// Heights 0 and 1 can appear more than once, so we never checkpoint there.
+		processed++
+		if processed%search.checkpointInterval == 0 && b.height > 1 {
+			checkpoint.Height = b.height
+			return search.saveCheckpoint(checkpoint)
+		}
+		return nil
+	}
+
+	// Hand a block off for processing.  Without workers, we do it all right here.
+	dispatch := func(b *crawledBlock) error {
+		b.extract()
+		return process(b)
+	}
+	var finish func() error
+
+	if search.workers > 1 {
+		var (
+			jobs       = make(chan *crawledBlock, search.workers)
+			ordered    = make(chan *crawledBlock, 2*search.workers)
+			stop       = make(chan struct{})
+			consumed   = make(chan struct{})
+			processErr error
+			wg         sync.WaitGroup
+		)
+
+		// Workers extract data from blocks concurrently.
+		for i := 0; i < search.workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for b := range jobs {
+					b.extract()
+					close(b.ready)
+				}
+			}()
+		}
+
+		// Blocks are processed in order as they become ready.  After an error, we drain.
+		go func() {
+			defer close(consumed)
+			for b := range ordered {
+				if processErr != nil {
+					continue
+				}
+				<-b.ready
+				processErr = process(b)
+				if processErr != nil {
+					close(stop)
+				}
+			}
+		}()
+
+		dispatch = func(b *crawledBlock) error {
+			select {
+			case <-stop:
+				return state.StopIteration()
+			default:
+			}
+			b.ready = make(chan struct{})
+			// Jobs must go out before their place in line, or we could deadlock.
+			jobs <- b
+			ordered <- b
+			return nil
+		}
+
+		finish = func() error {
+			close(jobs)
+			close(ordered)
+			<-consumed
+			wg.Wait()
+			return processErr
+		}
+	}
 
 	example := backing.State{}
 	err = state.IterHistory(db, ds, &example, func(stI state.State, height uint64) error {
-		// Save off the max height that we'll index up to by the end of the iteration.
-		if search.nextHeight == 0 {
-			// This assumes we're iterating blocks in order from the head to genesis.
-			search.nextHeight = height + 1
-			lastHeight = search.nextHeight
+		// IterHistory always starts from the current head, even when we're resuming a crawl
+		// that began at a lower height.
+		if !sawHead {
+			sawHead = true
+			lastHeight = height + 1
+			if checkpoint.NextHeight == 0 {
+				// Save off the max height that we'll index up to by the end of the iteration,
+				// so that a resumed crawl knows where this one began.
+				checkpoint.NextHeight = height + 1
+				search.nextHeight = checkpoint.NextHeight
+				err := search.saveCheckpoint(checkpoint)
+				if err != nil {
+					return err
+				}
+			}
 		}
 
 		// If we've reached the last height we indexed to, we can stop here.
@@ -72,26 +322,28 @@ func (search *Client) IndexBlockchain(
 		}
 		lastHeight = height
 
-		// The indexing code below uses this to know the current height.
-		search.blockHeight = height
+		// Blocks committed after the crawl began are left to incremental indexing.
+		if height >= checkpoint.NextHeight {
+			return nil
+		}
 
-		// NOTE: This is currently a no-op since we didn't pull anything out of noms to put into
-		// the search client struct before calling index().  We keep this here in case we do find
-		// more that we want to index that also can be pulled from noms before this line.
-		updCount, insCount, err := search.index()
-		updateCount += updCount
-		insertCount += insCount
-		if err != nil {
-			return err
+		// Skip what we indexed before we were interrupted.
+		if resumeHeight > 0 && height >= resumeHeight {
+			return nil
 		}
 
-		// Index sysvar key-value history, which we pull from noms.
-		st := stI.(*backing.State)
-		updCount, insCount, err = search.indexState(st)
-		updateCount += updCount
-		insertCount += insCount
-		return err
+		// IterHistory gives us a fresh state for every block, so it's safe to hang onto.
+		return dispatch(&crawledBlock{
+			height: height,
+			st:     stI.(*backing.State),
+		})
 	})
+	if finish != nil {
+		ferr := finish()
+		if ferr != nil && (err == nil || state.IsStopIteration(err)) {
+			err = ferr
+		}
+	}
 	if err != nil && !state.IsStopIteration(err) {
 		return updateCount, insertCount, err
 	}
@@ -106,6 +358,11 @@ func (search *Client) IndexBlockchain(
 	updCount, insCount, err := search.onIndexingComplete(checkForDupes)
 	updateCount += updCount
 	insertCount += insCount
+	if err != nil {
+		return updateCount, insertCount, err
+	}
 
-	return updateCount, insertCount, err
+	// We're done; the next crawl starts from scratch.
+	err = search.Client.Inner().Del(initialIndexCheckpointKey).Err()
+	return updateCount, insertCount, errors.Wrap(err, "clearing initial indexing checkpoint")
 }
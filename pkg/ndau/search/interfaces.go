@@ -12,6 +12,7 @@ package search
 import (
 	metastate "github.com/ndau/metanode/pkg/meta/state"
 	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/ndau/ndaumath/pkg/pricecurve"
 )
 
@@ -24,6 +25,7 @@ type AppIndexable interface {
 	GetState() metastate.State
 	CalculateTxFeeNapu(tx metatx.Transactable) (uint64, error)
 	CalculateTxSIBNapu(tx metatx.Transactable) (uint64, error)
+	GetSupply() (SupplyValueData, error)
 }
 
 // SysvarIndexable is a Transactable that has sysar data that we want to index.
@@ -49,3 +51,17 @@ type TargetPriceIndexable interface {
 
 	UpdatedTargetPrice()
 }
+
+// NodeRewardNominationIndexable is a Transactable that has nominated a node reward winner.
+type NodeRewardNominationIndexable interface {
+	metatx.Transactable
+
+	GetRandom() int64
+}
+
+// NodeRewardClaimIndexable is a Transactable that has claimed a node reward.
+type NodeRewardClaimIndexable interface {
+	metatx.Transactable
+
+	GetClaimingNode() address.Address
+}
@@ -39,6 +39,8 @@ func fmtHeightToTimestamp(height uint64) string {
 	return fmt.Sprintf("%s%d", heightToTimestampPrefix, height)
 }
 
+const initialIndexCheckpointKey = "initial.index.checkpoint"
+
 const (
 	marketPriceKeysetKey = "marketPriceKeys"
 	marketPriceKeyFmt    = "market.price:%d:%s"
@@ -48,6 +50,49 @@ func fmtMarketPriceKey(height uint64, timestamp math.Timestamp) string {
 	return fmt.Sprintf(marketPriceKeyFmt, height, timestamp)
 }
 
+const (
+	navKeysetKey = "navKeys"
+	navKeyFmt    = "endowment.nav:%d:%s"
+)
+
+func fmtNAVKey(height uint64, timestamp math.Timestamp) string {
+	return fmt.Sprintf(navKeyFmt, height, timestamp)
+}
+
+const (
+	sibKeysetKey = "sibKeys"
+	sibKeyFmt    = "sib:%d:%s"
+)
+
+func fmtSIBKey(height uint64, timestamp math.Timestamp) string {
+	return fmt.Sprintf(sibKeyFmt, height, timestamp)
+}
+
+const (
+	supplyKeysetKey = "supplyKeys"
+	supplyKeyFmt    = "supply:%d:%s"
+)
+
+func fmtSupplyKey(height uint64, timestamp math.Timestamp) string {
+	return fmt.Sprintf(supplyKeyFmt, height, timestamp)
+}
+
+// Node reward nominations are keyed by nomination height.  Each winning node has a sorted set of
+// the keys for the nominations it won, scored by height.
+const (
+	nodeRewardKeyFmt       = "node.reward:%d"
+	nodeRewardLatestKey    = "node.reward.latest"
+	nodeToRewardsKeyPrefix = "node:rewards:"
+)
+
+func fmtNodeRewardKey(height uint64) string {
+	return fmt.Sprintf(nodeRewardKeyFmt, height)
+}
+
+func fmtNodeToRewards(addr string) string {
+	return nodeToRewardsKeyPrefix + addr
+}
+
 const sysvarKeyToValuePrefix = "sysvar.key:value:"
 
 func fmtSysvarKeyToValue(key string) string {
@@ -75,6 +120,39 @@ func fmtTxTypeToHeight(typeName string) string {
 	return txTypeToHeightPrefix + strings.ToLower(typeName)
 }
 
+// Scheduled unlocks are keyed by address and unlock time, and kept in a keyset scored by unlock
+// time.  Each address also points at the key of its most recently indexed unlock, so that we can
+// update or remove it if the account is relocked before it unlocks.
+//
+// The number of accounts and their total balance unlocking on each day are kept up to date in a
+// hash per day, in a keyset scored by the day.
+const (
+	unlockKeysetKey       = "unlockKeys"
+	unlockKeyFmt          = "unlock:%s:%s"
+	addressToUnlockPrefix = "address:unlock:"
+	unlockDayKeysetKey    = "unlockDayKeys"
+	unlockDayKeyFmt       = "unlock.day:%s"
+	unlockDayAccounts     = "a"
+	unlockDayBalance      = "b"
+)
+
+func fmtUnlockKey(addr string, unlocksOn math.Timestamp) string {
+	return fmt.Sprintf(unlockKeyFmt, addr, unlocksOn)
+}
+
+func fmtAddressToUnlock(addr string) string {
+	return addressToUnlockPrefix + addr
+}
+
+func fmtUnlockDayKey(day math.Timestamp) string {
+	return fmt.Sprintf(unlockDayKeyFmt, day)
+}
+
+// unlockDay returns the start of the (UTC) day containing the given time.
+func unlockDay(ts math.Timestamp) math.Timestamp {
+	return ts - ts%math.Timestamp(math.Day)
+}
+
 const unionPrefix = "union:"
 
 func fmtUnion() string {
@@ -0,0 +1,154 @@
+package search
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+// Migrations of data already in the index when its format changes.
+
+import (
+	"fmt"
+	gomath "math"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// The format version of the data in the index.  Bump this, and add a migration step to migrate(),
+// whenever the format of existing entries changes such that they need to be reindexed.
+//
+// Bumping the index version instead would wipe the index, and initial indexing only rebuilds
+// what it can pull from noms: the tx indexes would be lost.  So we migrate them in place.
+//
+// Format history:
+//
+//	0: tx type sorted sets scored by height + offset/1000
+//	1: tx type sorted sets scored by txScore()
+//	2: per-day totals of scheduled unlocks
+const indexFormat = 2
+
+const indexFormatKey = "index.format"
+
+// Bring the index up to date with the current indexFormat.
+func (search *Client) migrate() error {
+	format := 0
+	f, err := search.Client.Get(indexFormatKey)
+	if err != nil {
+		return errors.Wrap(err, "getting index format")
+	}
+	if f != "" {
+		format, err = strconv.Atoi(f)
+		if err != nil {
+			return errors.Wrap(err, "parsing index format")
+		}
+	}
+
+	if format > indexFormat {
+		return fmt.Errorf("index format %d is newer than supported format %d", format, indexFormat)
+	}
+	if format == indexFormat {
+		return nil
+	}
+
+	if format < 1 {
+		err = search.migrateTxScores()
+		if err != nil {
+			return errors.Wrap(err, "migrating tx scores")
+		}
+	}
+	if format < 2 {
+		err = search.migrateUnlockDays()
+		if err != nil {
+			return errors.Wrap(err, "migrating unlock days")
+		}
+	}
+
+	return search.Client.Set(indexFormatKey, fmt.Sprint(indexFormat))
+}
+
+// Reindex every tx type sorted set entry with its exact txScore().
+//
+// The tx hash index stores the height and offset of every tx, so we take them from there.
+// We only fall back to decoding the old float score if the tx hash is somehow missing.
+//
+// We SCAN for the sorted sets rather than asking for them all at once with KEYS, which would
+// block redis while it walked the whole keyspace.
+func (search *Client) migrateTxScores() error {
+	iter := search.Client.Inner().Scan(0, fmtTxTypeToHeight("*"), 0).Iterator()
+	for iter.Next() {
+		key := iter.Val()
+		zs, err := search.Client.Inner().ZRangeWithScores(key, 0, -1).Result()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("getting entries of %s", key))
+		}
+
+		for _, z := range zs {
+			txHash, ok := z.Member.(string)
+			if !ok {
+				return fmt.Errorf("unexpected member type %T in %s", z.Member, key)
+			}
+
+			height := uint64(z.Score)
+			offset := int(gomath.Round((z.Score - gomath.Floor(z.Score)) * 1000))
+			valueData, err := search.SearchTxHash(txHash)
+			if err != nil {
+				return err
+			}
+			if valueData.BlockHeight > 0 {
+				height = valueData.BlockHeight
+				offset = valueData.TxOffset
+			}
+
+			score, err := txScore(height, offset)
+			if err != nil {
+				return err
+			}
+			// ZADD updates the score of an existing member in place.
+			_, err = search.Client.ZAdd(key, score, txHash)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return errors.Wrap(iter.Err(), "scanning tx type keys")
+}
+
+// Total up the unlocks already in the index by day, replacing any totals already there.
+func (search *Client) migrateUnlockDays() error {
+	dks, err := search.Client.Inner().ZRange(unlockDayKeysetKey, 0, -1).Result()
+	if err != nil {
+		return errors.Wrap(err, "getting unlock days")
+	}
+	for _, dk := range dks {
+		err = search.Client.Inner().Del(dk).Err()
+		if err != nil {
+			return err
+		}
+	}
+	err = search.Client.Inner().Del(unlockDayKeysetKey).Err()
+	if err != nil {
+		return err
+	}
+
+	ks, err := search.Client.Inner().ZRange(unlockKeysetKey, 0, -1).Result()
+	if err != nil {
+		return errors.Wrap(err, "getting unlocks")
+	}
+	for _, k := range ks {
+		unlock, err := search.getUnlock(k)
+		if err != nil {
+			return err
+		}
+		err = search.addToUnlockDay(unlock.UnlocksOn, 1, unlock.Balance)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -121,8 +121,11 @@ func (search *Client) GetTxTypeAtHeight(height uint64, txType string, limit int)
 
 	searchKey := fmtTxTypeToHeight(txType)
 
-	max := float64(height) + .9999
-	min := float64(height)
+	min, err := txScore(height, 0)
+	if err != nil {
+		return listValueData, err
+	}
+	max := min + maxTxsPerBlock - 1
 	count := int64(limit)
 
 	hashes, err := search.Client.ZRevRangeByScoreMinMax(searchKey, min, max, count)
@@ -207,7 +210,10 @@ func (search *Client) SearchTxTypes(txHashOrHeight string, txTypes []string, lim
 	if height > 0 {
 		// This is exclusive, so we add 1.  We'll get all transactions in the input block this
 		// way, not just the first (tx offset 0) transaction.
-		score := float64(height + 1)
+		score, err := txScore(height+1, 0)
+		if err != nil {
+			return listValueData, err
+		}
 		count = int64(limit)
 		// If not searching all, include one more to get the tx hash for the next page.
 		if count > 0 {
@@ -322,6 +328,49 @@ func (search *Client) SearchAccountHistory(
 	return ahr, err
 }
 
+// SearchNodeRewardHistory returns the node reward nominations won by the given node, and whether
+// and when each was claimed.  The response is sorted by ascending nomination height.
+// Pass in 0,0 for the paging params to get the entire history.
+func (search *Client) SearchNodeRewardHistory(
+	addr string, afterHeight uint64, limit int,
+) (*NodeRewardHistoryResponse, error) {
+	nrhr := new(NodeRewardHistoryResponse)
+
+	zropts := redis.ZRangeBy{
+		// leading paren in query causes exclusive semantics
+		Min: fmt.Sprintf("(%d", afterHeight),
+		Max: "+inf",
+	}
+	if limit > 0 {
+		// we add one so we can tell if extra elements exist
+		zropts.Count = int64(limit + 1)
+	}
+
+	ks, err := search.Client.Inner().ZRangeByScore(fmtNodeToRewards(addr), zropts).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "querying redis")
+	}
+	if limit > 0 && len(ks) > limit {
+		ks = ks[:limit]
+		nrhr.More = true
+	}
+
+	for _, k := range ks {
+		searchValue, err := search.Client.Get(k)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting node reward")
+		}
+		valueData := NodeRewardValueData{}
+		err = valueData.Unmarshal(searchValue)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshaling node reward")
+		}
+		nrhr.Rewards = append(nrhr.Rewards, valueData)
+	}
+
+	return nrhr, nil
+}
+
 // BlockTime returns the timestamp for the block at a given height
 // returns the zero value and no error if the block is unknown
 func (search *Client) BlockTime(height uint64) (math.Timestamp, error) {
@@ -413,56 +462,100 @@ func (search *Client) SearchTargetPrice(params PriceQueryParams) (PriceQueryResu
 	return search.searchPrice(params, targetPriceKeysetKey, targetPriceKeyFmt)
 }
 
-// searchPrice searches for market price records
+// SearchEndowmentNAV searches for endowment NAV records
 //
 // In the parameters:
 // Before and After have exclusive semantics.
 //
 // The zero value of Before and After are treated as open-ended ranges.
 // The zero value of Limit returns all results.
-func (search *Client) searchPrice(
-	params PriceQueryParams,
-	key, kfmt string,
-) (PriceQueryResults, error) {
-	// setup search options
-	var zropts redis.ZRangeBy
-	if after := params.After.GetTimestamp(search); after != 0 {
-		// leading paren in query causes exclusive semantics
-		zropts.Min = fmt.Sprintf("(%v", float64(after))
-	} else {
-		zropts.Min = "-inf"
-	}
-	if before := params.Before.GetTimestamp(search); before != 0 {
-		// leading paren in query causes exclusive semantics
-		zropts.Max = fmt.Sprintf("(%v", float64(before))
-	} else {
-		zropts.Max = "+inf"
-	}
+func (search *Client) SearchEndowmentNAV(params PriceQueryParams) (PriceQueryResults, error) {
+	return search.searchPrice(params, navKeysetKey, navKeyFmt)
+}
 
-	var iqty uint
-	if params.Limit != 0 {
+// SearchSIB searches for SIB records
+//
+// In the parameters:
+// Before and After have exclusive semantics.
+//
+// The zero value of Before and After are treated as open-ended ranges.
+// The zero value of Limit returns all results.
+func (search *Client) SearchSIB(params PriceQueryParams) (SIBQueryResults, error) {
+	out := SIBQueryResults{Items: make([]SIBQueryResult, 0)}
+	var err error
+	out.More, err = search.searchTimestamped(
+		sibKeysetKey, sibKeyFmt, "sib",
+		params.After, params.Before, params.Limit,
+		func(h uint64, ts math.Timestamp, value string) error {
+			result := SIBQueryResult{
+				Height:    h,
+				Timestamp: ts,
+			}
+			err := result.SIBValueData.Unmarshal(value)
+			if err != nil {
+				return err
+			}
+			out.Items = append(out.Items, result)
+			return nil
+		},
+	)
+	return out, err
+}
+
+// searchTimestampRange returns the members of the given timestamp-scored sorted set which
+// fall between after and before, in ascending timestamp order.
+//
+// after and before have exclusive semantics; their zero values are treated as open-ended.
+// If limit is nonzero, up to limit+1 members are returned, so the caller can tell whether
+// more results exist.
+func (search *Client) searchTimestampRange(
+	key string,
+	after, before RangeEndpoint,
+	limit uint,
+) ([]string, error) {
+	// setup search options
+	zropts := timestampRange(after.GetTimestamp(search), before.GetTimestamp(search))
+	if limit != 0 {
 		// we add one so we can tell if extra elements exist
-		zropts.Count = int64(params.Limit + 1)
-		iqty = params.Limit
+		zropts.Count = int64(limit + 1)
 	}
 
-	// execute query
 	ks, err := search.Client.Inner().ZRangeByScore(key, zropts).Result()
+	return ks, errors.Wrap(err, "querying redis")
+}
+
+// searchTimestamped searches a timestamp-scored sorted set whose members are the keys,
+// formatted by kfmt from a height and a timestamp, of the records stored in redis.
+//
+// decode is called in timestamp order with the height, timestamp and stored value of each
+// record within the range. what names the records in errors. more reports whether there are
+// more records in the range than limit.
+//
+// In the parameters:
+// Before and After have exclusive semantics.
+//
+// The zero value of Before and After are treated as open-ended ranges.
+// The zero value of Limit returns all results.
+func (search *Client) searchTimestamped(
+	key, kfmt, what string,
+	after, before RangeEndpoint,
+	limit uint,
+	decode func(height uint64, timestamp math.Timestamp, value string) error,
+) (more bool, err error) {
+	// execute query
+	ks, err := search.searchTimestampRange(key, after, before, limit)
 	if err != nil {
-		return PriceQueryResults{}, errors.Wrap(err, "querying redis")
+		return false, err
 	}
+	iqty := limit
 	if iqty == 0 {
 		iqty = uint(len(ks))
 	}
+	more = limit != 0 && len(ks) > int(limit)
 
-	// convert output into a nice format
-	out := PriceQueryResults{
-		Items: make([]PriceQueryResult, 0, iqty),
-		More:  params.Limit != 0 && len(ks) > int(params.Limit),
-	}
 	for i, k := range ks {
 		if uint(i) >= iqty {
-			// don't append the extra to the output
+			// don't decode the extra
 			break
 		}
 		// extract height and timestamp from key
@@ -470,44 +563,257 @@ func (search *Client) searchPrice(
 		var tss string
 		_, err := fmt.Sscanf(k, kfmt, &h, &tss)
 		if err != nil {
-			return out, errors.Wrap(
+			return more, errors.Wrap(
 				err,
-				fmt.Sprintf(
-					"parsing price zset key '%s' (idx %d)",
-					k,
-					i,
-				),
+				fmt.Sprintf("parsing %s zset key '%s' (idx %d)", what, k, i),
 			)
 		}
 		// parse real timestamp
 		ts, err := math.ParseTimestamp(tss)
 		if err != nil {
-			return out, errors.Wrap(
+			return more, errors.Wrap(
 				err,
-				fmt.Sprintf(
-					"parsing zset key timestamp '%s' (idx %d)",
-					tss,
-					i,
-				),
+				fmt.Sprintf("parsing zset key timestamp '%s' (idx %d)", tss, i),
 			)
 		}
-		// get price as string since we know its key
-		ps, err := search.Client.Inner().Get(k).Result()
+		// get the value since we know its key
+		value, err := search.Client.Inner().Get(k).Result()
 		if err != nil {
-			return out, errors.Wrap(err, fmt.Sprintf("getting redis key '%s' (zset idx %d)", k, i))
+			return more, errors.Wrap(err, fmt.Sprintf("getting redis key '%s' (zset idx %d)", k, i))
 		}
-		// parse real price
-		p, err := strconv.ParseInt(ps, 10, 64)
+		err = decode(h, ts, value)
 		if err != nil {
-			return out, errors.Wrap(err, fmt.Sprintf("parsing stored price '%s' as int (zset idx %d)", ps, i))
+			return more, errors.Wrap(err, fmt.Sprintf("parsing stored %s (zset idx %d)", what, i))
+		}
+	}
+
+	return more, nil
+}
+
+// searchPrice searches for price records
+//
+// In the parameters:
+// Before and After have exclusive semantics.
+//
+// The zero value of Before and After are treated as open-ended ranges.
+// The zero value of Limit returns all results.
+func (search *Client) searchPrice(
+	params PriceQueryParams,
+	key, kfmt string,
+) (PriceQueryResults, error) {
+	out := PriceQueryResults{Items: make([]PriceQueryResult, 0)}
+	var err error
+	out.More, err = search.searchTimestamped(
+		key, kfmt, "price",
+		params.After, params.Before, params.Limit,
+		func(h uint64, ts math.Timestamp, value string) error {
+			// parse real price
+			p, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return err
+			}
+			out.Items = append(out.Items, PriceQueryResult{
+				Price:     pricecurve.Nanocent(p),
+				Height:    h,
+				Timestamp: ts,
+			})
+			return nil
+		},
+	)
+	return out, err
+}
+
+// SearchSupply searches for supply history records
+//
+// In the parameters:
+// Before and After have exclusive semantics.
+//
+// The zero value of Before and After are treated as open-ended ranges.
+// The zero value of Limit returns all results.
+func (search *Client) SearchSupply(params SupplyQueryParams) (SupplyQueryResults, error) {
+	out := SupplyQueryResults{Items: make([]SupplyQueryResult, 0)}
+	var err error
+	out.More, err = search.searchTimestamped(
+		supplyKeysetKey, supplyKeyFmt, "supply",
+		params.After, params.Before, params.Limit,
+		func(h uint64, ts math.Timestamp, value string) error {
+			result := SupplyQueryResult{
+				Height:    h,
+				Timestamp: ts,
+			}
+			err := result.SupplyValueData.Unmarshal(value)
+			if err != nil {
+				return err
+			}
+			out.Items = append(out.Items, result)
+			return nil
+		},
+	)
+	return out, err
+}
+
+// SearchUnlocks searches for scheduled unlocks, sorted by unlock time
+//
+// In the parameters:
+// Before and After have exclusive semantics, and select on the unlock time.
+//
+// The zero value of Before and After are treated as open-ended ranges.
+// The zero value of Limit returns all results.
+//
+// Unlocks which have already happened are kept in the index; the unlocks query only returns
+// them when it is given an explicit After.
+//
+// The per-day totals in the results cover the whole range, regardless of paging.
+func (search *Client) SearchUnlocks(params UnlocksQueryParams) (UnlocksQueryResults, error) {
+	after := params.After.GetTimestamp(search)
+	before := params.Before.GetTimestamp(search)
+	zropts := timestampRange(after, before)
+
+	out := UnlocksQueryResults{
+		Items: make([]UnlockValueData, 0, params.Limit),
+		Days:  make([]UnlockDayTotal, 0),
+	}
+
+	// execute query for the requested page; we ask for one more so we can tell if extra
+	// elements exist
+	page := zropts
+	page.Offset = int64(params.Offset)
+	page.Count = -1
+	if params.Limit != 0 {
+		page.Count = int64(params.Limit + 1)
+	}
+	ks, err := search.Client.Inner().ZRangeByScore(unlockKeysetKey, page).Result()
+	if err != nil {
+		return out, errors.Wrap(err, "querying redis")
+	}
+	for i, k := range ks {
+		if params.Limit != 0 && uint(i) >= params.Limit {
+			out.More = true
+			break
+		}
+		unlock, err := search.getUnlock(k)
+		if err != nil {
+			return out, errors.Wrap(err, fmt.Sprintf("zset idx %d", i))
+		}
+		out.Items = append(out.Items, unlock)
+	}
+
+	// days entirely within the range have their totals indexed; we only need to add up the
+	// unlocks on the days at either end, which the range may cut short
+	days := zropts
+	days.Min = "-inf"
+	if after != 0 {
+		days.Min = fmt.Sprint(float64(unlockDay(after)))
+	}
+	dzs, err := search.Client.Inner().ZRangeByScoreWithScores(unlockDayKeysetKey, days).Result()
+	if err != nil {
+		return out, errors.Wrap(err, "querying redis for days")
+	}
+	for _, dz := range dzs {
+		dk, ok := dz.Member.(string)
+		if !ok {
+			return out, fmt.Errorf("unexpected member type %T in %s", dz.Member, unlockDayKeysetKey)
+		}
+		total := UnlockDayTotal{Day: math.Timestamp(dz.Score)}
+		end := total.Day.Add(math.Day)
+		if (after == 0 || after < total.Day) && (before == 0 || end <= before) {
+			total.Accounts, total.Balance, err = search.getUnlockDay(dk)
+		} else {
+			total.Accounts, total.Balance, err = search.sumUnlocks(
+				maxTimestamp(after, total.Day-1), minTimestamp(before, end),
+			)
+		}
+		if err != nil {
+			return out, err
+		}
+		if total.Accounts > 0 {
+			out.Days = append(out.Days, total)
 		}
-		// append this row
-		out.Items = append(out.Items, PriceQueryResult{
-			Price:     pricecurve.Nanocent(p),
-			Height:    h,
-			Timestamp: ts,
-		})
 	}
 
 	return out, nil
 }
+
+// timestampRange returns the options to select the members of a keyset scored by timestamp
+// strictly between after and before; zero values are open-ended.
+func timestampRange(after, before math.Timestamp) redis.ZRangeBy {
+	var zropts redis.ZRangeBy
+	if after != 0 {
+		// leading paren in query causes exclusive semantics
+		zropts.Min = fmt.Sprintf("(%v", float64(after))
+	} else {
+		zropts.Min = "-inf"
+	}
+	if before != 0 {
+		// leading paren in query causes exclusive semantics
+		zropts.Max = fmt.Sprintf("(%v", float64(before))
+	} else {
+		zropts.Max = "+inf"
+	}
+	return zropts
+}
+
+func (search *Client) getUnlock(searchKey string) (UnlockValueData, error) {
+	unlock := UnlockValueData{}
+	sv, err := search.Client.Inner().Get(searchKey).Result()
+	if err != nil {
+		return unlock, errors.Wrap(err, fmt.Sprintf("getting redis key '%s'", searchKey))
+	}
+	err = unlock.Unmarshal(sv)
+	return unlock, errors.Wrap(err, "parsing stored unlock")
+}
+
+// getUnlockDay returns the indexed totals of a day's unlocks.
+func (search *Client) getUnlockDay(dayKey string) (accounts uint64, balance math.Ndau, err error) {
+	vs, err := search.Client.Inner().HMGet(dayKey, unlockDayAccounts, unlockDayBalance).Result()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, fmt.Sprintf("getting redis key '%s'", dayKey))
+	}
+	for i, v := range vs {
+		vstr, ok := v.(string)
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(vstr, 10, 64)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, fmt.Sprintf("parsing %s", dayKey))
+		}
+		if i == 0 {
+			accounts = uint64(n)
+		} else {
+			balance = math.Ndau(n)
+		}
+	}
+	return accounts, balance, nil
+}
+
+// sumUnlocks adds up the unlocks strictly between after and before.
+func (search *Client) sumUnlocks(after, before math.Timestamp) (accounts uint64, balance math.Ndau, err error) {
+	ks, err := search.Client.Inner().ZRangeByScore(unlockKeysetKey, timestampRange(after, before)).Result()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "querying redis")
+	}
+	for _, k := range ks {
+		unlock, err := search.getUnlock(k)
+		if err != nil {
+			return 0, 0, err
+		}
+		accounts++
+		balance += unlock.Balance
+	}
+	return accounts, balance, nil
+}
+
+func maxTimestamp(a, b math.Timestamp) math.Timestamp {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minTimestamp(a, b math.Timestamp) math.Timestamp {
+	if a == 0 || b < a {
+		return b
+	}
+	return a
+}
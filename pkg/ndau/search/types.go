@@ -14,6 +14,7 @@ package search
 import (
 	"encoding/base64"
 
+	"github.com/ndau/ndaumath/pkg/eai"
 	"github.com/ndau/ndaumath/pkg/pricecurve"
 	"github.com/ndau/ndaumath/pkg/types"
 	math "github.com/ndau/ndaumath/pkg/types"
@@ -67,6 +68,13 @@ type AccountListParams struct {
 	Limit   int    `json:"limit"`
 }
 
+// NodeRewardHistoryParams is a json-friendly struct for the /node/rewards endpoint.
+type NodeRewardHistoryParams struct {
+	Address     string `json:"addr"`
+	AfterHeight uint64 `json:"afterheight"`
+	Limit       int    `json:"limit"`
+}
+
 // RangeEndpoint is a json-friendly struct for choosing the end of a range
 //
 // At most one of (`Height`, `Timestamp`) should ever be set. If both are set,
@@ -121,6 +129,174 @@ type PriceQueryResults struct {
 	More  bool               `json:"-"`
 }
 
+// SIBValueData is the SIB rate in effect at a particular block, along with the floor
+// price as of the end of that block.
+//
+// The floor price is computed when the block is indexed. The SIB is only recalculated when
+// a price is recorded, from the floor price as it stood then, so the two needn't agree.
+type SIBValueData struct {
+	SIB        eai.Rate            `json:"sib" msg:"s"`
+	FloorPrice pricecurve.Nanocent `json:"floor_price_nanocents" msg:"f"`
+}
+
+// Marshal the value data into a search value string to index it with its search key string.
+func (valueData *SIBValueData) Marshal() string {
+	m, err := valueData.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(m)
+}
+
+// Unmarshal the given search value string that was indexed with its search key string.
+func (valueData *SIBValueData) Unmarshal(searchValue string) error {
+	bytes, err := base64.StdEncoding.DecodeString(searchValue)
+	if err != nil {
+		return errors.Wrap(err, "decoding b64")
+	}
+	_, err = valueData.UnmarshalMsg(bytes)
+	return errors.Wrap(err, "decoding msgp")
+}
+
+// SIBQueryResult is a json-friendly struct returning SIB history data
+type SIBQueryResult struct {
+	SIBValueData
+	Height    uint64         `json:"block_height"`
+	Timestamp math.Timestamp `json:"timestamp"`
+}
+
+// SIBQueryResults encapsulates a set of SIB history data
+//
+// It is _not_ json-friendly; More should be replaced with Next at the API level
+// More is true when more results exist than were returned
+type SIBQueryResults struct {
+	Items []SIBQueryResult `json:"-"`
+	More  bool             `json:"-"`
+}
+
+// SupplyQueryParams is a json-friendly struct for querying supply history
+//
+// Before and After have exclusive semantics.
+//
+// The zero value of Before and After are treated as open-ended ranges.
+// The zero value of Limit returns all results.
+type SupplyQueryParams struct {
+	After  RangeEndpoint `json:"after,omitempty"`
+	Before RangeEndpoint `json:"before,omitempty"`
+	Limit  uint          `json:"limit,omitempty"`
+}
+
+// SupplyValueData is a snapshot of the ndau supply totals at a particular block.
+type SupplyValueData struct {
+	TotalNdau        math.Ndau           `json:"total_ndau" msg:"n"`
+	TotalCirculation math.Ndau           `json:"total_circulation" msg:"c"`
+	TotalRFE         math.Ndau           `json:"total_rfe" msg:"r"`
+	TotalIssue       math.Ndau           `json:"total_issue" msg:"i"`
+	TotalBurned      math.Ndau           `json:"total_burned" msg:"b"`
+	SIB              eai.Rate            `json:"sib" msg:"s"`
+	EndowmentNAV     pricecurve.Nanocent `json:"endowment_nav" msg:"v"`
+	FloorPrice       pricecurve.Nanocent `json:"floor_price" msg:"f"`
+}
+
+// Marshal the value data into a search value string to index it with its search key string.
+func (valueData *SupplyValueData) Marshal() string {
+	m, err := valueData.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(m)
+}
+
+// Unmarshal the given search value string that was indexed with its search key string.
+func (valueData *SupplyValueData) Unmarshal(searchValue string) error {
+	bytes, err := base64.StdEncoding.DecodeString(searchValue)
+	if err != nil {
+		return errors.Wrap(err, "decoding b64")
+	}
+	_, err = valueData.UnmarshalMsg(bytes)
+	return errors.Wrap(err, "decoding msgp")
+}
+
+// SupplyQueryResult is a json-friendly struct returning supply history data
+type SupplyQueryResult struct {
+	SupplyValueData
+	Height    uint64         `json:"block_height"`
+	Timestamp math.Timestamp `json:"timestamp"`
+}
+
+// SupplyQueryResults encapsulates a set of supply history data
+//
+// It is _not_ json-friendly; More should be replaced with Next at the API level
+// More is true when more results exist than were returned
+type SupplyQueryResults struct {
+	Items []SupplyQueryResult `json:"-"`
+	More  bool                `json:"-"`
+}
+
+// UnlocksQueryParams is a json-friendly struct for querying scheduled unlocks
+//
+// Before and After have exclusive semantics, and select on the unlock time. As unlocks are
+// generally in the future, they should be specified by timestamp rather than block height.
+//
+// The zero value of Before is treated as an open-ended range. The unlocks query treats the
+// zero value of After as the current block time, so past unlocks are only returned on request.
+// Many accounts can unlock at the same moment, so paging is by Offset into the range.
+// The zero value of Limit returns all results.
+type UnlocksQueryParams struct {
+	After  RangeEndpoint `json:"after,omitempty"`
+	Before RangeEndpoint `json:"before,omitempty"`
+	Offset uint          `json:"offset,omitempty"`
+	Limit  uint          `json:"limit,omitempty"`
+}
+
+// UnlockValueData is a notified lock: the account, when it unlocks, and its balance as of
+// the block at which it was last indexed.
+//
+// The balance is refreshed by every transaction which touches the account, including the
+// CreditEAI of its delegation node, but it never includes uncredited EAI.
+type UnlockValueData struct {
+	Address   string         `json:"address" msg:"a"`
+	UnlocksOn math.Timestamp `json:"unlocks_on" msg:"u"`
+	Balance   math.Ndau      `json:"balance" msg:"b"`
+	Height    uint64         `json:"block_height" msg:"h"`
+}
+
+// Marshal the value data into a search value string to index it with its search key string.
+func (valueData *UnlockValueData) Marshal() string {
+	m, err := valueData.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(m)
+}
+
+// Unmarshal the given search value string that was indexed with its search key string.
+func (valueData *UnlockValueData) Unmarshal(searchValue string) error {
+	bytes, err := base64.StdEncoding.DecodeString(searchValue)
+	if err != nil {
+		return errors.Wrap(err, "decoding b64")
+	}
+	_, err = valueData.UnmarshalMsg(bytes)
+	return errors.Wrap(err, "decoding msgp")
+}
+
+// UnlockDayTotal aggregates the unlocks scheduled on a single (UTC) day.
+type UnlockDayTotal struct {
+	Day      math.Timestamp `json:"day"`
+	Accounts uint64         `json:"accounts"`
+	Balance  math.Ndau      `json:"balance"`
+}
+
+// UnlocksQueryResults encapsulates a set of scheduled unlocks
+//
+// Days totals every unlock in the queried range, not just the returned Items.
+// More is true when more results exist than were returned
+type UnlocksQueryResults struct {
+	Items []UnlockValueData `json:"items"`
+	Days  []UnlockDayTotal  `json:"days"`
+	More  bool              `json:"-"`
+}
+
 // ValueData is used for skipping duplicate key value pairs while iterating the blockchain.
 type ValueData struct {
 	Height      uint64 `msg:"h"`
@@ -146,6 +322,19 @@ func (valueData *ValueData) Unmarshal(searchValue string) error {
 	return errors.Wrap(err, "decoding msgp")
 }
 
+// InitialIndexCheckpoint records the progress of initial indexing, so that it can resume where
+// it left off if it's interrupted.
+type InitialIndexCheckpoint struct {
+	// The height after the head block when the crawl began; the next height to index.
+	NextHeight uint64 `msg:"n"`
+	// The lowest height the crawl must reach.
+	MinHeight uint64 `msg:"m"`
+	// Every height from this one up to NextHeight has been indexed.
+	Height uint64 `msg:"h"`
+	// The sysvar values the crawl has yet to index, by search key.
+	Sysvars map[string]ValueData `msg:"s"`
+}
+
 // TxValueData is used for data about a particular transaction.
 type TxValueData struct {
 	BlockHeight uint64 `json:"height" msg:"h"`
@@ -249,3 +438,63 @@ func (response *AccountHistoryResponse) Unmarshal(searchValue string) error {
 	_, err = response.UnmarshalMsg(bytes)
 	return errors.Wrap(err, "decoding msgp")
 }
+
+// NodeRewardValueData records a single node reward nomination, and its claim if any.
+//
+// A nomination which was never claimed is still indexed; comparing it against the
+// nomination timeout tells an auditor whether the winner missed its claim.
+type NodeRewardValueData struct {
+	Height         uint64         `json:"height" msg:"h"`
+	Timestamp      math.Timestamp `json:"timestamp" msg:"t"`
+	Random         int64          `json:"random" msg:"r"`
+	Winner         string         `json:"winner" msg:"w"`
+	Amount         math.Ndau      `json:"amount" msg:"a"`
+	Claimed        bool           `json:"claimed" msg:"c"`
+	ClaimHeight    uint64         `json:"claim_height,omitempty" msg:"ch"`
+	ClaimTimestamp math.Timestamp `json:"claim_timestamp,omitempty" msg:"ct"`
+	ClaimTxHash    string         `json:"claim_tx_hash,omitempty" msg:"cx"`
+}
+
+// Marshal the value data into a search value string to index it with its search key string.
+func (valueData *NodeRewardValueData) Marshal() string {
+	m, err := valueData.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(m)
+}
+
+// Unmarshal the given search value string that was indexed with its search key string.
+func (valueData *NodeRewardValueData) Unmarshal(searchValue string) error {
+	bytes, err := base64.StdEncoding.DecodeString(searchValue)
+	if err != nil {
+		return errors.Wrap(err, "decoding b64")
+	}
+	_, err = valueData.UnmarshalMsg(bytes)
+	return errors.Wrap(err, "decoding msgp")
+}
+
+// NodeRewardHistoryResponse is the return value from the node reward history endpoint.
+type NodeRewardHistoryResponse struct {
+	Rewards []NodeRewardValueData `msg:"r"`
+	More    bool                  `msg:"m"`
+}
+
+// Marshal the node reward history response into something we can pass over RPC.
+func (response *NodeRewardHistoryResponse) Marshal() string {
+	m, err := response.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(m)
+}
+
+// Unmarshal the node reward history response from something we received over RPC.
+func (response *NodeRewardHistoryResponse) Unmarshal(searchValue string) error {
+	bytes, err := base64.StdEncoding.DecodeString(searchValue)
+	if err != nil {
+		return errors.Wrap(err, "decoding b64")
+	}
+	_, err = response.UnmarshalMsg(bytes)
+	return errors.Wrap(err, "decoding msgp")
+}
@@ -747,7 +747,7 @@ func (z *AccountTxValueData) Msgsize() (s int) {
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *PriceQueryParams) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *InitialIndexCheckpoint) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -764,80 +764,3340 @@ func (z *PriceQueryParams) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "After":
+		case "n":
+			z.NextHeight, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "NextHeight")
+				return
+			}
+		case "m":
+			z.MinHeight, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "MinHeight")
+				return
+			}
+		case "h":
+			z.Height, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "s":
 			var zb0002 uint32
 			zb0002, err = dc.ReadMapHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "After")
+				err = msgp.WrapError(err, "Sysvars")
 				return
 			}
+			if z.Sysvars == nil {
+				z.Sysvars = make(map[string]ValueData, zb0002)
+			} else if len(z.Sysvars) > 0 {
+				for key := range z.Sysvars {
+					delete(z.Sysvars, key)
+				}
+			}
 			for zb0002 > 0 {
 				zb0002--
-				field, err = dc.ReadMapKeyPtr()
+				var za0001 string
+				var za0002 ValueData
+				za0001, err = dc.ReadString()
 				if err != nil {
-					err = msgp.WrapError(err, "After")
+					err = msgp.WrapError(err, "Sysvars")
 					return
 				}
-				switch msgp.UnsafeString(field) {
-				case "Height":
-					z.After.Height, err = dc.ReadUint64()
+				var zb0003 uint32
+				zb0003, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Sysvars", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, err = dc.ReadMapKeyPtr()
 					if err != nil {
-						err = msgp.WrapError(err, "After", "Height")
+						err = msgp.WrapError(err, "Sysvars", za0001)
 						return
 					}
-				case "Timestamp":
-					err = z.After.Timestamp.DecodeMsg(dc)
-					if err != nil {
-						err = msgp.WrapError(err, "After", "Timestamp")
-						return
+					switch msgp.UnsafeString(field) {
+					case "h":
+						za0002.Height, err = dc.ReadUint64()
+						if err != nil {
+							err = msgp.WrapError(err, "Sysvars", za0001, "Height")
+							return
+						}
+					case "v":
+						za0002.ValueBase64, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Sysvars", za0001, "ValueBase64")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Sysvars", za0001)
+							return
+						}
 					}
-				default:
-					err = dc.Skip()
+				}
+				z.Sysvars[za0001] = za0002
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *InitialIndexCheckpoint) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "n"
+	err = en.Append(0x84, 0xa1, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.NextHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "NextHeight")
+		return
+	}
+	// write "m"
+	err = en.Append(0xa1, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.MinHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "MinHeight")
+		return
+	}
+	// write "h"
+	err = en.Append(0xa1, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Height")
+		return
+	}
+	// write "s"
+	err = en.Append(0xa1, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.Sysvars)))
+	if err != nil {
+		err = msgp.WrapError(err, "Sysvars")
+		return
+	}
+	for za0001, za0002 := range z.Sysvars {
+		err = en.WriteString(za0001)
+		if err != nil {
+			err = msgp.WrapError(err, "Sysvars")
+			return
+		}
+		// map header, size 2
+		// write "h"
+		err = en.Append(0x82, 0xa1, 0x68)
+		if err != nil {
+			return
+		}
+		err = en.WriteUint64(za0002.Height)
+		if err != nil {
+			err = msgp.WrapError(err, "Sysvars", za0001, "Height")
+			return
+		}
+		// write "v"
+		err = en.Append(0xa1, 0x76)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(za0002.ValueBase64)
+		if err != nil {
+			err = msgp.WrapError(err, "Sysvars", za0001, "ValueBase64")
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *InitialIndexCheckpoint) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "n"
+	o = append(o, 0x84, 0xa1, 0x6e)
+	o = msgp.AppendUint64(o, z.NextHeight)
+	// string "m"
+	o = append(o, 0xa1, 0x6d)
+	o = msgp.AppendUint64(o, z.MinHeight)
+	// string "h"
+	o = append(o, 0xa1, 0x68)
+	o = msgp.AppendUint64(o, z.Height)
+	// string "s"
+	o = append(o, 0xa1, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Sysvars)))
+	for za0001, za0002 := range z.Sysvars {
+		o = msgp.AppendString(o, za0001)
+		// map header, size 2
+		// string "h"
+		o = append(o, 0x82, 0xa1, 0x68)
+		o = msgp.AppendUint64(o, za0002.Height)
+		// string "v"
+		o = append(o, 0xa1, 0x76)
+		o = msgp.AppendString(o, za0002.ValueBase64)
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *InitialIndexCheckpoint) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "n":
+			z.NextHeight, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NextHeight")
+				return
+			}
+		case "m":
+			z.MinHeight, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinHeight")
+				return
+			}
+		case "h":
+			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "s":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Sysvars")
+				return
+			}
+			if z.Sysvars == nil {
+				z.Sysvars = make(map[string]ValueData, zb0002)
+			} else if len(z.Sysvars) > 0 {
+				for key := range z.Sysvars {
+					delete(z.Sysvars, key)
+				}
+			}
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 ValueData
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Sysvars")
+					return
+				}
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Sysvars", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
 					if err != nil {
-						err = msgp.WrapError(err, "After")
+						err = msgp.WrapError(err, "Sysvars", za0001)
 						return
 					}
+					switch msgp.UnsafeString(field) {
+					case "h":
+						za0002.Height, bts, err = msgp.ReadUint64Bytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Sysvars", za0001, "Height")
+							return
+						}
+					case "v":
+						za0002.ValueBase64, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Sysvars", za0001, "ValueBase64")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Sysvars", za0001)
+							return
+						}
+					}
 				}
+				z.Sysvars[za0001] = za0002
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *InitialIndexCheckpoint) Msgsize() (s int) {
+	s = 1 + 2 + msgp.Uint64Size + 2 + msgp.Uint64Size + 2 + msgp.Uint64Size + 2 + msgp.MapHeaderSize
+	if z.Sysvars != nil {
+		for za0001, za0002 := range z.Sysvars {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + 1 + 2 + msgp.Uint64Size + 2 + msgp.StringPrefixSize + len(za0002.ValueBase64)
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *NodeRewardHistoryParams) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			z.Address, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "AfterHeight":
+			z.AfterHeight, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "AfterHeight")
+				return
+			}
+		case "Limit":
+			z.Limit, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z NodeRewardHistoryParams) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Address"
+	err = en.Append(0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Address)
+	if err != nil {
+		err = msgp.WrapError(err, "Address")
+		return
+	}
+	// write "AfterHeight"
+	err = en.Append(0xab, 0x41, 0x66, 0x74, 0x65, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.AfterHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "AfterHeight")
+		return
+	}
+	// write "Limit"
+	err = en.Append(0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.Limit)
+	if err != nil {
+		err = msgp.WrapError(err, "Limit")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z NodeRewardHistoryParams) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Address"
+	o = append(o, 0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendString(o, z.Address)
+	// string "AfterHeight"
+	o = append(o, 0xab, 0x41, 0x66, 0x74, 0x65, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.AfterHeight)
+	// string "Limit"
+	o = append(o, 0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	o = msgp.AppendInt(o, z.Limit)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *NodeRewardHistoryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			z.Address, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "AfterHeight":
+			z.AfterHeight, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AfterHeight")
+				return
+			}
+		case "Limit":
+			z.Limit, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z NodeRewardHistoryParams) Msgsize() (s int) {
+	s = 1 + 8 + msgp.StringPrefixSize + len(z.Address) + 12 + msgp.Uint64Size + 6 + msgp.IntSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *NodeRewardHistoryResponse) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "r":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Rewards")
+				return
+			}
+			if cap(z.Rewards) >= int(zb0002) {
+				z.Rewards = (z.Rewards)[:zb0002]
+			} else {
+				z.Rewards = make([]NodeRewardValueData, zb0002)
+			}
+			for za0001 := range z.Rewards {
+				err = z.Rewards[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Rewards", za0001)
+					return
+				}
+			}
+		case "m":
+			z.More, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "More")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *NodeRewardHistoryResponse) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "r"
+	err = en.Append(0x82, 0xa1, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Rewards)))
+	if err != nil {
+		err = msgp.WrapError(err, "Rewards")
+		return
+	}
+	for za0001 := range z.Rewards {
+		err = z.Rewards[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Rewards", za0001)
+			return
+		}
+	}
+	// write "m"
+	err = en.Append(0xa1, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.More)
+	if err != nil {
+		err = msgp.WrapError(err, "More")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *NodeRewardHistoryResponse) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "r"
+	o = append(o, 0x82, 0xa1, 0x72)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Rewards)))
+	for za0001 := range z.Rewards {
+		o, err = z.Rewards[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Rewards", za0001)
+			return
+		}
+	}
+	// string "m"
+	o = append(o, 0xa1, 0x6d)
+	o = msgp.AppendBool(o, z.More)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *NodeRewardHistoryResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "r":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Rewards")
+				return
+			}
+			if cap(z.Rewards) >= int(zb0002) {
+				z.Rewards = (z.Rewards)[:zb0002]
+			} else {
+				z.Rewards = make([]NodeRewardValueData, zb0002)
+			}
+			for za0001 := range z.Rewards {
+				bts, err = z.Rewards[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Rewards", za0001)
+					return
+				}
+			}
+		case "m":
+			z.More, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "More")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *NodeRewardHistoryResponse) Msgsize() (s int) {
+	s = 1 + 2 + msgp.ArrayHeaderSize
+	for za0001 := range z.Rewards {
+		s += z.Rewards[za0001].Msgsize()
+	}
+	s += 2 + msgp.BoolSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *NodeRewardValueData) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "h":
+			z.Height, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "t":
+			err = z.Timestamp.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		case "r":
+			z.Random, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Random")
+				return
+			}
+		case "w":
+			z.Winner, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Winner")
+				return
+			}
+		case "a":
+			err = z.Amount.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "c":
+			z.Claimed, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "Claimed")
+				return
+			}
+		case "ch":
+			z.ClaimHeight, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "ClaimHeight")
+				return
+			}
+		case "ct":
+			err = z.ClaimTimestamp.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "ClaimTimestamp")
+				return
+			}
+		case "cx":
+			z.ClaimTxHash, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ClaimTxHash")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *NodeRewardValueData) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 9
+	// write "h"
+	err = en.Append(0x89, 0xa1, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Height")
+		return
+	}
+	// write "t"
+	err = en.Append(0xa1, 0x74)
+	if err != nil {
+		return
+	}
+	err = z.Timestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	// write "r"
+	err = en.Append(0xa1, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Random)
+	if err != nil {
+		err = msgp.WrapError(err, "Random")
+		return
+	}
+	// write "w"
+	err = en.Append(0xa1, 0x77)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Winner)
+	if err != nil {
+		err = msgp.WrapError(err, "Winner")
+		return
+	}
+	// write "a"
+	err = en.Append(0xa1, 0x61)
+	if err != nil {
+		return
+	}
+	err = z.Amount.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	// write "c"
+	err = en.Append(0xa1, 0x63)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.Claimed)
+	if err != nil {
+		err = msgp.WrapError(err, "Claimed")
+		return
+	}
+	// write "ch"
+	err = en.Append(0xa2, 0x63, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.ClaimHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "ClaimHeight")
+		return
+	}
+	// write "ct"
+	err = en.Append(0xa2, 0x63, 0x74)
+	if err != nil {
+		return
+	}
+	err = z.ClaimTimestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "ClaimTimestamp")
+		return
+	}
+	// write "cx"
+	err = en.Append(0xa2, 0x63, 0x78)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.ClaimTxHash)
+	if err != nil {
+		err = msgp.WrapError(err, "ClaimTxHash")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *NodeRewardValueData) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 9
+	// string "h"
+	o = append(o, 0x89, 0xa1, 0x68)
+	o = msgp.AppendUint64(o, z.Height)
+	// string "t"
+	o = append(o, 0xa1, 0x74)
+	o, err = z.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	// string "r"
+	o = append(o, 0xa1, 0x72)
+	o = msgp.AppendInt64(o, z.Random)
+	// string "w"
+	o = append(o, 0xa1, 0x77)
+	o = msgp.AppendString(o, z.Winner)
+	// string "a"
+	o = append(o, 0xa1, 0x61)
+	o, err = z.Amount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	// string "c"
+	o = append(o, 0xa1, 0x63)
+	o = msgp.AppendBool(o, z.Claimed)
+	// string "ch"
+	o = append(o, 0xa2, 0x63, 0x68)
+	o = msgp.AppendUint64(o, z.ClaimHeight)
+	// string "ct"
+	o = append(o, 0xa2, 0x63, 0x74)
+	o, err = z.ClaimTimestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ClaimTimestamp")
+		return
+	}
+	// string "cx"
+	o = append(o, 0xa2, 0x63, 0x78)
+	o = msgp.AppendString(o, z.ClaimTxHash)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *NodeRewardValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "h":
+			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "t":
+			bts, err = z.Timestamp.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		case "r":
+			z.Random, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Random")
+				return
+			}
+		case "w":
+			z.Winner, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Winner")
+				return
+			}
+		case "a":
+			bts, err = z.Amount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "c":
+			z.Claimed, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Claimed")
+				return
+			}
+		case "ch":
+			z.ClaimHeight, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClaimHeight")
+				return
+			}
+		case "ct":
+			bts, err = z.ClaimTimestamp.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClaimTimestamp")
+				return
+			}
+		case "cx":
+			z.ClaimTxHash, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClaimTxHash")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *NodeRewardValueData) Msgsize() (s int) {
+	s = 1 + 2 + msgp.Uint64Size + 2 + z.Timestamp.Msgsize() + 2 + msgp.Int64Size + 2 + msgp.StringPrefixSize + len(z.Winner) + 2 + z.Amount.Msgsize() + 2 + msgp.BoolSize + 3 + msgp.Uint64Size + 3 + z.ClaimTimestamp.Msgsize() + 3 + msgp.StringPrefixSize + len(z.ClaimTxHash)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *PriceQueryParams) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "After":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "After")
+				return
+			}
+			for zb0002 > 0 {
+				zb0002--
+				field, err = dc.ReadMapKeyPtr()
+				if err != nil {
+					err = msgp.WrapError(err, "After")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.After.Height, err = dc.ReadUint64()
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Height")
+						return
+					}
+				case "Timestamp":
+					err = z.After.Timestamp.DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Timestamp")
+						return
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, "After")
+						return
+					}
+				}
+			}
+		case "Before":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Before")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, err = dc.ReadMapKeyPtr()
+				if err != nil {
+					err = msgp.WrapError(err, "Before")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.Before.Height, err = dc.ReadUint64()
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Height")
+						return
+					}
+				case "Timestamp":
+					err = z.Before.Timestamp.DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Timestamp")
+						return
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, "Before")
+						return
+					}
+				}
+			}
+		case "Limit":
+			z.Limit, err = dc.ReadUint()
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *PriceQueryParams) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "After"
+	// map header, size 2
+	// write "Height"
+	err = en.Append(0x83, 0xa5, 0x41, 0x66, 0x74, 0x65, 0x72, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.After.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "After", "Height")
+		return
+	}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
+	}
+	err = z.After.Timestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "After", "Timestamp")
+		return
+	}
+	// write "Before"
+	// map header, size 2
+	// write "Height"
+	err = en.Append(0xa6, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Before.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Before", "Height")
+		return
+	}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
+	}
+	err = z.Before.Timestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Before", "Timestamp")
+		return
+	}
+	// write "Limit"
+	err = en.Append(0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint(z.Limit)
+	if err != nil {
+		err = msgp.WrapError(err, "Limit")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PriceQueryParams) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "After"
+	// map header, size 2
+	// string "Height"
+	o = append(o, 0x83, 0xa5, 0x41, 0x66, 0x74, 0x65, 0x72, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.After.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.After.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "After", "Timestamp")
+		return
+	}
+	// string "Before"
+	// map header, size 2
+	// string "Height"
+	o = append(o, 0xa6, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.Before.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.Before.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Before", "Timestamp")
+		return
+	}
+	// string "Limit"
+	o = append(o, 0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	o = msgp.AppendUint(o, z.Limit)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PriceQueryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "After":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "After")
+				return
+			}
+			for zb0002 > 0 {
+				zb0002--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "After")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.After.Height, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Height")
+						return
+					}
+				case "Timestamp":
+					bts, err = z.After.Timestamp.UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Timestamp")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "After")
+						return
+					}
+				}
+			}
+		case "Before":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Before")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Before")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.Before.Height, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Height")
+						return
+					}
+				case "Timestamp":
+					bts, err = z.Before.Timestamp.UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Timestamp")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Before")
+						return
+					}
+				}
+			}
+		case "Limit":
+			z.Limit, bts, err = msgp.ReadUintBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PriceQueryParams) Msgsize() (s int) {
+	s = 1 + 6 + 1 + 7 + msgp.Uint64Size + 10 + z.After.Timestamp.Msgsize() + 7 + 1 + 7 + msgp.Uint64Size + 10 + z.Before.Timestamp.Msgsize() + 6 + msgp.UintSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *PriceQueryResult) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Price":
+			err = z.Price.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Price")
+				return
+			}
+		case "PriceS":
+			z.PriceS, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "PriceS")
+				return
+			}
+		case "Height":
+			z.Height, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Timestamp":
+			err = z.Timestamp.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *PriceQueryResult) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Price"
+	err = en.Append(0x84, 0xa5, 0x50, 0x72, 0x69, 0x63, 0x65)
+	if err != nil {
+		return
+	}
+	err = z.Price.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Price")
+		return
+	}
+	// write "PriceS"
+	err = en.Append(0xa6, 0x50, 0x72, 0x69, 0x63, 0x65, 0x53)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.PriceS)
+	if err != nil {
+		err = msgp.WrapError(err, "PriceS")
+		return
+	}
+	// write "Height"
+	err = en.Append(0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Height")
+		return
+	}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
+	}
+	err = z.Timestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PriceQueryResult) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Price"
+	o = append(o, 0x84, 0xa5, 0x50, 0x72, 0x69, 0x63, 0x65)
+	o, err = z.Price.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Price")
+		return
+	}
+	// string "PriceS"
+	o = append(o, 0xa6, 0x50, 0x72, 0x69, 0x63, 0x65, 0x53)
+	o = msgp.AppendString(o, z.PriceS)
+	// string "Height"
+	o = append(o, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PriceQueryResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Price":
+			bts, err = z.Price.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Price")
+				return
+			}
+		case "PriceS":
+			z.PriceS, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PriceS")
+				return
+			}
+		case "Height":
+			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Timestamp":
+			bts, err = z.Timestamp.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PriceQueryResult) Msgsize() (s int) {
+	s = 1 + 6 + z.Price.Msgsize() + 7 + msgp.StringPrefixSize + len(z.PriceS) + 7 + msgp.Uint64Size + 10 + z.Timestamp.Msgsize()
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *PriceQueryResults) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Items":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Items")
+				return
+			}
+			if cap(z.Items) >= int(zb0002) {
+				z.Items = (z.Items)[:zb0002]
+			} else {
+				z.Items = make([]PriceQueryResult, zb0002)
+			}
+			for za0001 := range z.Items {
+				err = z.Items[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Items", za0001)
+					return
+				}
+			}
+		case "More":
+			z.More, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "More")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *PriceQueryResults) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Items"
+	err = en.Append(0x82, 0xa5, 0x49, 0x74, 0x65, 0x6d, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Items)))
+	if err != nil {
+		err = msgp.WrapError(err, "Items")
+		return
+	}
+	for za0001 := range z.Items {
+		err = z.Items[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001)
+			return
+		}
+	}
+	// write "More"
+	err = en.Append(0xa4, 0x4d, 0x6f, 0x72, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.More)
+	if err != nil {
+		err = msgp.WrapError(err, "More")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PriceQueryResults) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Items"
+	o = append(o, 0x82, 0xa5, 0x49, 0x74, 0x65, 0x6d, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Items)))
+	for za0001 := range z.Items {
+		o, err = z.Items[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001)
+			return
+		}
+	}
+	// string "More"
+	o = append(o, 0xa4, 0x4d, 0x6f, 0x72, 0x65)
+	o = msgp.AppendBool(o, z.More)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PriceQueryResults) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Items":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Items")
+				return
+			}
+			if cap(z.Items) >= int(zb0002) {
+				z.Items = (z.Items)[:zb0002]
+			} else {
+				z.Items = make([]PriceQueryResult, zb0002)
+			}
+			for za0001 := range z.Items {
+				bts, err = z.Items[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Items", za0001)
+					return
+				}
+			}
+		case "More":
+			z.More, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "More")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PriceQueryResults) Msgsize() (s int) {
+	s = 1 + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Items {
+		s += z.Items[za0001].Msgsize()
+	}
+	s += 5 + msgp.BoolSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *QueryParams) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Command":
+			z.Command, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Command")
+				return
+			}
+		case "Hash":
+			z.Hash, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Hash")
+				return
+			}
+		case "Types":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Types")
+				return
+			}
+			if cap(z.Types) >= int(zb0002) {
+				z.Types = (z.Types)[:zb0002]
+			} else {
+				z.Types = make([]string, zb0002)
+			}
+			for za0001 := range z.Types {
+				z.Types[za0001], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Types", za0001)
+					return
+				}
+			}
+		case "Limit":
+			z.Limit, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *QueryParams) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Command"
+	err = en.Append(0x84, 0xa7, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Command)
+	if err != nil {
+		err = msgp.WrapError(err, "Command")
+		return
+	}
+	// write "Hash"
+	err = en.Append(0xa4, 0x48, 0x61, 0x73, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Hash)
+	if err != nil {
+		err = msgp.WrapError(err, "Hash")
+		return
+	}
+	// write "Types"
+	err = en.Append(0xa5, 0x54, 0x79, 0x70, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Types)))
+	if err != nil {
+		err = msgp.WrapError(err, "Types")
+		return
+	}
+	for za0001 := range z.Types {
+		err = en.WriteString(z.Types[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "Types", za0001)
+			return
+		}
+	}
+	// write "Limit"
+	err = en.Append(0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.Limit)
+	if err != nil {
+		err = msgp.WrapError(err, "Limit")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *QueryParams) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Command"
+	o = append(o, 0x84, 0xa7, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64)
+	o = msgp.AppendString(o, z.Command)
+	// string "Hash"
+	o = append(o, 0xa4, 0x48, 0x61, 0x73, 0x68)
+	o = msgp.AppendString(o, z.Hash)
+	// string "Types"
+	o = append(o, 0xa5, 0x54, 0x79, 0x70, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Types)))
+	for za0001 := range z.Types {
+		o = msgp.AppendString(o, z.Types[za0001])
+	}
+	// string "Limit"
+	o = append(o, 0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	o = msgp.AppendInt(o, z.Limit)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *QueryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Command":
+			z.Command, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Command")
+				return
+			}
+		case "Hash":
+			z.Hash, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Hash")
+				return
+			}
+		case "Types":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Types")
+				return
+			}
+			if cap(z.Types) >= int(zb0002) {
+				z.Types = (z.Types)[:zb0002]
+			} else {
+				z.Types = make([]string, zb0002)
+			}
+			for za0001 := range z.Types {
+				z.Types[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Types", za0001)
+					return
+				}
+			}
+		case "Limit":
+			z.Limit, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *QueryParams) Msgsize() (s int) {
+	s = 1 + 8 + msgp.StringPrefixSize + len(z.Command) + 5 + msgp.StringPrefixSize + len(z.Hash) + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Types {
+		s += msgp.StringPrefixSize + len(z.Types[za0001])
+	}
+	s += 6 + msgp.IntSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *RangeEndpoint) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Height":
+			z.Height, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Timestamp":
+			err = z.Timestamp.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *RangeEndpoint) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Height"
+	err = en.Append(0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Height")
+		return
+	}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
+	}
+	err = z.Timestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *RangeEndpoint) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Height"
+	o = append(o, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *RangeEndpoint) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Height":
+			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Timestamp":
+			bts, err = z.Timestamp.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *RangeEndpoint) Msgsize() (s int) {
+	s = 1 + 7 + msgp.Uint64Size + 10 + z.Timestamp.Msgsize()
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SIBQueryResult) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "SIBValueData":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "SIBValueData")
+				return
+			}
+			for zb0002 > 0 {
+				zb0002--
+				field, err = dc.ReadMapKeyPtr()
+				if err != nil {
+					err = msgp.WrapError(err, "SIBValueData")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "s":
+					err = z.SIBValueData.SIB.DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "SIBValueData", "SIB")
+						return
+					}
+				case "f":
+					err = z.SIBValueData.FloorPrice.DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "SIBValueData", "FloorPrice")
+						return
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, "SIBValueData")
+						return
+					}
+				}
+			}
+		case "Height":
+			z.Height, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Timestamp":
+			err = z.Timestamp.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SIBQueryResult) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "SIBValueData"
+	// map header, size 2
+	// write "s"
+	err = en.Append(0x83, 0xac, 0x53, 0x49, 0x42, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x61, 0x74, 0x61, 0x82, 0xa1, 0x73)
+	if err != nil {
+		return
+	}
+	err = z.SIBValueData.SIB.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "SIBValueData", "SIB")
+		return
+	}
+	// write "f"
+	err = en.Append(0xa1, 0x66)
+	if err != nil {
+		return
+	}
+	err = z.SIBValueData.FloorPrice.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "SIBValueData", "FloorPrice")
+		return
+	}
+	// write "Height"
+	err = en.Append(0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Height")
+		return
+	}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
+	}
+	err = z.Timestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SIBQueryResult) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "SIBValueData"
+	// map header, size 2
+	// string "s"
+	o = append(o, 0x83, 0xac, 0x53, 0x49, 0x42, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x61, 0x74, 0x61, 0x82, 0xa1, 0x73)
+	o, err = z.SIBValueData.SIB.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "SIBValueData", "SIB")
+		return
+	}
+	// string "f"
+	o = append(o, 0xa1, 0x66)
+	o, err = z.SIBValueData.FloorPrice.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "SIBValueData", "FloorPrice")
+		return
+	}
+	// string "Height"
+	o = append(o, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SIBQueryResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "SIBValueData":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SIBValueData")
+				return
+			}
+			for zb0002 > 0 {
+				zb0002--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SIBValueData")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "s":
+					bts, err = z.SIBValueData.SIB.UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "SIBValueData", "SIB")
+						return
+					}
+				case "f":
+					bts, err = z.SIBValueData.FloorPrice.UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "SIBValueData", "FloorPrice")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "SIBValueData")
+						return
+					}
+				}
+			}
+		case "Height":
+			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Timestamp":
+			bts, err = z.Timestamp.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SIBQueryResult) Msgsize() (s int) {
+	s = 1 + 13 + 1 + 2 + z.SIBValueData.SIB.Msgsize() + 2 + z.SIBValueData.FloorPrice.Msgsize() + 7 + msgp.Uint64Size + 10 + z.Timestamp.Msgsize()
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SIBQueryResults) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Items":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Items")
+				return
+			}
+			if cap(z.Items) >= int(zb0002) {
+				z.Items = (z.Items)[:zb0002]
+			} else {
+				z.Items = make([]SIBQueryResult, zb0002)
+			}
+			for za0001 := range z.Items {
+				err = z.Items[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Items", za0001)
+					return
+				}
+			}
+		case "More":
+			z.More, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "More")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SIBQueryResults) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Items"
+	err = en.Append(0x82, 0xa5, 0x49, 0x74, 0x65, 0x6d, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Items)))
+	if err != nil {
+		err = msgp.WrapError(err, "Items")
+		return
+	}
+	for za0001 := range z.Items {
+		err = z.Items[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001)
+			return
+		}
+	}
+	// write "More"
+	err = en.Append(0xa4, 0x4d, 0x6f, 0x72, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.More)
+	if err != nil {
+		err = msgp.WrapError(err, "More")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SIBQueryResults) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Items"
+	o = append(o, 0x82, 0xa5, 0x49, 0x74, 0x65, 0x6d, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Items)))
+	for za0001 := range z.Items {
+		o, err = z.Items[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001)
+			return
+		}
+	}
+	// string "More"
+	o = append(o, 0xa4, 0x4d, 0x6f, 0x72, 0x65)
+	o = msgp.AppendBool(o, z.More)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SIBQueryResults) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Items":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Items")
+				return
+			}
+			if cap(z.Items) >= int(zb0002) {
+				z.Items = (z.Items)[:zb0002]
+			} else {
+				z.Items = make([]SIBQueryResult, zb0002)
+			}
+			for za0001 := range z.Items {
+				bts, err = z.Items[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Items", za0001)
+					return
+				}
+			}
+		case "More":
+			z.More, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "More")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SIBQueryResults) Msgsize() (s int) {
+	s = 1 + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Items {
+		s += z.Items[za0001].Msgsize()
+	}
+	s += 5 + msgp.BoolSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SIBValueData) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "s":
+			err = z.SIB.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "SIB")
+				return
+			}
+		case "f":
+			err = z.FloorPrice.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "FloorPrice")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SIBValueData) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "s"
+	err = en.Append(0x82, 0xa1, 0x73)
+	if err != nil {
+		return
+	}
+	err = z.SIB.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "SIB")
+		return
+	}
+	// write "f"
+	err = en.Append(0xa1, 0x66)
+	if err != nil {
+		return
+	}
+	err = z.FloorPrice.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "FloorPrice")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SIBValueData) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "s"
+	o = append(o, 0x82, 0xa1, 0x73)
+	o, err = z.SIB.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "SIB")
+		return
+	}
+	// string "f"
+	o = append(o, 0xa1, 0x66)
+	o, err = z.FloorPrice.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "FloorPrice")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SIBValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "s":
+			bts, err = z.SIB.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SIB")
+				return
+			}
+		case "f":
+			bts, err = z.FloorPrice.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FloorPrice")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SIBValueData) Msgsize() (s int) {
+	s = 1 + 2 + z.SIB.Msgsize() + 2 + z.FloorPrice.Msgsize()
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SupplyQueryParams) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "After":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "After")
+				return
+			}
+			for zb0002 > 0 {
+				zb0002--
+				field, err = dc.ReadMapKeyPtr()
+				if err != nil {
+					err = msgp.WrapError(err, "After")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.After.Height, err = dc.ReadUint64()
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Height")
+						return
+					}
+				case "Timestamp":
+					err = z.After.Timestamp.DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Timestamp")
+						return
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, "After")
+						return
+					}
+				}
+			}
+		case "Before":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Before")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, err = dc.ReadMapKeyPtr()
+				if err != nil {
+					err = msgp.WrapError(err, "Before")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.Before.Height, err = dc.ReadUint64()
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Height")
+						return
+					}
+				case "Timestamp":
+					err = z.Before.Timestamp.DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Timestamp")
+						return
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, "Before")
+						return
+					}
+				}
+			}
+		case "Limit":
+			z.Limit, err = dc.ReadUint()
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SupplyQueryParams) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "After"
+	// map header, size 2
+	// write "Height"
+	err = en.Append(0x83, 0xa5, 0x41, 0x66, 0x74, 0x65, 0x72, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.After.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "After", "Height")
+		return
+	}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
+	}
+	err = z.After.Timestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "After", "Timestamp")
+		return
+	}
+	// write "Before"
+	// map header, size 2
+	// write "Height"
+	err = en.Append(0xa6, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Before.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Before", "Height")
+		return
+	}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
+	}
+	err = z.Before.Timestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Before", "Timestamp")
+		return
+	}
+	// write "Limit"
+	err = en.Append(0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint(z.Limit)
+	if err != nil {
+		err = msgp.WrapError(err, "Limit")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SupplyQueryParams) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "After"
+	// map header, size 2
+	// string "Height"
+	o = append(o, 0x83, 0xa5, 0x41, 0x66, 0x74, 0x65, 0x72, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.After.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.After.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "After", "Timestamp")
+		return
+	}
+	// string "Before"
+	// map header, size 2
+	// string "Height"
+	o = append(o, 0xa6, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.Before.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.Before.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Before", "Timestamp")
+		return
+	}
+	// string "Limit"
+	o = append(o, 0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	o = msgp.AppendUint(o, z.Limit)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SupplyQueryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "After":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "After")
+				return
+			}
+			for zb0002 > 0 {
+				zb0002--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "After")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.After.Height, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Height")
+						return
+					}
+				case "Timestamp":
+					bts, err = z.After.Timestamp.UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Timestamp")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "After")
+						return
+					}
+				}
+			}
+		case "Before":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Before")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Before")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.Before.Height, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Height")
+						return
+					}
+				case "Timestamp":
+					bts, err = z.Before.Timestamp.UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Timestamp")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Before")
+						return
+					}
+				}
+			}
+		case "Limit":
+			z.Limit, bts, err = msgp.ReadUintBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SupplyQueryParams) Msgsize() (s int) {
+	s = 1 + 6 + 1 + 7 + msgp.Uint64Size + 10 + z.After.Timestamp.Msgsize() + 7 + 1 + 7 + msgp.Uint64Size + 10 + z.Before.Timestamp.Msgsize() + 6 + msgp.UintSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SupplyQueryResult) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "SupplyValueData":
+			err = z.SupplyValueData.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "SupplyValueData")
+				return
+			}
+		case "Height":
+			z.Height, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Timestamp":
+			err = z.Timestamp.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SupplyQueryResult) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "SupplyValueData"
+	err = en.Append(0x83, 0xaf, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x61, 0x74, 0x61)
+	if err != nil {
+		return
+	}
+	err = z.SupplyValueData.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "SupplyValueData")
+		return
+	}
+	// write "Height"
+	err = en.Append(0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Height")
+		return
+	}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
+	}
+	err = z.Timestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SupplyQueryResult) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "SupplyValueData"
+	o = append(o, 0x83, 0xaf, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x61, 0x74, 0x61)
+	o, err = z.SupplyValueData.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "SupplyValueData")
+		return
+	}
+	// string "Height"
+	o = append(o, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SupplyQueryResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "SupplyValueData":
+			bts, err = z.SupplyValueData.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SupplyValueData")
+				return
+			}
+		case "Height":
+			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Timestamp":
+			bts, err = z.Timestamp.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SupplyQueryResult) Msgsize() (s int) {
+	s = 1 + 16 + z.SupplyValueData.Msgsize() + 7 + msgp.Uint64Size + 10 + z.Timestamp.Msgsize()
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SupplyQueryResults) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Items":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Items")
+				return
+			}
+			if cap(z.Items) >= int(zb0002) {
+				z.Items = (z.Items)[:zb0002]
+			} else {
+				z.Items = make([]SupplyQueryResult, zb0002)
+			}
+			for za0001 := range z.Items {
+				var zb0003 uint32
+				zb0003, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Items", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Items", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "SupplyValueData":
+						err = z.Items[za0001].SupplyValueData.DecodeMsg(dc)
+						if err != nil {
+							err = msgp.WrapError(err, "Items", za0001, "SupplyValueData")
+							return
+						}
+					case "Height":
+						z.Items[za0001].Height, err = dc.ReadUint64()
+						if err != nil {
+							err = msgp.WrapError(err, "Items", za0001, "Height")
+							return
+						}
+					case "Timestamp":
+						err = z.Items[za0001].Timestamp.DecodeMsg(dc)
+						if err != nil {
+							err = msgp.WrapError(err, "Items", za0001, "Timestamp")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Items", za0001)
+							return
+						}
+					}
+				}
+			}
+		case "More":
+			z.More, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "More")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SupplyQueryResults) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Items"
+	err = en.Append(0x82, 0xa5, 0x49, 0x74, 0x65, 0x6d, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Items)))
+	if err != nil {
+		err = msgp.WrapError(err, "Items")
+		return
+	}
+	for za0001 := range z.Items {
+		// map header, size 3
+		// write "SupplyValueData"
+		err = en.Append(0x83, 0xaf, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x61, 0x74, 0x61)
+		if err != nil {
+			return
+		}
+		err = z.Items[za0001].SupplyValueData.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001, "SupplyValueData")
+			return
+		}
+		// write "Height"
+		err = en.Append(0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+		if err != nil {
+			return
+		}
+		err = en.WriteUint64(z.Items[za0001].Height)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001, "Height")
+			return
+		}
+		// write "Timestamp"
+		err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+		if err != nil {
+			return
+		}
+		err = z.Items[za0001].Timestamp.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001, "Timestamp")
+			return
+		}
+	}
+	// write "More"
+	err = en.Append(0xa4, 0x4d, 0x6f, 0x72, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.More)
+	if err != nil {
+		err = msgp.WrapError(err, "More")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SupplyQueryResults) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Items"
+	o = append(o, 0x82, 0xa5, 0x49, 0x74, 0x65, 0x6d, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Items)))
+	for za0001 := range z.Items {
+		// map header, size 3
+		// string "SupplyValueData"
+		o = append(o, 0x83, 0xaf, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x61, 0x74, 0x61)
+		o, err = z.Items[za0001].SupplyValueData.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001, "SupplyValueData")
+			return
+		}
+		// string "Height"
+		o = append(o, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+		o = msgp.AppendUint64(o, z.Items[za0001].Height)
+		// string "Timestamp"
+		o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+		o, err = z.Items[za0001].Timestamp.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001, "Timestamp")
+			return
+		}
+	}
+	// string "More"
+	o = append(o, 0xa4, 0x4d, 0x6f, 0x72, 0x65)
+	o = msgp.AppendBool(o, z.More)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SupplyQueryResults) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Items":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Items")
+				return
+			}
+			if cap(z.Items) >= int(zb0002) {
+				z.Items = (z.Items)[:zb0002]
+			} else {
+				z.Items = make([]SupplyQueryResult, zb0002)
+			}
+			for za0001 := range z.Items {
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Items", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Items", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "SupplyValueData":
+						bts, err = z.Items[za0001].SupplyValueData.UnmarshalMsg(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Items", za0001, "SupplyValueData")
+							return
+						}
+					case "Height":
+						z.Items[za0001].Height, bts, err = msgp.ReadUint64Bytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Items", za0001, "Height")
+							return
+						}
+					case "Timestamp":
+						bts, err = z.Items[za0001].Timestamp.UnmarshalMsg(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Items", za0001, "Timestamp")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Items", za0001)
+							return
+						}
+					}
+				}
+			}
+		case "More":
+			z.More, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "More")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SupplyQueryResults) Msgsize() (s int) {
+	s = 1 + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Items {
+		s += 1 + 16 + z.Items[za0001].SupplyValueData.Msgsize() + 7 + msgp.Uint64Size + 10 + z.Items[za0001].Timestamp.Msgsize()
+	}
+	s += 5 + msgp.BoolSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SupplyValueData) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "n":
+			err = z.TotalNdau.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalNdau")
+				return
+			}
+		case "c":
+			err = z.TotalCirculation.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalCirculation")
+				return
 			}
-		case "Before":
-			var zb0003 uint32
-			zb0003, err = dc.ReadMapHeader()
+		case "r":
+			err = z.TotalRFE.DecodeMsg(dc)
 			if err != nil {
-				err = msgp.WrapError(err, "Before")
+				err = msgp.WrapError(err, "TotalRFE")
 				return
 			}
-			for zb0003 > 0 {
-				zb0003--
-				field, err = dc.ReadMapKeyPtr()
-				if err != nil {
-					err = msgp.WrapError(err, "Before")
-					return
-				}
-				switch msgp.UnsafeString(field) {
-				case "Height":
-					z.Before.Height, err = dc.ReadUint64()
-					if err != nil {
-						err = msgp.WrapError(err, "Before", "Height")
-						return
-					}
-				case "Timestamp":
-					err = z.Before.Timestamp.DecodeMsg(dc)
-					if err != nil {
-						err = msgp.WrapError(err, "Before", "Timestamp")
-						return
-					}
-				default:
-					err = dc.Skip()
-					if err != nil {
-						err = msgp.WrapError(err, "Before")
-						return
-					}
-				}
+		case "i":
+			err = z.TotalIssue.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalIssue")
+				return
 			}
-		case "Limit":
-			z.Limit, err = dc.ReadUint()
+		case "b":
+			err = z.TotalBurned.DecodeMsg(dc)
 			if err != nil {
-				err = msgp.WrapError(err, "Limit")
+				err = msgp.WrapError(err, "TotalBurned")
+				return
+			}
+		case "s":
+			err = z.SIB.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "SIB")
+				return
+			}
+		case "v":
+			err = z.EndowmentNAV.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "EndowmentNAV")
+				return
+			}
+		case "f":
+			err = z.FloorPrice.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "FloorPrice")
 				return
 			}
 		default:
@@ -852,101 +4112,156 @@ func (z *PriceQueryParams) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *PriceQueryParams) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "After"
-	// map header, size 2
-	// write "Height"
-	err = en.Append(0x83, 0xa5, 0x41, 0x66, 0x74, 0x65, 0x72, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+func (z *SupplyValueData) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 8
+	// write "n"
+	err = en.Append(0x88, 0xa1, 0x6e)
 	if err != nil {
 		return
 	}
-	err = en.WriteUint64(z.After.Height)
+	err = z.TotalNdau.EncodeMsg(en)
 	if err != nil {
-		err = msgp.WrapError(err, "After", "Height")
+		err = msgp.WrapError(err, "TotalNdau")
 		return
 	}
-	// write "Timestamp"
-	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	// write "c"
+	err = en.Append(0xa1, 0x63)
 	if err != nil {
 		return
 	}
-	err = z.After.Timestamp.EncodeMsg(en)
+	err = z.TotalCirculation.EncodeMsg(en)
 	if err != nil {
-		err = msgp.WrapError(err, "After", "Timestamp")
+		err = msgp.WrapError(err, "TotalCirculation")
 		return
 	}
-	// write "Before"
-	// map header, size 2
-	// write "Height"
-	err = en.Append(0xa6, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	// write "r"
+	err = en.Append(0xa1, 0x72)
 	if err != nil {
 		return
 	}
-	err = en.WriteUint64(z.Before.Height)
+	err = z.TotalRFE.EncodeMsg(en)
 	if err != nil {
-		err = msgp.WrapError(err, "Before", "Height")
+		err = msgp.WrapError(err, "TotalRFE")
 		return
 	}
-	// write "Timestamp"
-	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	// write "i"
+	err = en.Append(0xa1, 0x69)
 	if err != nil {
 		return
 	}
-	err = z.Before.Timestamp.EncodeMsg(en)
+	err = z.TotalIssue.EncodeMsg(en)
 	if err != nil {
-		err = msgp.WrapError(err, "Before", "Timestamp")
+		err = msgp.WrapError(err, "TotalIssue")
 		return
 	}
-	// write "Limit"
-	err = en.Append(0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	// write "b"
+	err = en.Append(0xa1, 0x62)
 	if err != nil {
 		return
 	}
-	err = en.WriteUint(z.Limit)
+	err = z.TotalBurned.EncodeMsg(en)
 	if err != nil {
-		err = msgp.WrapError(err, "Limit")
+		err = msgp.WrapError(err, "TotalBurned")
+		return
+	}
+	// write "s"
+	err = en.Append(0xa1, 0x73)
+	if err != nil {
+		return
+	}
+	err = z.SIB.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "SIB")
+		return
+	}
+	// write "v"
+	err = en.Append(0xa1, 0x76)
+	if err != nil {
+		return
+	}
+	err = z.EndowmentNAV.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "EndowmentNAV")
+		return
+	}
+	// write "f"
+	err = en.Append(0xa1, 0x66)
+	if err != nil {
+		return
+	}
+	err = z.FloorPrice.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "FloorPrice")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *PriceQueryParams) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *SupplyValueData) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "After"
-	// map header, size 2
-	// string "Height"
-	o = append(o, 0x83, 0xa5, 0x41, 0x66, 0x74, 0x65, 0x72, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendUint64(o, z.After.Height)
-	// string "Timestamp"
-	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
-	o, err = z.After.Timestamp.MarshalMsg(o)
+	// map header, size 8
+	// string "n"
+	o = append(o, 0x88, 0xa1, 0x6e)
+	o, err = z.TotalNdau.MarshalMsg(o)
 	if err != nil {
-		err = msgp.WrapError(err, "After", "Timestamp")
+		err = msgp.WrapError(err, "TotalNdau")
 		return
 	}
-	// string "Before"
-	// map header, size 2
-	// string "Height"
-	o = append(o, 0xa6, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendUint64(o, z.Before.Height)
-	// string "Timestamp"
-	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
-	o, err = z.Before.Timestamp.MarshalMsg(o)
+	// string "c"
+	o = append(o, 0xa1, 0x63)
+	o, err = z.TotalCirculation.MarshalMsg(o)
 	if err != nil {
-		err = msgp.WrapError(err, "Before", "Timestamp")
+		err = msgp.WrapError(err, "TotalCirculation")
+		return
+	}
+	// string "r"
+	o = append(o, 0xa1, 0x72)
+	o, err = z.TotalRFE.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalRFE")
+		return
+	}
+	// string "i"
+	o = append(o, 0xa1, 0x69)
+	o, err = z.TotalIssue.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalIssue")
+		return
+	}
+	// string "b"
+	o = append(o, 0xa1, 0x62)
+	o, err = z.TotalBurned.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalBurned")
+		return
+	}
+	// string "s"
+	o = append(o, 0xa1, 0x73)
+	o, err = z.SIB.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "SIB")
+		return
+	}
+	// string "v"
+	o = append(o, 0xa1, 0x76)
+	o, err = z.EndowmentNAV.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "EndowmentNAV")
+		return
+	}
+	// string "f"
+	o = append(o, 0xa1, 0x66)
+	o, err = z.FloorPrice.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "FloorPrice")
 		return
 	}
-	// string "Limit"
-	o = append(o, 0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
-	o = msgp.AppendUint(o, z.Limit)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *PriceQueryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *SupplyValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -963,80 +4278,52 @@ func (z *PriceQueryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "After":
-			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+		case "n":
+			bts, err = z.TotalNdau.UnmarshalMsg(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "After")
+				err = msgp.WrapError(err, "TotalNdau")
 				return
 			}
-			for zb0002 > 0 {
-				zb0002--
-				field, bts, err = msgp.ReadMapKeyZC(bts)
-				if err != nil {
-					err = msgp.WrapError(err, "After")
-					return
-				}
-				switch msgp.UnsafeString(field) {
-				case "Height":
-					z.After.Height, bts, err = msgp.ReadUint64Bytes(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "After", "Height")
-						return
-					}
-				case "Timestamp":
-					bts, err = z.After.Timestamp.UnmarshalMsg(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "After", "Timestamp")
-						return
-					}
-				default:
-					bts, err = msgp.Skip(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "After")
-						return
-					}
-				}
+		case "c":
+			bts, err = z.TotalCirculation.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalCirculation")
+				return
 			}
-		case "Before":
-			var zb0003 uint32
-			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+		case "r":
+			bts, err = z.TotalRFE.UnmarshalMsg(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Before")
+				err = msgp.WrapError(err, "TotalRFE")
 				return
 			}
-			for zb0003 > 0 {
-				zb0003--
-				field, bts, err = msgp.ReadMapKeyZC(bts)
-				if err != nil {
-					err = msgp.WrapError(err, "Before")
-					return
-				}
-				switch msgp.UnsafeString(field) {
-				case "Height":
-					z.Before.Height, bts, err = msgp.ReadUint64Bytes(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "Before", "Height")
-						return
-					}
-				case "Timestamp":
-					bts, err = z.Before.Timestamp.UnmarshalMsg(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "Before", "Timestamp")
-						return
-					}
-				default:
-					bts, err = msgp.Skip(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "Before")
-						return
-					}
-				}
+		case "i":
+			bts, err = z.TotalIssue.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalIssue")
+				return
 			}
-		case "Limit":
-			z.Limit, bts, err = msgp.ReadUintBytes(bts)
+		case "b":
+			bts, err = z.TotalBurned.UnmarshalMsg(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Limit")
+				err = msgp.WrapError(err, "TotalBurned")
+				return
+			}
+		case "s":
+			bts, err = z.SIB.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SIB")
+				return
+			}
+		case "v":
+			bts, err = z.EndowmentNAV.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EndowmentNAV")
+				return
+			}
+		case "f":
+			bts, err = z.FloorPrice.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FloorPrice")
 				return
 			}
 		default:
@@ -1052,13 +4339,13 @@ func (z *PriceQueryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *PriceQueryParams) Msgsize() (s int) {
-	s = 1 + 6 + 1 + 7 + msgp.Uint64Size + 10 + z.After.Timestamp.Msgsize() + 7 + 1 + 7 + msgp.Uint64Size + 10 + z.Before.Timestamp.Msgsize() + 6 + msgp.UintSize
+func (z *SupplyValueData) Msgsize() (s int) {
+	s = 1 + 2 + z.TotalNdau.Msgsize() + 2 + z.TotalCirculation.Msgsize() + 2 + z.TotalRFE.Msgsize() + 2 + z.TotalIssue.Msgsize() + 2 + z.TotalBurned.Msgsize() + 2 + z.SIB.Msgsize() + 2 + z.EndowmentNAV.Msgsize() + 2 + z.FloorPrice.Msgsize()
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *PriceQueryResult) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *SysvarHistoryParams) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1072,31 +4359,25 @@ func (z *PriceQueryResult) DecodeMsg(dc *msgp.Reader) (err error) {
 		field, err = dc.ReadMapKeyPtr()
 		if err != nil {
 			err = msgp.WrapError(err)
-			return
-		}
-		switch msgp.UnsafeString(field) {
-		case "Price":
-			err = z.Price.DecodeMsg(dc)
-			if err != nil {
-				err = msgp.WrapError(err, "Price")
-				return
-			}
-		case "PriceS":
-			z.PriceS, err = dc.ReadString()
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Name":
+			z.Name, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "PriceS")
+				err = msgp.WrapError(err, "Name")
 				return
 			}
-		case "Height":
-			z.Height, err = dc.ReadUint64()
+		case "AfterHeight":
+			z.AfterHeight, err = dc.ReadUint64()
 			if err != nil {
-				err = msgp.WrapError(err, "Height")
+				err = msgp.WrapError(err, "AfterHeight")
 				return
 			}
-		case "Timestamp":
-			err = z.Timestamp.DecodeMsg(dc)
+		case "Limit":
+			z.Limit, err = dc.ReadInt()
 			if err != nil {
-				err = msgp.WrapError(err, "Timestamp")
+				err = msgp.WrapError(err, "Limit")
 				return
 			}
 		default:
@@ -1111,80 +4392,59 @@ func (z *PriceQueryResult) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *PriceQueryResult) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 4
-	// write "Price"
-	err = en.Append(0x84, 0xa5, 0x50, 0x72, 0x69, 0x63, 0x65)
-	if err != nil {
-		return
-	}
-	err = z.Price.EncodeMsg(en)
-	if err != nil {
-		err = msgp.WrapError(err, "Price")
-		return
-	}
-	// write "PriceS"
-	err = en.Append(0xa6, 0x50, 0x72, 0x69, 0x63, 0x65, 0x53)
+func (z SysvarHistoryParams) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Name"
+	err = en.Append(0x83, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.PriceS)
+	err = en.WriteString(z.Name)
 	if err != nil {
-		err = msgp.WrapError(err, "PriceS")
+		err = msgp.WrapError(err, "Name")
 		return
 	}
-	// write "Height"
-	err = en.Append(0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	// write "AfterHeight"
+	err = en.Append(0xab, 0x41, 0x66, 0x74, 0x65, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteUint64(z.Height)
+	err = en.WriteUint64(z.AfterHeight)
 	if err != nil {
-		err = msgp.WrapError(err, "Height")
+		err = msgp.WrapError(err, "AfterHeight")
 		return
 	}
-	// write "Timestamp"
-	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	// write "Limit"
+	err = en.Append(0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
 	if err != nil {
 		return
 	}
-	err = z.Timestamp.EncodeMsg(en)
+	err = en.WriteInt(z.Limit)
 	if err != nil {
-		err = msgp.WrapError(err, "Timestamp")
+		err = msgp.WrapError(err, "Limit")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *PriceQueryResult) MarshalMsg(b []byte) (o []byte, err error) {
+func (z SysvarHistoryParams) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
-	// string "Price"
-	o = append(o, 0x84, 0xa5, 0x50, 0x72, 0x69, 0x63, 0x65)
-	o, err = z.Price.MarshalMsg(o)
-	if err != nil {
-		err = msgp.WrapError(err, "Price")
-		return
-	}
-	// string "PriceS"
-	o = append(o, 0xa6, 0x50, 0x72, 0x69, 0x63, 0x65, 0x53)
-	o = msgp.AppendString(o, z.PriceS)
-	// string "Height"
-	o = append(o, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendUint64(o, z.Height)
-	// string "Timestamp"
-	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
-	o, err = z.Timestamp.MarshalMsg(o)
-	if err != nil {
-		err = msgp.WrapError(err, "Timestamp")
-		return
-	}
+	// map header, size 3
+	// string "Name"
+	o = append(o, 0x83, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.Name)
+	// string "AfterHeight"
+	o = append(o, 0xab, 0x41, 0x66, 0x74, 0x65, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.AfterHeight)
+	// string "Limit"
+	o = append(o, 0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	o = msgp.AppendInt(o, z.Limit)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *PriceQueryResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *SysvarHistoryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1201,28 +4461,22 @@ func (z *PriceQueryResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Price":
-			bts, err = z.Price.UnmarshalMsg(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "Price")
-				return
-			}
-		case "PriceS":
-			z.PriceS, bts, err = msgp.ReadStringBytes(bts)
+		case "Name":
+			z.Name, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "PriceS")
+				err = msgp.WrapError(err, "Name")
 				return
 			}
-		case "Height":
-			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+		case "AfterHeight":
+			z.AfterHeight, bts, err = msgp.ReadUint64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Height")
+				err = msgp.WrapError(err, "AfterHeight")
 				return
 			}
-		case "Timestamp":
-			bts, err = z.Timestamp.UnmarshalMsg(bts)
+		case "Limit":
+			z.Limit, bts, err = msgp.ReadIntBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Timestamp")
+				err = msgp.WrapError(err, "Limit")
 				return
 			}
 		default:
@@ -1238,13 +4492,13 @@ func (z *PriceQueryResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *PriceQueryResult) Msgsize() (s int) {
-	s = 1 + 6 + z.Price.Msgsize() + 7 + msgp.StringPrefixSize + len(z.PriceS) + 7 + msgp.Uint64Size + 10 + z.Timestamp.Msgsize()
+func (z SysvarHistoryParams) Msgsize() (s int) {
+	s = 1 + 5 + msgp.StringPrefixSize + len(z.Name) + 12 + msgp.Uint64Size + 6 + msgp.IntSize
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *PriceQueryResults) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *TxListValueData) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1261,29 +4515,29 @@ func (z *PriceQueryResults) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Items":
+		case "t":
 			var zb0002 uint32
 			zb0002, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "Items")
+				err = msgp.WrapError(err, "Txs")
 				return
 			}
-			if cap(z.Items) >= int(zb0002) {
-				z.Items = (z.Items)[:zb0002]
+			if cap(z.Txs) >= int(zb0002) {
+				z.Txs = (z.Txs)[:zb0002]
 			} else {
-				z.Items = make([]PriceQueryResult, zb0002)
+				z.Txs = make([]TxValueData, zb0002)
 			}
-			for za0001 := range z.Items {
-				err = z.Items[za0001].DecodeMsg(dc)
+			for za0001 := range z.Txs {
+				err = z.Txs[za0001].DecodeMsg(dc)
 				if err != nil {
-					err = msgp.WrapError(err, "Items", za0001)
+					err = msgp.WrapError(err, "Txs", za0001)
 					return
 				}
 			}
-		case "More":
-			z.More, err = dc.ReadBool()
+		case "n":
+			z.NextTxHash, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "More")
+				err = msgp.WrapError(err, "NextTxHash")
 				return
 			}
 		default:
@@ -1298,60 +4552,60 @@ func (z *PriceQueryResults) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *PriceQueryResults) EncodeMsg(en *msgp.Writer) (err error) {
+func (z *TxListValueData) EncodeMsg(en *msgp.Writer) (err error) {
 	// map header, size 2
-	// write "Items"
-	err = en.Append(0x82, 0xa5, 0x49, 0x74, 0x65, 0x6d, 0x73)
+	// write "t"
+	err = en.Append(0x82, 0xa1, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.Items)))
+	err = en.WriteArrayHeader(uint32(len(z.Txs)))
 	if err != nil {
-		err = msgp.WrapError(err, "Items")
+		err = msgp.WrapError(err, "Txs")
 		return
 	}
-	for za0001 := range z.Items {
-		err = z.Items[za0001].EncodeMsg(en)
+	for za0001 := range z.Txs {
+		err = z.Txs[za0001].EncodeMsg(en)
 		if err != nil {
-			err = msgp.WrapError(err, "Items", za0001)
+			err = msgp.WrapError(err, "Txs", za0001)
 			return
 		}
 	}
-	// write "More"
-	err = en.Append(0xa4, 0x4d, 0x6f, 0x72, 0x65)
+	// write "n"
+	err = en.Append(0xa1, 0x6e)
 	if err != nil {
 		return
 	}
-	err = en.WriteBool(z.More)
+	err = en.WriteString(z.NextTxHash)
 	if err != nil {
-		err = msgp.WrapError(err, "More")
+		err = msgp.WrapError(err, "NextTxHash")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *PriceQueryResults) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *TxListValueData) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// map header, size 2
-	// string "Items"
-	o = append(o, 0x82, 0xa5, 0x49, 0x74, 0x65, 0x6d, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Items)))
-	for za0001 := range z.Items {
-		o, err = z.Items[za0001].MarshalMsg(o)
+	// string "t"
+	o = append(o, 0x82, 0xa1, 0x74)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Txs)))
+	for za0001 := range z.Txs {
+		o, err = z.Txs[za0001].MarshalMsg(o)
 		if err != nil {
-			err = msgp.WrapError(err, "Items", za0001)
+			err = msgp.WrapError(err, "Txs", za0001)
 			return
 		}
 	}
-	// string "More"
-	o = append(o, 0xa4, 0x4d, 0x6f, 0x72, 0x65)
-	o = msgp.AppendBool(o, z.More)
+	// string "n"
+	o = append(o, 0xa1, 0x6e)
+	o = msgp.AppendString(o, z.NextTxHash)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *PriceQueryResults) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *TxListValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1368,29 +4622,29 @@ func (z *PriceQueryResults) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Items":
+		case "t":
 			var zb0002 uint32
 			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Items")
+				err = msgp.WrapError(err, "Txs")
 				return
 			}
-			if cap(z.Items) >= int(zb0002) {
-				z.Items = (z.Items)[:zb0002]
+			if cap(z.Txs) >= int(zb0002) {
+				z.Txs = (z.Txs)[:zb0002]
 			} else {
-				z.Items = make([]PriceQueryResult, zb0002)
+				z.Txs = make([]TxValueData, zb0002)
 			}
-			for za0001 := range z.Items {
-				bts, err = z.Items[za0001].UnmarshalMsg(bts)
+			for za0001 := range z.Txs {
+				bts, err = z.Txs[za0001].UnmarshalMsg(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "Items", za0001)
+					err = msgp.WrapError(err, "Txs", za0001)
 					return
 				}
 			}
-		case "More":
-			z.More, bts, err = msgp.ReadBoolBytes(bts)
+		case "n":
+			z.NextTxHash, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "More")
+				err = msgp.WrapError(err, "NextTxHash")
 				return
 			}
 		default:
@@ -1406,17 +4660,17 @@ func (z *PriceQueryResults) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *PriceQueryResults) Msgsize() (s int) {
-	s = 1 + 6 + msgp.ArrayHeaderSize
-	for za0001 := range z.Items {
-		s += z.Items[za0001].Msgsize()
+func (z *TxListValueData) Msgsize() (s int) {
+	s = 1 + 2 + msgp.ArrayHeaderSize
+	for za0001 := range z.Txs {
+		s += z.Txs[za0001].Msgsize()
 	}
-	s += 5 + msgp.BoolSize
+	s += 2 + msgp.StringPrefixSize + len(z.NextTxHash)
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *QueryParams) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *TxValueData) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1433,41 +4687,28 @@ func (z *QueryParams) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Command":
-			z.Command, err = dc.ReadString()
+		case "h":
+			z.BlockHeight, err = dc.ReadUint64()
 			if err != nil {
-				err = msgp.WrapError(err, "Command")
+				err = msgp.WrapError(err, "BlockHeight")
 				return
 			}
-		case "Hash":
-			z.Hash, err = dc.ReadString()
+		case "o":
+			z.TxOffset, err = dc.ReadInt()
 			if err != nil {
-				err = msgp.WrapError(err, "Hash")
+				err = msgp.WrapError(err, "TxOffset")
 				return
 			}
-		case "Types":
-			var zb0002 uint32
-			zb0002, err = dc.ReadArrayHeader()
+		case "f":
+			z.Fee, err = dc.ReadUint64()
 			if err != nil {
-				err = msgp.WrapError(err, "Types")
-				return
-			}
-			if cap(z.Types) >= int(zb0002) {
-				z.Types = (z.Types)[:zb0002]
-			} else {
-				z.Types = make([]string, zb0002)
-			}
-			for za0001 := range z.Types {
-				z.Types[za0001], err = dc.ReadString()
-				if err != nil {
-					err = msgp.WrapError(err, "Types", za0001)
-					return
-				}
+				err = msgp.WrapError(err, "Fee")
+				return
 			}
-		case "Limit":
-			z.Limit, err = dc.ReadInt()
+		case "s":
+			z.SIB, err = dc.ReadUint64()
 			if err != nil {
-				err = msgp.WrapError(err, "Limit")
+				err = msgp.WrapError(err, "SIB")
 				return
 			}
 		default:
@@ -1482,82 +4723,72 @@ func (z *QueryParams) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *QueryParams) EncodeMsg(en *msgp.Writer) (err error) {
+func (z *TxValueData) EncodeMsg(en *msgp.Writer) (err error) {
 	// map header, size 4
-	// write "Command"
-	err = en.Append(0x84, 0xa7, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64)
+	// write "h"
+	err = en.Append(0x84, 0xa1, 0x68)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.Command)
+	err = en.WriteUint64(z.BlockHeight)
 	if err != nil {
-		err = msgp.WrapError(err, "Command")
+		err = msgp.WrapError(err, "BlockHeight")
 		return
 	}
-	// write "Hash"
-	err = en.Append(0xa4, 0x48, 0x61, 0x73, 0x68)
+	// write "o"
+	err = en.Append(0xa1, 0x6f)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.Hash)
+	err = en.WriteInt(z.TxOffset)
 	if err != nil {
-		err = msgp.WrapError(err, "Hash")
+		err = msgp.WrapError(err, "TxOffset")
 		return
 	}
-	// write "Types"
-	err = en.Append(0xa5, 0x54, 0x79, 0x70, 0x65, 0x73)
+	// write "f"
+	err = en.Append(0xa1, 0x66)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.Types)))
+	err = en.WriteUint64(z.Fee)
 	if err != nil {
-		err = msgp.WrapError(err, "Types")
+		err = msgp.WrapError(err, "Fee")
 		return
 	}
-	for za0001 := range z.Types {
-		err = en.WriteString(z.Types[za0001])
-		if err != nil {
-			err = msgp.WrapError(err, "Types", za0001)
-			return
-		}
-	}
-	// write "Limit"
-	err = en.Append(0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	// write "s"
+	err = en.Append(0xa1, 0x73)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt(z.Limit)
+	err = en.WriteUint64(z.SIB)
 	if err != nil {
-		err = msgp.WrapError(err, "Limit")
+		err = msgp.WrapError(err, "SIB")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *QueryParams) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *TxValueData) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// map header, size 4
-	// string "Command"
-	o = append(o, 0x84, 0xa7, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64)
-	o = msgp.AppendString(o, z.Command)
-	// string "Hash"
-	o = append(o, 0xa4, 0x48, 0x61, 0x73, 0x68)
-	o = msgp.AppendString(o, z.Hash)
-	// string "Types"
-	o = append(o, 0xa5, 0x54, 0x79, 0x70, 0x65, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Types)))
-	for za0001 := range z.Types {
-		o = msgp.AppendString(o, z.Types[za0001])
-	}
-	// string "Limit"
-	o = append(o, 0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
-	o = msgp.AppendInt(o, z.Limit)
+	// string "h"
+	o = append(o, 0x84, 0xa1, 0x68)
+	o = msgp.AppendUint64(o, z.BlockHeight)
+	// string "o"
+	o = append(o, 0xa1, 0x6f)
+	o = msgp.AppendInt(o, z.TxOffset)
+	// string "f"
+	o = append(o, 0xa1, 0x66)
+	o = msgp.AppendUint64(o, z.Fee)
+	// string "s"
+	o = append(o, 0xa1, 0x73)
+	o = msgp.AppendUint64(o, z.SIB)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *QueryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *TxValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1574,41 +4805,28 @@ func (z *QueryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Command":
-			z.Command, bts, err = msgp.ReadStringBytes(bts)
+		case "h":
+			z.BlockHeight, bts, err = msgp.ReadUint64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Command")
+				err = msgp.WrapError(err, "BlockHeight")
 				return
 			}
-		case "Hash":
-			z.Hash, bts, err = msgp.ReadStringBytes(bts)
+		case "o":
+			z.TxOffset, bts, err = msgp.ReadIntBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Hash")
+				err = msgp.WrapError(err, "TxOffset")
 				return
 			}
-		case "Types":
-			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "f":
+			z.Fee, bts, err = msgp.ReadUint64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Types")
+				err = msgp.WrapError(err, "Fee")
 				return
 			}
-			if cap(z.Types) >= int(zb0002) {
-				z.Types = (z.Types)[:zb0002]
-			} else {
-				z.Types = make([]string, zb0002)
-			}
-			for za0001 := range z.Types {
-				z.Types[za0001], bts, err = msgp.ReadStringBytes(bts)
-				if err != nil {
-					err = msgp.WrapError(err, "Types", za0001)
-					return
-				}
-			}
-		case "Limit":
-			z.Limit, bts, err = msgp.ReadIntBytes(bts)
+		case "s":
+			z.SIB, bts, err = msgp.ReadUint64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Limit")
+				err = msgp.WrapError(err, "SIB")
 				return
 			}
 		default:
@@ -1624,17 +4842,13 @@ func (z *QueryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *QueryParams) Msgsize() (s int) {
-	s = 1 + 8 + msgp.StringPrefixSize + len(z.Command) + 5 + msgp.StringPrefixSize + len(z.Hash) + 6 + msgp.ArrayHeaderSize
-	for za0001 := range z.Types {
-		s += msgp.StringPrefixSize + len(z.Types[za0001])
-	}
-	s += 6 + msgp.IntSize
+func (z *TxValueData) Msgsize() (s int) {
+	s = 1 + 2 + msgp.Uint64Size + 2 + msgp.IntSize + 2 + msgp.Uint64Size + 2 + msgp.Uint64Size
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *RangeEndpoint) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *UnlockDayTotal) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1651,16 +4865,22 @@ func (z *RangeEndpoint) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Height":
-			z.Height, err = dc.ReadUint64()
+		case "Day":
+			err = z.Day.DecodeMsg(dc)
 			if err != nil {
-				err = msgp.WrapError(err, "Height")
+				err = msgp.WrapError(err, "Day")
 				return
 			}
-		case "Timestamp":
-			err = z.Timestamp.DecodeMsg(dc)
+		case "Accounts":
+			z.Accounts, err = dc.ReadUint64()
 			if err != nil {
-				err = msgp.WrapError(err, "Timestamp")
+				err = msgp.WrapError(err, "Accounts")
+				return
+			}
+		case "Balance":
+			err = z.Balance.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Balance")
 				return
 			}
 		default:
@@ -1675,50 +4895,67 @@ func (z *RangeEndpoint) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *RangeEndpoint) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
-	// write "Height"
-	err = en.Append(0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+func (z *UnlockDayTotal) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Day"
+	err = en.Append(0x83, 0xa3, 0x44, 0x61, 0x79)
 	if err != nil {
 		return
 	}
-	err = en.WriteUint64(z.Height)
+	err = z.Day.EncodeMsg(en)
 	if err != nil {
-		err = msgp.WrapError(err, "Height")
+		err = msgp.WrapError(err, "Day")
 		return
 	}
-	// write "Timestamp"
-	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	// write "Accounts"
+	err = en.Append(0xa8, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73)
 	if err != nil {
 		return
 	}
-	err = z.Timestamp.EncodeMsg(en)
+	err = en.WriteUint64(z.Accounts)
 	if err != nil {
-		err = msgp.WrapError(err, "Timestamp")
+		err = msgp.WrapError(err, "Accounts")
+		return
+	}
+	// write "Balance"
+	err = en.Append(0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+	if err != nil {
+		return
+	}
+	err = z.Balance.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Balance")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *RangeEndpoint) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *UnlockDayTotal) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
-	// string "Height"
-	o = append(o, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendUint64(o, z.Height)
-	// string "Timestamp"
-	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
-	o, err = z.Timestamp.MarshalMsg(o)
+	// map header, size 3
+	// string "Day"
+	o = append(o, 0x83, 0xa3, 0x44, 0x61, 0x79)
+	o, err = z.Day.MarshalMsg(o)
 	if err != nil {
-		err = msgp.WrapError(err, "Timestamp")
+		err = msgp.WrapError(err, "Day")
+		return
+	}
+	// string "Accounts"
+	o = append(o, 0xa8, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73)
+	o = msgp.AppendUint64(o, z.Accounts)
+	// string "Balance"
+	o = append(o, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+	o, err = z.Balance.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Balance")
 		return
 	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *RangeEndpoint) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *UnlockDayTotal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1735,16 +4972,22 @@ func (z *RangeEndpoint) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Height":
-			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+		case "Day":
+			bts, err = z.Day.UnmarshalMsg(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Height")
+				err = msgp.WrapError(err, "Day")
 				return
 			}
-		case "Timestamp":
-			bts, err = z.Timestamp.UnmarshalMsg(bts)
+		case "Accounts":
+			z.Accounts, bts, err = msgp.ReadUint64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Timestamp")
+				err = msgp.WrapError(err, "Accounts")
+				return
+			}
+		case "Balance":
+			bts, err = z.Balance.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Balance")
 				return
 			}
 		default:
@@ -1760,13 +5003,13 @@ func (z *RangeEndpoint) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *RangeEndpoint) Msgsize() (s int) {
-	s = 1 + 7 + msgp.Uint64Size + 10 + z.Timestamp.Msgsize()
+func (z *UnlockDayTotal) Msgsize() (s int) {
+	s = 1 + 4 + z.Day.Msgsize() + 9 + msgp.Uint64Size + 8 + z.Balance.Msgsize()
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *SysvarHistoryParams) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *UnlockValueData) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1783,22 +5026,28 @@ func (z *SysvarHistoryParams) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Name":
-			z.Name, err = dc.ReadString()
+		case "a":
+			z.Address, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "Name")
+				err = msgp.WrapError(err, "Address")
 				return
 			}
-		case "AfterHeight":
-			z.AfterHeight, err = dc.ReadUint64()
+		case "u":
+			err = z.UnlocksOn.DecodeMsg(dc)
 			if err != nil {
-				err = msgp.WrapError(err, "AfterHeight")
+				err = msgp.WrapError(err, "UnlocksOn")
 				return
 			}
-		case "Limit":
-			z.Limit, err = dc.ReadInt()
+		case "b":
+			err = z.Balance.DecodeMsg(dc)
 			if err != nil {
-				err = msgp.WrapError(err, "Limit")
+				err = msgp.WrapError(err, "Balance")
+				return
+			}
+		case "h":
+			z.Height, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
 				return
 			}
 		default:
@@ -1813,59 +5062,80 @@ func (z *SysvarHistoryParams) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z SysvarHistoryParams) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "Name"
-	err = en.Append(0x83, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+func (z *UnlockValueData) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "a"
+	err = en.Append(0x84, 0xa1, 0x61)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.Name)
+	err = en.WriteString(z.Address)
 	if err != nil {
-		err = msgp.WrapError(err, "Name")
+		err = msgp.WrapError(err, "Address")
 		return
 	}
-	// write "AfterHeight"
-	err = en.Append(0xab, 0x41, 0x66, 0x74, 0x65, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	// write "u"
+	err = en.Append(0xa1, 0x75)
 	if err != nil {
 		return
 	}
-	err = en.WriteUint64(z.AfterHeight)
+	err = z.UnlocksOn.EncodeMsg(en)
 	if err != nil {
-		err = msgp.WrapError(err, "AfterHeight")
+		err = msgp.WrapError(err, "UnlocksOn")
 		return
 	}
-	// write "Limit"
-	err = en.Append(0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	// write "b"
+	err = en.Append(0xa1, 0x62)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt(z.Limit)
+	err = z.Balance.EncodeMsg(en)
 	if err != nil {
-		err = msgp.WrapError(err, "Limit")
+		err = msgp.WrapError(err, "Balance")
+		return
+	}
+	// write "h"
+	err = en.Append(0xa1, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Height")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z SysvarHistoryParams) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *UnlockValueData) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "Name"
-	o = append(o, 0x83, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
-	o = msgp.AppendString(o, z.Name)
-	// string "AfterHeight"
-	o = append(o, 0xab, 0x41, 0x66, 0x74, 0x65, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendUint64(o, z.AfterHeight)
-	// string "Limit"
-	o = append(o, 0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
-	o = msgp.AppendInt(o, z.Limit)
+	// map header, size 4
+	// string "a"
+	o = append(o, 0x84, 0xa1, 0x61)
+	o = msgp.AppendString(o, z.Address)
+	// string "u"
+	o = append(o, 0xa1, 0x75)
+	o, err = z.UnlocksOn.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "UnlocksOn")
+		return
+	}
+	// string "b"
+	o = append(o, 0xa1, 0x62)
+	o, err = z.Balance.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Balance")
+		return
+	}
+	// string "h"
+	o = append(o, 0xa1, 0x68)
+	o = msgp.AppendUint64(o, z.Height)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *SysvarHistoryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *UnlockValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1882,22 +5152,28 @@ func (z *SysvarHistoryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Name":
-			z.Name, bts, err = msgp.ReadStringBytes(bts)
+		case "a":
+			z.Address, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Name")
+				err = msgp.WrapError(err, "Address")
 				return
 			}
-		case "AfterHeight":
-			z.AfterHeight, bts, err = msgp.ReadUint64Bytes(bts)
+		case "u":
+			bts, err = z.UnlocksOn.UnmarshalMsg(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "AfterHeight")
+				err = msgp.WrapError(err, "UnlocksOn")
 				return
 			}
-		case "Limit":
-			z.Limit, bts, err = msgp.ReadIntBytes(bts)
+		case "b":
+			bts, err = z.Balance.UnmarshalMsg(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Limit")
+				err = msgp.WrapError(err, "Balance")
+				return
+			}
+		case "h":
+			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
 				return
 			}
 		default:
@@ -1913,13 +5189,13 @@ func (z *SysvarHistoryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z SysvarHistoryParams) Msgsize() (s int) {
-	s = 1 + 5 + msgp.StringPrefixSize + len(z.Name) + 12 + msgp.Uint64Size + 6 + msgp.IntSize
+func (z *UnlockValueData) Msgsize() (s int) {
+	s = 1 + 2 + msgp.StringPrefixSize + len(z.Address) + 2 + z.UnlocksOn.Msgsize() + 2 + z.Balance.Msgsize() + 2 + msgp.Uint64Size
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *TxListValueData) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *UnlocksQueryParams) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1936,29 +5212,86 @@ func (z *TxListValueData) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "t":
+		case "After":
 			var zb0002 uint32
-			zb0002, err = dc.ReadArrayHeader()
+			zb0002, err = dc.ReadMapHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "Txs")
+				err = msgp.WrapError(err, "After")
 				return
 			}
-			if cap(z.Txs) >= int(zb0002) {
-				z.Txs = (z.Txs)[:zb0002]
-			} else {
-				z.Txs = make([]TxValueData, zb0002)
+			for zb0002 > 0 {
+				zb0002--
+				field, err = dc.ReadMapKeyPtr()
+				if err != nil {
+					err = msgp.WrapError(err, "After")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.After.Height, err = dc.ReadUint64()
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Height")
+						return
+					}
+				case "Timestamp":
+					err = z.After.Timestamp.DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Timestamp")
+						return
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, "After")
+						return
+					}
+				}
 			}
-			for za0001 := range z.Txs {
-				err = z.Txs[za0001].DecodeMsg(dc)
+		case "Before":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Before")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, err = dc.ReadMapKeyPtr()
 				if err != nil {
-					err = msgp.WrapError(err, "Txs", za0001)
+					err = msgp.WrapError(err, "Before")
 					return
 				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.Before.Height, err = dc.ReadUint64()
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Height")
+						return
+					}
+				case "Timestamp":
+					err = z.Before.Timestamp.DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Timestamp")
+						return
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, "Before")
+						return
+					}
+				}
 			}
-		case "n":
-			z.NextTxHash, err = dc.ReadString()
+		case "Offset":
+			z.Offset, err = dc.ReadUint()
 			if err != nil {
-				err = msgp.WrapError(err, "NextTxHash")
+				err = msgp.WrapError(err, "Offset")
+				return
+			}
+		case "Limit":
+			z.Limit, err = dc.ReadUint()
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
 				return
 			}
 		default:
@@ -1973,60 +5306,114 @@ func (z *TxListValueData) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *TxListValueData) EncodeMsg(en *msgp.Writer) (err error) {
+func (z *UnlocksQueryParams) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "After"
 	// map header, size 2
-	// write "t"
-	err = en.Append(0x82, 0xa1, 0x74)
+	// write "Height"
+	err = en.Append(0x84, 0xa5, 0x41, 0x66, 0x74, 0x65, 0x72, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.Txs)))
+	err = en.WriteUint64(z.After.Height)
 	if err != nil {
-		err = msgp.WrapError(err, "Txs")
+		err = msgp.WrapError(err, "After", "Height")
 		return
 	}
-	for za0001 := range z.Txs {
-		err = z.Txs[za0001].EncodeMsg(en)
-		if err != nil {
-			err = msgp.WrapError(err, "Txs", za0001)
-			return
-		}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
 	}
-	// write "n"
-	err = en.Append(0xa1, 0x6e)
+	err = z.After.Timestamp.EncodeMsg(en)
 	if err != nil {
+		err = msgp.WrapError(err, "After", "Timestamp")
 		return
 	}
-	err = en.WriteString(z.NextTxHash)
+	// write "Before"
+	// map header, size 2
+	// write "Height"
+	err = en.Append(0xa6, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
 	if err != nil {
-		err = msgp.WrapError(err, "NextTxHash")
+		return
+	}
+	err = en.WriteUint64(z.Before.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Before", "Height")
+		return
+	}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
+	}
+	err = z.Before.Timestamp.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Before", "Timestamp")
+		return
+	}
+	// write "Offset"
+	err = en.Append(0xa6, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint(z.Offset)
+	if err != nil {
+		err = msgp.WrapError(err, "Offset")
+		return
+	}
+	// write "Limit"
+	err = en.Append(0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint(z.Limit)
+	if err != nil {
+		err = msgp.WrapError(err, "Limit")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *TxListValueData) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *UnlocksQueryParams) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "After"
 	// map header, size 2
-	// string "t"
-	o = append(o, 0x82, 0xa1, 0x74)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Txs)))
-	for za0001 := range z.Txs {
-		o, err = z.Txs[za0001].MarshalMsg(o)
-		if err != nil {
-			err = msgp.WrapError(err, "Txs", za0001)
-			return
-		}
+	// string "Height"
+	o = append(o, 0x84, 0xa5, 0x41, 0x66, 0x74, 0x65, 0x72, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.After.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.After.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "After", "Timestamp")
+		return
 	}
-	// string "n"
-	o = append(o, 0xa1, 0x6e)
-	o = msgp.AppendString(o, z.NextTxHash)
+	// string "Before"
+	// map header, size 2
+	// string "Height"
+	o = append(o, 0xa6, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.Before.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o, err = z.Before.Timestamp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Before", "Timestamp")
+		return
+	}
+	// string "Offset"
+	o = append(o, 0xa6, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74)
+	o = msgp.AppendUint(o, z.Offset)
+	// string "Limit"
+	o = append(o, 0xa5, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	o = msgp.AppendUint(o, z.Limit)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *TxListValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *UnlocksQueryParams) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -2043,29 +5430,86 @@ func (z *TxListValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "t":
+		case "After":
 			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Txs")
+				err = msgp.WrapError(err, "After")
 				return
 			}
-			if cap(z.Txs) >= int(zb0002) {
-				z.Txs = (z.Txs)[:zb0002]
-			} else {
-				z.Txs = make([]TxValueData, zb0002)
+			for zb0002 > 0 {
+				zb0002--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "After")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.After.Height, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Height")
+						return
+					}
+				case "Timestamp":
+					bts, err = z.After.Timestamp.UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "After", "Timestamp")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "After")
+						return
+					}
+				}
 			}
-			for za0001 := range z.Txs {
-				bts, err = z.Txs[za0001].UnmarshalMsg(bts)
+		case "Before":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Before")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "Txs", za0001)
+					err = msgp.WrapError(err, "Before")
 					return
 				}
+				switch msgp.UnsafeString(field) {
+				case "Height":
+					z.Before.Height, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Height")
+						return
+					}
+				case "Timestamp":
+					bts, err = z.Before.Timestamp.UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Before", "Timestamp")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Before")
+						return
+					}
+				}
 			}
-		case "n":
-			z.NextTxHash, bts, err = msgp.ReadStringBytes(bts)
+		case "Offset":
+			z.Offset, bts, err = msgp.ReadUintBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "NextTxHash")
+				err = msgp.WrapError(err, "Offset")
+				return
+			}
+		case "Limit":
+			z.Limit, bts, err = msgp.ReadUintBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
 				return
 			}
 		default:
@@ -2081,17 +5525,13 @@ func (z *TxListValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *TxListValueData) Msgsize() (s int) {
-	s = 1 + 2 + msgp.ArrayHeaderSize
-	for za0001 := range z.Txs {
-		s += z.Txs[za0001].Msgsize()
-	}
-	s += 2 + msgp.StringPrefixSize + len(z.NextTxHash)
+func (z *UnlocksQueryParams) Msgsize() (s int) {
+	s = 1 + 6 + 1 + 7 + msgp.Uint64Size + 10 + z.After.Timestamp.Msgsize() + 7 + 1 + 7 + msgp.Uint64Size + 10 + z.Before.Timestamp.Msgsize() + 7 + msgp.UintSize + 6 + msgp.UintSize
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *TxValueData) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *UnlocksQueryResults) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -2108,28 +5548,83 @@ func (z *TxValueData) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "h":
-			z.BlockHeight, err = dc.ReadUint64()
+		case "Items":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "BlockHeight")
+				err = msgp.WrapError(err, "Items")
 				return
 			}
-		case "o":
-			z.TxOffset, err = dc.ReadInt()
-			if err != nil {
-				err = msgp.WrapError(err, "TxOffset")
-				return
+			if cap(z.Items) >= int(zb0002) {
+				z.Items = (z.Items)[:zb0002]
+			} else {
+				z.Items = make([]UnlockValueData, zb0002)
 			}
-		case "f":
-			z.Fee, err = dc.ReadUint64()
+			for za0001 := range z.Items {
+				err = z.Items[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Items", za0001)
+					return
+				}
+			}
+		case "Days":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "Fee")
+				err = msgp.WrapError(err, "Days")
 				return
 			}
-		case "s":
-			z.SIB, err = dc.ReadUint64()
+			if cap(z.Days) >= int(zb0003) {
+				z.Days = (z.Days)[:zb0003]
+			} else {
+				z.Days = make([]UnlockDayTotal, zb0003)
+			}
+			for za0002 := range z.Days {
+				var zb0004 uint32
+				zb0004, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Days", za0002)
+					return
+				}
+				for zb0004 > 0 {
+					zb0004--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Days", za0002)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Day":
+						err = z.Days[za0002].Day.DecodeMsg(dc)
+						if err != nil {
+							err = msgp.WrapError(err, "Days", za0002, "Day")
+							return
+						}
+					case "Accounts":
+						z.Days[za0002].Accounts, err = dc.ReadUint64()
+						if err != nil {
+							err = msgp.WrapError(err, "Days", za0002, "Accounts")
+							return
+						}
+					case "Balance":
+						err = z.Days[za0002].Balance.DecodeMsg(dc)
+						if err != nil {
+							err = msgp.WrapError(err, "Days", za0002, "Balance")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Days", za0002)
+							return
+						}
+					}
+				}
+			}
+		case "More":
+			z.More, err = dc.ReadBool()
 			if err != nil {
-				err = msgp.WrapError(err, "SIB")
+				err = msgp.WrapError(err, "More")
 				return
 			}
 		default:
@@ -2144,72 +5639,126 @@ func (z *TxValueData) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *TxValueData) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 4
-	// write "h"
-	err = en.Append(0x84, 0xa1, 0x68)
+func (z *UnlocksQueryResults) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Items"
+	err = en.Append(0x83, 0xa5, 0x49, 0x74, 0x65, 0x6d, 0x73)
 	if err != nil {
 		return
 	}
-	err = en.WriteUint64(z.BlockHeight)
+	err = en.WriteArrayHeader(uint32(len(z.Items)))
 	if err != nil {
-		err = msgp.WrapError(err, "BlockHeight")
+		err = msgp.WrapError(err, "Items")
 		return
 	}
-	// write "o"
-	err = en.Append(0xa1, 0x6f)
-	if err != nil {
-		return
+	for za0001 := range z.Items {
+		err = z.Items[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001)
+			return
+		}
 	}
-	err = en.WriteInt(z.TxOffset)
+	// write "Days"
+	err = en.Append(0xa4, 0x44, 0x61, 0x79, 0x73)
 	if err != nil {
-		err = msgp.WrapError(err, "TxOffset")
 		return
 	}
-	// write "f"
-	err = en.Append(0xa1, 0x66)
+	err = en.WriteArrayHeader(uint32(len(z.Days)))
 	if err != nil {
+		err = msgp.WrapError(err, "Days")
 		return
 	}
-	err = en.WriteUint64(z.Fee)
-	if err != nil {
-		err = msgp.WrapError(err, "Fee")
-		return
+	for za0002 := range z.Days {
+		// map header, size 3
+		// write "Day"
+		err = en.Append(0x83, 0xa3, 0x44, 0x61, 0x79)
+		if err != nil {
+			return
+		}
+		err = z.Days[za0002].Day.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Days", za0002, "Day")
+			return
+		}
+		// write "Accounts"
+		err = en.Append(0xa8, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73)
+		if err != nil {
+			return
+		}
+		err = en.WriteUint64(z.Days[za0002].Accounts)
+		if err != nil {
+			err = msgp.WrapError(err, "Days", za0002, "Accounts")
+			return
+		}
+		// write "Balance"
+		err = en.Append(0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+		if err != nil {
+			return
+		}
+		err = z.Days[za0002].Balance.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Days", za0002, "Balance")
+			return
+		}
 	}
-	// write "s"
-	err = en.Append(0xa1, 0x73)
+	// write "More"
+	err = en.Append(0xa4, 0x4d, 0x6f, 0x72, 0x65)
 	if err != nil {
 		return
 	}
-	err = en.WriteUint64(z.SIB)
+	err = en.WriteBool(z.More)
 	if err != nil {
-		err = msgp.WrapError(err, "SIB")
+		err = msgp.WrapError(err, "More")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *TxValueData) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *UnlocksQueryResults) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
-	// string "h"
-	o = append(o, 0x84, 0xa1, 0x68)
-	o = msgp.AppendUint64(o, z.BlockHeight)
-	// string "o"
-	o = append(o, 0xa1, 0x6f)
-	o = msgp.AppendInt(o, z.TxOffset)
-	// string "f"
-	o = append(o, 0xa1, 0x66)
-	o = msgp.AppendUint64(o, z.Fee)
-	// string "s"
-	o = append(o, 0xa1, 0x73)
-	o = msgp.AppendUint64(o, z.SIB)
+	// map header, size 3
+	// string "Items"
+	o = append(o, 0x83, 0xa5, 0x49, 0x74, 0x65, 0x6d, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Items)))
+	for za0001 := range z.Items {
+		o, err = z.Items[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Items", za0001)
+			return
+		}
+	}
+	// string "Days"
+	o = append(o, 0xa4, 0x44, 0x61, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Days)))
+	for za0002 := range z.Days {
+		// map header, size 3
+		// string "Day"
+		o = append(o, 0x83, 0xa3, 0x44, 0x61, 0x79)
+		o, err = z.Days[za0002].Day.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Days", za0002, "Day")
+			return
+		}
+		// string "Accounts"
+		o = append(o, 0xa8, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73)
+		o = msgp.AppendUint64(o, z.Days[za0002].Accounts)
+		// string "Balance"
+		o = append(o, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+		o, err = z.Days[za0002].Balance.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Days", za0002, "Balance")
+			return
+		}
+	}
+	// string "More"
+	o = append(o, 0xa4, 0x4d, 0x6f, 0x72, 0x65)
+	o = msgp.AppendBool(o, z.More)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *TxValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *UnlocksQueryResults) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -2226,28 +5775,83 @@ func (z *TxValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "h":
-			z.BlockHeight, bts, err = msgp.ReadUint64Bytes(bts)
+		case "Items":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "BlockHeight")
+				err = msgp.WrapError(err, "Items")
 				return
 			}
-		case "o":
-			z.TxOffset, bts, err = msgp.ReadIntBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "TxOffset")
-				return
+			if cap(z.Items) >= int(zb0002) {
+				z.Items = (z.Items)[:zb0002]
+			} else {
+				z.Items = make([]UnlockValueData, zb0002)
 			}
-		case "f":
-			z.Fee, bts, err = msgp.ReadUint64Bytes(bts)
+			for za0001 := range z.Items {
+				bts, err = z.Items[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Items", za0001)
+					return
+				}
+			}
+		case "Days":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Fee")
+				err = msgp.WrapError(err, "Days")
 				return
 			}
-		case "s":
-			z.SIB, bts, err = msgp.ReadUint64Bytes(bts)
+			if cap(z.Days) >= int(zb0003) {
+				z.Days = (z.Days)[:zb0003]
+			} else {
+				z.Days = make([]UnlockDayTotal, zb0003)
+			}
+			for za0002 := range z.Days {
+				var zb0004 uint32
+				zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Days", za0002)
+					return
+				}
+				for zb0004 > 0 {
+					zb0004--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Days", za0002)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Day":
+						bts, err = z.Days[za0002].Day.UnmarshalMsg(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Days", za0002, "Day")
+							return
+						}
+					case "Accounts":
+						z.Days[za0002].Accounts, bts, err = msgp.ReadUint64Bytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Days", za0002, "Accounts")
+							return
+						}
+					case "Balance":
+						bts, err = z.Days[za0002].Balance.UnmarshalMsg(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Days", za0002, "Balance")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Days", za0002)
+							return
+						}
+					}
+				}
+			}
+		case "More":
+			z.More, bts, err = msgp.ReadBoolBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "SIB")
+				err = msgp.WrapError(err, "More")
 				return
 			}
 		default:
@@ -2263,8 +5867,16 @@ func (z *TxValueData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *TxValueData) Msgsize() (s int) {
-	s = 1 + 2 + msgp.Uint64Size + 2 + msgp.IntSize + 2 + msgp.Uint64Size + 2 + msgp.Uint64Size
+func (z *UnlocksQueryResults) Msgsize() (s int) {
+	s = 1 + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Items {
+		s += z.Items[za0001].Msgsize()
+	}
+	s += 5 + msgp.ArrayHeaderSize
+	for za0002 := range z.Days {
+		s += 1 + 4 + z.Days[za0002].Day.Msgsize() + 9 + msgp.Uint64Size + 8 + z.Days[za0002].Balance.Msgsize()
+	}
+	s += 5 + msgp.BoolSize
 	return
 }
 
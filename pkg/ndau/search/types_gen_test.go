@@ -461,6 +461,458 @@ func BenchmarkDecodeAccountTxValueData(b *testing.B) {
 	}
 }
 
+func TestMarshalUnmarshalInitialIndexCheckpoint(t *testing.T) {
+	v := InitialIndexCheckpoint{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgInitialIndexCheckpoint(b *testing.B) {
+	v := InitialIndexCheckpoint{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgInitialIndexCheckpoint(b *testing.B) {
+	v := InitialIndexCheckpoint{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalInitialIndexCheckpoint(b *testing.B) {
+	v := InitialIndexCheckpoint{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeInitialIndexCheckpoint(t *testing.T) {
+	v := InitialIndexCheckpoint{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := InitialIndexCheckpoint{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeInitialIndexCheckpoint(b *testing.B) {
+	v := InitialIndexCheckpoint{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeInitialIndexCheckpoint(b *testing.B) {
+	v := InitialIndexCheckpoint{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalNodeRewardHistoryParams(t *testing.T) {
+	v := NodeRewardHistoryParams{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgNodeRewardHistoryParams(b *testing.B) {
+	v := NodeRewardHistoryParams{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgNodeRewardHistoryParams(b *testing.B) {
+	v := NodeRewardHistoryParams{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalNodeRewardHistoryParams(b *testing.B) {
+	v := NodeRewardHistoryParams{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeNodeRewardHistoryParams(t *testing.T) {
+	v := NodeRewardHistoryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := NodeRewardHistoryParams{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeNodeRewardHistoryParams(b *testing.B) {
+	v := NodeRewardHistoryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeNodeRewardHistoryParams(b *testing.B) {
+	v := NodeRewardHistoryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalNodeRewardHistoryResponse(t *testing.T) {
+	v := NodeRewardHistoryResponse{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgNodeRewardHistoryResponse(b *testing.B) {
+	v := NodeRewardHistoryResponse{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgNodeRewardHistoryResponse(b *testing.B) {
+	v := NodeRewardHistoryResponse{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalNodeRewardHistoryResponse(b *testing.B) {
+	v := NodeRewardHistoryResponse{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeNodeRewardHistoryResponse(t *testing.T) {
+	v := NodeRewardHistoryResponse{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := NodeRewardHistoryResponse{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeNodeRewardHistoryResponse(b *testing.B) {
+	v := NodeRewardHistoryResponse{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeNodeRewardHistoryResponse(b *testing.B) {
+	v := NodeRewardHistoryResponse{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalNodeRewardValueData(t *testing.T) {
+	v := NodeRewardValueData{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgNodeRewardValueData(b *testing.B) {
+	v := NodeRewardValueData{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgNodeRewardValueData(b *testing.B) {
+	v := NodeRewardValueData{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalNodeRewardValueData(b *testing.B) {
+	v := NodeRewardValueData{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeNodeRewardValueData(t *testing.T) {
+	v := NodeRewardValueData{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := NodeRewardValueData{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeNodeRewardValueData(b *testing.B) {
+	v := NodeRewardValueData{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeNodeRewardValueData(b *testing.B) {
+	v := NodeRewardValueData{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestMarshalUnmarshalPriceQueryParams(t *testing.T) {
 	v := PriceQueryParams{}
 	bts, err := v.MarshalMsg(nil)
@@ -484,8 +936,1251 @@ func TestMarshalUnmarshalPriceQueryParams(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgPriceQueryParams(b *testing.B) {
-	v := PriceQueryParams{}
+func BenchmarkMarshalMsgPriceQueryParams(b *testing.B) {
+	v := PriceQueryParams{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgPriceQueryParams(b *testing.B) {
+	v := PriceQueryParams{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalPriceQueryParams(b *testing.B) {
+	v := PriceQueryParams{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodePriceQueryParams(t *testing.T) {
+	v := PriceQueryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := PriceQueryParams{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodePriceQueryParams(b *testing.B) {
+	v := PriceQueryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodePriceQueryParams(b *testing.B) {
+	v := PriceQueryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalPriceQueryResult(t *testing.T) {
+	v := PriceQueryResult{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgPriceQueryResult(b *testing.B) {
+	v := PriceQueryResult{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgPriceQueryResult(b *testing.B) {
+	v := PriceQueryResult{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalPriceQueryResult(b *testing.B) {
+	v := PriceQueryResult{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodePriceQueryResult(t *testing.T) {
+	v := PriceQueryResult{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := PriceQueryResult{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodePriceQueryResult(b *testing.B) {
+	v := PriceQueryResult{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodePriceQueryResult(b *testing.B) {
+	v := PriceQueryResult{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalPriceQueryResults(t *testing.T) {
+	v := PriceQueryResults{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgPriceQueryResults(b *testing.B) {
+	v := PriceQueryResults{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgPriceQueryResults(b *testing.B) {
+	v := PriceQueryResults{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalPriceQueryResults(b *testing.B) {
+	v := PriceQueryResults{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodePriceQueryResults(t *testing.T) {
+	v := PriceQueryResults{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := PriceQueryResults{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodePriceQueryResults(b *testing.B) {
+	v := PriceQueryResults{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodePriceQueryResults(b *testing.B) {
+	v := PriceQueryResults{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalQueryParams(t *testing.T) {
+	v := QueryParams{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgQueryParams(b *testing.B) {
+	v := QueryParams{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgQueryParams(b *testing.B) {
+	v := QueryParams{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalQueryParams(b *testing.B) {
+	v := QueryParams{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeQueryParams(t *testing.T) {
+	v := QueryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := QueryParams{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeQueryParams(b *testing.B) {
+	v := QueryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeQueryParams(b *testing.B) {
+	v := QueryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalRangeEndpoint(t *testing.T) {
+	v := RangeEndpoint{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgRangeEndpoint(b *testing.B) {
+	v := RangeEndpoint{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgRangeEndpoint(b *testing.B) {
+	v := RangeEndpoint{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalRangeEndpoint(b *testing.B) {
+	v := RangeEndpoint{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeRangeEndpoint(t *testing.T) {
+	v := RangeEndpoint{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := RangeEndpoint{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeRangeEndpoint(b *testing.B) {
+	v := RangeEndpoint{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeRangeEndpoint(b *testing.B) {
+	v := RangeEndpoint{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalSIBQueryResult(t *testing.T) {
+	v := SIBQueryResult{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgSIBQueryResult(b *testing.B) {
+	v := SIBQueryResult{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgSIBQueryResult(b *testing.B) {
+	v := SIBQueryResult{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalSIBQueryResult(b *testing.B) {
+	v := SIBQueryResult{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeSIBQueryResult(t *testing.T) {
+	v := SIBQueryResult{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := SIBQueryResult{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeSIBQueryResult(b *testing.B) {
+	v := SIBQueryResult{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeSIBQueryResult(b *testing.B) {
+	v := SIBQueryResult{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalSIBQueryResults(t *testing.T) {
+	v := SIBQueryResults{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgSIBQueryResults(b *testing.B) {
+	v := SIBQueryResults{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgSIBQueryResults(b *testing.B) {
+	v := SIBQueryResults{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalSIBQueryResults(b *testing.B) {
+	v := SIBQueryResults{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeSIBQueryResults(t *testing.T) {
+	v := SIBQueryResults{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := SIBQueryResults{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeSIBQueryResults(b *testing.B) {
+	v := SIBQueryResults{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeSIBQueryResults(b *testing.B) {
+	v := SIBQueryResults{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalSIBValueData(t *testing.T) {
+	v := SIBValueData{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgSIBValueData(b *testing.B) {
+	v := SIBValueData{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgSIBValueData(b *testing.B) {
+	v := SIBValueData{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalSIBValueData(b *testing.B) {
+	v := SIBValueData{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeSIBValueData(t *testing.T) {
+	v := SIBValueData{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := SIBValueData{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeSIBValueData(b *testing.B) {
+	v := SIBValueData{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeSIBValueData(b *testing.B) {
+	v := SIBValueData{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalSupplyQueryParams(t *testing.T) {
+	v := SupplyQueryParams{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgSupplyQueryParams(b *testing.B) {
+	v := SupplyQueryParams{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgSupplyQueryParams(b *testing.B) {
+	v := SupplyQueryParams{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalSupplyQueryParams(b *testing.B) {
+	v := SupplyQueryParams{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeSupplyQueryParams(t *testing.T) {
+	v := SupplyQueryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := SupplyQueryParams{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeSupplyQueryParams(b *testing.B) {
+	v := SupplyQueryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeSupplyQueryParams(b *testing.B) {
+	v := SupplyQueryParams{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalSupplyQueryResult(t *testing.T) {
+	v := SupplyQueryResult{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgSupplyQueryResult(b *testing.B) {
+	v := SupplyQueryResult{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgSupplyQueryResult(b *testing.B) {
+	v := SupplyQueryResult{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalSupplyQueryResult(b *testing.B) {
+	v := SupplyQueryResult{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeSupplyQueryResult(t *testing.T) {
+	v := SupplyQueryResult{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := SupplyQueryResult{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeSupplyQueryResult(b *testing.B) {
+	v := SupplyQueryResult{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeSupplyQueryResult(b *testing.B) {
+	v := SupplyQueryResult{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalSupplyQueryResults(t *testing.T) {
+	v := SupplyQueryResults{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgSupplyQueryResults(b *testing.B) {
+	v := SupplyQueryResults{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgSupplyQueryResults(b *testing.B) {
+	v := SupplyQueryResults{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalSupplyQueryResults(b *testing.B) {
+	v := SupplyQueryResults{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeSupplyQueryResults(t *testing.T) {
+	v := SupplyQueryResults{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
+	}
+
+	vn := SupplyQueryResults{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeSupplyQueryResults(b *testing.B) {
+	v := SupplyQueryResults{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeSupplyQueryResults(b *testing.B) {
+	v := SupplyQueryResults{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalSupplyValueData(t *testing.T) {
+	v := SupplyValueData{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgSupplyValueData(b *testing.B) {
+	v := SupplyValueData{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -493,8 +2188,8 @@ func BenchmarkMarshalMsgPriceQueryParams(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgPriceQueryParams(b *testing.B) {
-	v := PriceQueryParams{}
+func BenchmarkAppendMsgSupplyValueData(b *testing.B) {
+	v := SupplyValueData{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -505,8 +2200,8 @@ func BenchmarkAppendMsgPriceQueryParams(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalPriceQueryParams(b *testing.B) {
-	v := PriceQueryParams{}
+func BenchmarkUnmarshalSupplyValueData(b *testing.B) {
+	v := SupplyValueData{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -519,8 +2214,8 @@ func BenchmarkUnmarshalPriceQueryParams(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodePriceQueryParams(t *testing.T) {
-	v := PriceQueryParams{}
+func TestEncodeDecodeSupplyValueData(t *testing.T) {
+	v := SupplyValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
@@ -529,7 +2224,7 @@ func TestEncodeDecodePriceQueryParams(t *testing.T) {
 		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
 	}
 
-	vn := PriceQueryParams{}
+	vn := SupplyValueData{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -543,8 +2238,8 @@ func TestEncodeDecodePriceQueryParams(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodePriceQueryParams(b *testing.B) {
-	v := PriceQueryParams{}
+func BenchmarkEncodeSupplyValueData(b *testing.B) {
+	v := SupplyValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -557,8 +2252,8 @@ func BenchmarkEncodePriceQueryParams(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodePriceQueryParams(b *testing.B) {
-	v := PriceQueryParams{}
+func BenchmarkDecodeSupplyValueData(b *testing.B) {
+	v := SupplyValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -574,8 +2269,8 @@ func BenchmarkDecodePriceQueryParams(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalPriceQueryResult(t *testing.T) {
-	v := PriceQueryResult{}
+func TestMarshalUnmarshalSysvarHistoryParams(t *testing.T) {
+	v := SysvarHistoryParams{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -597,8 +2292,8 @@ func TestMarshalUnmarshalPriceQueryResult(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgPriceQueryResult(b *testing.B) {
-	v := PriceQueryResult{}
+func BenchmarkMarshalMsgSysvarHistoryParams(b *testing.B) {
+	v := SysvarHistoryParams{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -606,8 +2301,8 @@ func BenchmarkMarshalMsgPriceQueryResult(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgPriceQueryResult(b *testing.B) {
-	v := PriceQueryResult{}
+func BenchmarkAppendMsgSysvarHistoryParams(b *testing.B) {
+	v := SysvarHistoryParams{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -618,8 +2313,8 @@ func BenchmarkAppendMsgPriceQueryResult(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalPriceQueryResult(b *testing.B) {
-	v := PriceQueryResult{}
+func BenchmarkUnmarshalSysvarHistoryParams(b *testing.B) {
+	v := SysvarHistoryParams{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -632,8 +2327,8 @@ func BenchmarkUnmarshalPriceQueryResult(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodePriceQueryResult(t *testing.T) {
-	v := PriceQueryResult{}
+func TestEncodeDecodeSysvarHistoryParams(t *testing.T) {
+	v := SysvarHistoryParams{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
@@ -642,7 +2337,7 @@ func TestEncodeDecodePriceQueryResult(t *testing.T) {
 		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
 	}
 
-	vn := PriceQueryResult{}
+	vn := SysvarHistoryParams{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -656,8 +2351,8 @@ func TestEncodeDecodePriceQueryResult(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodePriceQueryResult(b *testing.B) {
-	v := PriceQueryResult{}
+func BenchmarkEncodeSysvarHistoryParams(b *testing.B) {
+	v := SysvarHistoryParams{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -670,8 +2365,8 @@ func BenchmarkEncodePriceQueryResult(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodePriceQueryResult(b *testing.B) {
-	v := PriceQueryResult{}
+func BenchmarkDecodeSysvarHistoryParams(b *testing.B) {
+	v := SysvarHistoryParams{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -687,8 +2382,8 @@ func BenchmarkDecodePriceQueryResult(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalPriceQueryResults(t *testing.T) {
-	v := PriceQueryResults{}
+func TestMarshalUnmarshalTxListValueData(t *testing.T) {
+	v := TxListValueData{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -710,8 +2405,8 @@ func TestMarshalUnmarshalPriceQueryResults(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgPriceQueryResults(b *testing.B) {
-	v := PriceQueryResults{}
+func BenchmarkMarshalMsgTxListValueData(b *testing.B) {
+	v := TxListValueData{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -719,8 +2414,8 @@ func BenchmarkMarshalMsgPriceQueryResults(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgPriceQueryResults(b *testing.B) {
-	v := PriceQueryResults{}
+func BenchmarkAppendMsgTxListValueData(b *testing.B) {
+	v := TxListValueData{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -731,8 +2426,8 @@ func BenchmarkAppendMsgPriceQueryResults(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalPriceQueryResults(b *testing.B) {
-	v := PriceQueryResults{}
+func BenchmarkUnmarshalTxListValueData(b *testing.B) {
+	v := TxListValueData{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -745,8 +2440,8 @@ func BenchmarkUnmarshalPriceQueryResults(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodePriceQueryResults(t *testing.T) {
-	v := PriceQueryResults{}
+func TestEncodeDecodeTxListValueData(t *testing.T) {
+	v := TxListValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
@@ -755,7 +2450,7 @@ func TestEncodeDecodePriceQueryResults(t *testing.T) {
 		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
 	}
 
-	vn := PriceQueryResults{}
+	vn := TxListValueData{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -769,8 +2464,8 @@ func TestEncodeDecodePriceQueryResults(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodePriceQueryResults(b *testing.B) {
-	v := PriceQueryResults{}
+func BenchmarkEncodeTxListValueData(b *testing.B) {
+	v := TxListValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -783,8 +2478,8 @@ func BenchmarkEncodePriceQueryResults(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodePriceQueryResults(b *testing.B) {
-	v := PriceQueryResults{}
+func BenchmarkDecodeTxListValueData(b *testing.B) {
+	v := TxListValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -800,8 +2495,8 @@ func BenchmarkDecodePriceQueryResults(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalQueryParams(t *testing.T) {
-	v := QueryParams{}
+func TestMarshalUnmarshalTxValueData(t *testing.T) {
+	v := TxValueData{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -823,8 +2518,8 @@ func TestMarshalUnmarshalQueryParams(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgQueryParams(b *testing.B) {
-	v := QueryParams{}
+func BenchmarkMarshalMsgTxValueData(b *testing.B) {
+	v := TxValueData{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -832,8 +2527,8 @@ func BenchmarkMarshalMsgQueryParams(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgQueryParams(b *testing.B) {
-	v := QueryParams{}
+func BenchmarkAppendMsgTxValueData(b *testing.B) {
+	v := TxValueData{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -844,8 +2539,8 @@ func BenchmarkAppendMsgQueryParams(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalQueryParams(b *testing.B) {
-	v := QueryParams{}
+func BenchmarkUnmarshalTxValueData(b *testing.B) {
+	v := TxValueData{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -858,8 +2553,8 @@ func BenchmarkUnmarshalQueryParams(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeQueryParams(t *testing.T) {
-	v := QueryParams{}
+func TestEncodeDecodeTxValueData(t *testing.T) {
+	v := TxValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
@@ -868,7 +2563,7 @@ func TestEncodeDecodeQueryParams(t *testing.T) {
 		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
 	}
 
-	vn := QueryParams{}
+	vn := TxValueData{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -882,8 +2577,8 @@ func TestEncodeDecodeQueryParams(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeQueryParams(b *testing.B) {
-	v := QueryParams{}
+func BenchmarkEncodeTxValueData(b *testing.B) {
+	v := TxValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -896,8 +2591,8 @@ func BenchmarkEncodeQueryParams(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeQueryParams(b *testing.B) {
-	v := QueryParams{}
+func BenchmarkDecodeTxValueData(b *testing.B) {
+	v := TxValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -913,8 +2608,8 @@ func BenchmarkDecodeQueryParams(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalRangeEndpoint(t *testing.T) {
-	v := RangeEndpoint{}
+func TestMarshalUnmarshalUnlockDayTotal(t *testing.T) {
+	v := UnlockDayTotal{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -936,8 +2631,8 @@ func TestMarshalUnmarshalRangeEndpoint(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgRangeEndpoint(b *testing.B) {
-	v := RangeEndpoint{}
+func BenchmarkMarshalMsgUnlockDayTotal(b *testing.B) {
+	v := UnlockDayTotal{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -945,8 +2640,8 @@ func BenchmarkMarshalMsgRangeEndpoint(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgRangeEndpoint(b *testing.B) {
-	v := RangeEndpoint{}
+func BenchmarkAppendMsgUnlockDayTotal(b *testing.B) {
+	v := UnlockDayTotal{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -957,8 +2652,8 @@ func BenchmarkAppendMsgRangeEndpoint(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalRangeEndpoint(b *testing.B) {
-	v := RangeEndpoint{}
+func BenchmarkUnmarshalUnlockDayTotal(b *testing.B) {
+	v := UnlockDayTotal{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -971,8 +2666,8 @@ func BenchmarkUnmarshalRangeEndpoint(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeRangeEndpoint(t *testing.T) {
-	v := RangeEndpoint{}
+func TestEncodeDecodeUnlockDayTotal(t *testing.T) {
+	v := UnlockDayTotal{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
@@ -981,7 +2676,7 @@ func TestEncodeDecodeRangeEndpoint(t *testing.T) {
 		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
 	}
 
-	vn := RangeEndpoint{}
+	vn := UnlockDayTotal{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -995,8 +2690,8 @@ func TestEncodeDecodeRangeEndpoint(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeRangeEndpoint(b *testing.B) {
-	v := RangeEndpoint{}
+func BenchmarkEncodeUnlockDayTotal(b *testing.B) {
+	v := UnlockDayTotal{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1009,8 +2704,8 @@ func BenchmarkEncodeRangeEndpoint(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeRangeEndpoint(b *testing.B) {
-	v := RangeEndpoint{}
+func BenchmarkDecodeUnlockDayTotal(b *testing.B) {
+	v := UnlockDayTotal{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1026,8 +2721,8 @@ func BenchmarkDecodeRangeEndpoint(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalSysvarHistoryParams(t *testing.T) {
-	v := SysvarHistoryParams{}
+func TestMarshalUnmarshalUnlockValueData(t *testing.T) {
+	v := UnlockValueData{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -1049,8 +2744,8 @@ func TestMarshalUnmarshalSysvarHistoryParams(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgSysvarHistoryParams(b *testing.B) {
-	v := SysvarHistoryParams{}
+func BenchmarkMarshalMsgUnlockValueData(b *testing.B) {
+	v := UnlockValueData{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -1058,8 +2753,8 @@ func BenchmarkMarshalMsgSysvarHistoryParams(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgSysvarHistoryParams(b *testing.B) {
-	v := SysvarHistoryParams{}
+func BenchmarkAppendMsgUnlockValueData(b *testing.B) {
+	v := UnlockValueData{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -1070,8 +2765,8 @@ func BenchmarkAppendMsgSysvarHistoryParams(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalSysvarHistoryParams(b *testing.B) {
-	v := SysvarHistoryParams{}
+func BenchmarkUnmarshalUnlockValueData(b *testing.B) {
+	v := UnlockValueData{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -1084,8 +2779,8 @@ func BenchmarkUnmarshalSysvarHistoryParams(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeSysvarHistoryParams(t *testing.T) {
-	v := SysvarHistoryParams{}
+func TestEncodeDecodeUnlockValueData(t *testing.T) {
+	v := UnlockValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
@@ -1094,7 +2789,7 @@ func TestEncodeDecodeSysvarHistoryParams(t *testing.T) {
 		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
 	}
 
-	vn := SysvarHistoryParams{}
+	vn := UnlockValueData{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -1108,8 +2803,8 @@ func TestEncodeDecodeSysvarHistoryParams(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeSysvarHistoryParams(b *testing.B) {
-	v := SysvarHistoryParams{}
+func BenchmarkEncodeUnlockValueData(b *testing.B) {
+	v := UnlockValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1122,8 +2817,8 @@ func BenchmarkEncodeSysvarHistoryParams(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeSysvarHistoryParams(b *testing.B) {
-	v := SysvarHistoryParams{}
+func BenchmarkDecodeUnlockValueData(b *testing.B) {
+	v := UnlockValueData{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1139,8 +2834,8 @@ func BenchmarkDecodeSysvarHistoryParams(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalTxListValueData(t *testing.T) {
-	v := TxListValueData{}
+func TestMarshalUnmarshalUnlocksQueryParams(t *testing.T) {
+	v := UnlocksQueryParams{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -1162,8 +2857,8 @@ func TestMarshalUnmarshalTxListValueData(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgTxListValueData(b *testing.B) {
-	v := TxListValueData{}
+func BenchmarkMarshalMsgUnlocksQueryParams(b *testing.B) {
+	v := UnlocksQueryParams{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -1171,8 +2866,8 @@ func BenchmarkMarshalMsgTxListValueData(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgTxListValueData(b *testing.B) {
-	v := TxListValueData{}
+func BenchmarkAppendMsgUnlocksQueryParams(b *testing.B) {
+	v := UnlocksQueryParams{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -1183,8 +2878,8 @@ func BenchmarkAppendMsgTxListValueData(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalTxListValueData(b *testing.B) {
-	v := TxListValueData{}
+func BenchmarkUnmarshalUnlocksQueryParams(b *testing.B) {
+	v := UnlocksQueryParams{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -1197,8 +2892,8 @@ func BenchmarkUnmarshalTxListValueData(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeTxListValueData(t *testing.T) {
-	v := TxListValueData{}
+func TestEncodeDecodeUnlocksQueryParams(t *testing.T) {
+	v := UnlocksQueryParams{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
@@ -1207,7 +2902,7 @@ func TestEncodeDecodeTxListValueData(t *testing.T) {
 		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
 	}
 
-	vn := TxListValueData{}
+	vn := UnlocksQueryParams{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -1221,8 +2916,8 @@ func TestEncodeDecodeTxListValueData(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeTxListValueData(b *testing.B) {
-	v := TxListValueData{}
+func BenchmarkEncodeUnlocksQueryParams(b *testing.B) {
+	v := UnlocksQueryParams{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1235,8 +2930,8 @@ func BenchmarkEncodeTxListValueData(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeTxListValueData(b *testing.B) {
-	v := TxListValueData{}
+func BenchmarkDecodeUnlocksQueryParams(b *testing.B) {
+	v := UnlocksQueryParams{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1252,8 +2947,8 @@ func BenchmarkDecodeTxListValueData(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalTxValueData(t *testing.T) {
-	v := TxValueData{}
+func TestMarshalUnmarshalUnlocksQueryResults(t *testing.T) {
+	v := UnlocksQueryResults{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -1275,8 +2970,8 @@ func TestMarshalUnmarshalTxValueData(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgTxValueData(b *testing.B) {
-	v := TxValueData{}
+func BenchmarkMarshalMsgUnlocksQueryResults(b *testing.B) {
+	v := UnlocksQueryResults{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -1284,8 +2979,8 @@ func BenchmarkMarshalMsgTxValueData(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgTxValueData(b *testing.B) {
-	v := TxValueData{}
+func BenchmarkAppendMsgUnlocksQueryResults(b *testing.B) {
+	v := UnlocksQueryResults{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -1296,8 +2991,8 @@ func BenchmarkAppendMsgTxValueData(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalTxValueData(b *testing.B) {
-	v := TxValueData{}
+func BenchmarkUnmarshalUnlocksQueryResults(b *testing.B) {
+	v := UnlocksQueryResults{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -1310,8 +3005,8 @@ func BenchmarkUnmarshalTxValueData(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeTxValueData(t *testing.T) {
-	v := TxValueData{}
+func TestEncodeDecodeUnlocksQueryResults(t *testing.T) {
+	v := UnlocksQueryResults{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
@@ -1320,7 +3015,7 @@ func TestEncodeDecodeTxValueData(t *testing.T) {
 		t.Logf("WARNING: Msgsize() for %v is inaccurate", v)
 	}
 
-	vn := TxValueData{}
+	vn := UnlocksQueryResults{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -1334,8 +3029,8 @@ func TestEncodeDecodeTxValueData(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeTxValueData(b *testing.B) {
-	v := TxValueData{}
+func BenchmarkEncodeUnlocksQueryResults(b *testing.B) {
+	v := UnlocksQueryResults{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1348,8 +3043,8 @@ func BenchmarkEncodeTxValueData(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeTxValueData(b *testing.B) {
-	v := TxValueData{}
+func BenchmarkDecodeUnlocksQueryResults(b *testing.B) {
+	v := UnlocksQueryResults{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
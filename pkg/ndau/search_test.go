@@ -21,6 +21,7 @@ import (
 	metatx "github.com/ndau/metanode/pkg/meta/transaction"
 	"github.com/ndau/ndau/pkg/ndau/backing"
 	srch "github.com/ndau/ndau/pkg/ndau/search"
+	"github.com/ndau/ndau/pkg/query"
 	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/ndau/ndaumath/pkg/constants"
 	"github.com/ndau/ndaumath/pkg/pricecurve"
@@ -28,6 +29,7 @@ import (
 	math "github.com/ndau/ndaumath/pkg/types"
 	sv "github.com/ndau/system_vars/pkg/system_vars"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 func withRedis(t *testing.T, test func(port string)) {
@@ -131,6 +133,38 @@ func TestIndex(t *testing.T) {
 			require.GreaterOrEqual(t, insertCount, numSysvars)
 		})
 
+		// Test initial indexing again from scratch, this time in the background with workers.
+		t.Run("TestBackgroundInitialIndexing", func(t *testing.T) {
+			err := search.FlushDB()
+			require.NoError(t, err)
+
+			search.ConfigureInitialIndexing(4, 1)
+			defer search.ConfigureInitialIndexing(1, 1000)
+
+			type result struct {
+				insertCount int
+				err         error
+			}
+			done := make(chan result, 1)
+			search.IndexBlockchainInBackground(
+				app.GetDB(), app.GetDS(),
+				func(_, insertCount int, err error) {
+					done <- result{insertCount, err}
+				},
+			)
+			r := <-done
+			require.NoError(t, r.err)
+			require.False(t, search.IndexBehind())
+
+			state := app.GetState().(*backing.State)
+			require.GreaterOrEqual(t, r.insertCount, len(state.Sysvars))
+
+			// The checkpoint is cleared once indexing completes.
+			checkpoint, err := search.Get("initial.index.checkpoint")
+			require.NoError(t, err)
+			require.Empty(t, checkpoint)
+		})
+
 		// Deliver some transactions, which should trigger incremental indexing
 		privateKeys := assc[sysvarKeys].([]signature.PrivateKey)
 		ssv := NewSetSysvar(
@@ -253,6 +287,52 @@ func TestIndex(t *testing.T) {
 			})
 		})
 
+		t.Run("TestResumeInitialIndexingPastHead", func(t *testing.T) {
+			// setup: an interrupted crawl which began before the block we just committed
+			checkpoint := srch.InitialIndexCheckpoint{
+				NextHeight: height,
+				MinHeight:  1,
+			}
+			bytes, err := checkpoint.MarshalMsg(nil)
+			require.NoError(t, err)
+			err = search.Set("initial.index.checkpoint", string(bytes))
+			require.NoError(t, err)
+
+			// state change: resume the crawl from a head which has moved past it
+			_, _, err = search.IndexBlockchain(app.GetDB(), app.GetDS())
+			require.NoError(t, err)
+
+			// postcondition: the crawl completed without going backward
+			saved, err := search.Get("initial.index.checkpoint")
+			require.NoError(t, err)
+			require.Empty(t, saved)
+			require.Equal(t, height+1, search.GetNextHeight())
+		})
+
+		t.Run("TestTxScoreMigration", func(t *testing.T) {
+			// setup: rewind the RFE tx to the legacy float score and index format
+			rfeKey := "tx.type:height:releasefromendowment"
+			_, err := search.Client.ZAdd(rfeKey, float64(height)+float64(txOffsetRFE)/1000, txHashRFE)
+			require.NoError(t, err)
+			err = search.Client.Inner().Del("index.format").Err()
+			require.NoError(t, err)
+
+			// state change: a new client migrates the index
+			_, err = srch.NewClient("localhost:"+port, 0, app)
+			require.NoError(t, err)
+
+			// postcondition: the tx has its exact score, and searching still works
+			score, err := search.Client.Inner().ZScore(rfeKey, txHashRFE).Result()
+			require.NoError(t, err)
+			require.Equal(t, float64(height<<20|uint64(txOffsetRFE)), score)
+
+			vd, err := search.SearchTxTypes("", []string{"ReleaseFromEndowment", "SetSysvar"}, 1)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(vd.Txs))
+			require.Equal(t, txHashSSV, vd.NextTxHash)
+			require.Equal(t, txOffsetRFE, vd.Txs[0].TxOffset)
+		})
+
 		t.Run("TestMostRecentRegisterNode", func(t *testing.T) {
 			// precondition: this node has never been registered
 			txData, err := search.SearchMostRecentRegisterNode(targetAddress.String())
@@ -524,5 +604,409 @@ func TestIndex(t *testing.T) {
 				require.Equal(t, pairs[1].tgt, priceResult.Items[idx].Price)
 			})
 		})
+
+		t.Run("TestSupplySearch", func(t *testing.T) {
+			// setup: generate but do not yet send some RFE txs
+			rfeAddr := address.Address{}
+			err := app.System(sv.ReleaseFromEndowmentAddressName, &rfeAddr)
+			require.NoError(t, err)
+			privateKeys = assc[rfeKeys].([]signature.PrivateKey)
+			modify(t, rfeAddr.String(), app, func(ad *backing.AccountData) {
+				ad.Sequence = 50
+			})
+
+			type pair struct {
+				ts     math.Timestamp
+				tx     metatx.Transactable
+				supply srch.SupplyValueData
+			}
+
+			qty := math.Ndau(100 * constants.NapuPerNdau)
+			pairs := []pair{
+				{ts: 3*math.Year + 1*math.Month + 1*math.Day, tx: NewReleaseFromEndowment(targetAddress, qty, 51, privateKeys...)},
+				{ts: 3*math.Year + 2*math.Month + 1*math.Day, tx: NewReleaseFromEndowment(targetAddress, qty, 52, privateKeys...)},
+			}
+
+			search.Client.FlushDB()
+
+			// precondition: search does not know about any supply data
+			supplyResult, err := search.SearchSupply(srch.SupplyQueryParams{})
+			require.NoError(t, err)
+			require.Empty(t, supplyResult.Items)
+			require.False(t, supplyResult.More, "must not have unreturned items")
+
+			// state change: deliver the RFE txs
+			for idx, pair := range pairs {
+				resp, _ := deliverTxContext(t, app, pair.tx, ddc(t).at(pair.ts).atHeight(300+(uint64(idx)*15)))
+				require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+				pairs[idx].supply, err = app.GetSupply()
+				require.NoError(t, err)
+			}
+			require.Equal(t, pairs[0].supply.TotalRFE+qty, pairs[1].supply.TotalRFE)
+
+			// postcondition: search can find the supply data
+			t.Run("Unlimited", func(t *testing.T) {
+				t.Parallel()
+				supplyResult, err := search.SearchSupply(srch.SupplyQueryParams{})
+				require.NoError(t, err)
+				require.Equal(t, len(pairs), len(supplyResult.Items))
+				require.False(t, supplyResult.More, "must not have unreturned items")
+				for idx := range pairs {
+					require.Equal(t, pairs[idx].ts, supplyResult.Items[idx].Timestamp)
+					require.Equal(t, pairs[idx].supply, supplyResult.Items[idx].SupplyValueData)
+					require.Equal(t, 300+(uint64(idx)*15), supplyResult.Items[idx].Height)
+				}
+			})
+			t.Run("Limited", func(t *testing.T) {
+				t.Parallel()
+				supplyResult, err := search.SearchSupply(srch.SupplyQueryParams{
+					Limit: 1,
+				})
+				require.NoError(t, err)
+				require.Equal(t, 1, len(supplyResult.Items))
+				require.True(t, supplyResult.More, "must have unreturned items")
+				require.Equal(t, pairs[0].supply, supplyResult.Items[0].SupplyValueData)
+				// get subsequent results by height
+				supplyResult, err = search.SearchSupply(srch.SupplyQueryParams{
+					After: srch.RangeEndpoint{Height: supplyResult.Items[0].Height},
+				})
+				require.NoError(t, err)
+				require.Equal(t, 1, len(supplyResult.Items))
+				require.False(t, supplyResult.More, "must not have unreturned items")
+				require.Equal(t, pairs[1].supply, supplyResult.Items[0].SupplyValueData)
+			})
+		})
+
+		t.Run("TestSIBAndNAVSearch", func(t *testing.T) {
+			// setup: generate but do not yet send some RecordEndowmentNAV txs
+			renavAddr := address.Address{}
+			err := app.System(sv.RecordEndowmentNAVAddressName, &renavAddr)
+			require.NoError(t, err)
+			renavPvt, err := MockSystemAccount(app, renavAddr)
+			require.NoError(t, err)
+			modify(t, renavAddr.String(), app, func(ad *backing.AccountData) {
+				ad.Sequence = 50
+			})
+
+			type pair struct {
+				ts     math.Timestamp
+				tx     *RecordEndowmentNAV
+				supply srch.SupplyValueData
+			}
+
+			pairs := []pair{
+				{ts: 4*math.Year + 1*math.Month + 1*math.Day, tx: NewRecordEndowmentNAV(20*1000*pricecurve.Dollar, 51, renavPvt...)},
+				{ts: 4*math.Year + 2*math.Month + 1*math.Day, tx: NewRecordEndowmentNAV(30*1000*pricecurve.Dollar, 52, renavPvt...)},
+			}
+
+			search.Client.FlushDB()
+
+			// precondition: search does not know about any nav or sib data
+			navResult, err := search.SearchEndowmentNAV(srch.PriceQueryParams{})
+			require.NoError(t, err)
+			require.Empty(t, navResult.Items)
+			sibResult, err := search.SearchSIB(srch.PriceQueryParams{})
+			require.NoError(t, err)
+			require.Empty(t, sibResult.Items)
+
+			// state change: deliver the RecordEndowmentNAV txs
+			for idx, pair := range pairs {
+				resp, _ := deliverTxContext(t, app, pair.tx, ddc(t).at(pair.ts).atHeight(400+(uint64(idx)*15)))
+				require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+				pairs[idx].supply, err = app.GetSupply()
+				require.NoError(t, err)
+			}
+
+			// postcondition: search can find the nav data
+			navResult, err = search.SearchEndowmentNAV(srch.PriceQueryParams{})
+			require.NoError(t, err)
+			require.Equal(t, len(pairs), len(navResult.Items))
+			require.False(t, navResult.More, "must not have unreturned items")
+			for idx := range pairs {
+				require.Equal(t, pairs[idx].ts, navResult.Items[idx].Timestamp)
+				require.Equal(t, pairs[idx].tx.NAV, navResult.Items[idx].Price)
+			}
+
+			// postcondition: search can find the sib data, whose floor price tracks the nav
+			sibResult, err = search.SearchSIB(srch.PriceQueryParams{})
+			require.NoError(t, err)
+			require.Equal(t, len(pairs), len(sibResult.Items))
+			require.False(t, sibResult.More, "must not have unreturned items")
+			for idx := range pairs {
+				require.Equal(t, pairs[idx].ts, sibResult.Items[idx].Timestamp)
+				require.Equal(t, pairs[idx].supply.SIB, sibResult.Items[idx].SIB)
+				require.Equal(t, pairs[idx].supply.FloorPrice, sibResult.Items[idx].FloorPrice)
+			}
+			require.True(t, sibResult.Items[0].FloorPrice < sibResult.Items[1].FloorPrice)
+		})
+
+		t.Run("TestNodeRewardSearch", func(t *testing.T) {
+			// setup: make a single self-staked node so that it must win the nomination
+			nodePublic, nodePrivate, err := signature.Generate(signature.Ed25519, nil)
+			require.NoError(t, err)
+			node, err := address.Generate(address.KindNdau, nodePublic.KeyBytes())
+			require.NoError(t, err)
+			rulesAcct, _ := getRulesAccount(t, app)
+
+			app.UpdateStateImmediately(func(stI metast.State) (metast.State, error) {
+				state := stI.(*backing.State)
+				state.LastNodeRewardNomination = math.Timestamp(0)
+				state.PendingNodeReward = 100 * constants.NapuPerNdau
+				state.Nodes = map[string]backing.Node{
+					node.String(): {Active: true},
+				}
+				state.Accounts[node.String()] = backing.AccountData{
+					Balance:        1000 * constants.NapuPerNdau,
+					ValidationKeys: []signature.PublicKey{nodePublic},
+				}
+				return app.Stake(1000*constants.NapuPerNdau, node, rulesAcct, rulesAcct, nil)(state)
+			})
+
+			nnrAddr := address.Address{}
+			err = app.System(sv.NominateNodeRewardAddressName, &nnrAddr)
+			require.NoError(t, err)
+			nnrPvt, err := MockSystemAccount(app, nnrAddr)
+			require.NoError(t, err)
+
+			search.Client.FlushDB()
+
+			// precondition: search does not know about any node rewards
+			nrhr, err := search.SearchNodeRewardHistory(node.String(), 0, 0)
+			require.NoError(t, err)
+			require.Empty(t, nrhr.Rewards)
+
+			// state change: nominate, then claim in a later block
+			nnrTs := math.Timestamp(5 * math.Year)
+			nnr := NewNominateNodeReward(1234, 1, nnrPvt...)
+			resp, _ := deliverTxContext(t, app, nnr, ddc(t).at(nnrTs).atHeight(500))
+			require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+
+			nrhr, err = search.SearchNodeRewardHistory(node.String(), 0, 0)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(nrhr.Rewards))
+			require.False(t, nrhr.More, "must not have unreturned items")
+			reward := nrhr.Rewards[0]
+			require.Equal(t, uint64(500), reward.Height)
+			require.Equal(t, nnrTs, reward.Timestamp)
+			require.Equal(t, int64(1234), reward.Random)
+			require.Equal(t, node.String(), reward.Winner)
+			require.Equal(t, math.Ndau(100*constants.NapuPerNdau), reward.Amount)
+			require.False(t, reward.Claimed)
+
+			cnrTs := nnrTs + 1
+			cnr := NewClaimNodeReward(node, 1, nodePrivate)
+			resp, _ = deliverTxContext(t, app, cnr, ddc(t).at(cnrTs).atHeight(501))
+			require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+
+			// postcondition: search knows about the claim
+			nrhr, err = search.SearchNodeRewardHistory(node.String(), 0, 0)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(nrhr.Rewards))
+			reward = nrhr.Rewards[0]
+			require.Equal(t, uint64(500), reward.Height)
+			require.True(t, reward.Claimed)
+			require.Equal(t, uint64(501), reward.ClaimHeight)
+			require.Equal(t, cnrTs, reward.ClaimTimestamp)
+			require.Equal(t, metatx.Hash(cnr), reward.ClaimTxHash)
+
+			// postcondition: paging past the nomination finds nothing
+			nrhr, err = search.SearchNodeRewardHistory(node.String(), 500, 0)
+			require.NoError(t, err)
+			require.Empty(t, nrhr.Rewards)
+		})
+
+		t.Run("TestUnlocksSearch", func(t *testing.T) {
+			// setup: make a couple of accounts we can lock
+			type acct struct {
+				addr address.Address
+				pvt  []signature.PrivateKey
+				bal  math.Ndau
+			}
+			accts := make([]acct, 2)
+			for idx := range accts {
+				public, _, err := signature.Generate(signature.Ed25519, nil)
+				require.NoError(t, err)
+				accts[idx].addr, err = address.Generate(address.KindUser, public.KeyBytes())
+				require.NoError(t, err)
+				accts[idx].pvt, err = MockSystemAccount(app, accts[idx].addr)
+				require.NoError(t, err)
+				accts[idx].bal = math.Ndau(idx+1) * 1000 * constants.NapuPerNdau
+				modify(t, accts[idx].addr.String(), app, func(ad *backing.AccountData) {
+					ad.Balance = accts[idx].bal
+				})
+			}
+
+			search.Client.FlushDB()
+
+			// precondition: search does not know about any unlocks
+			unlocks, err := search.SearchUnlocks(srch.UnlocksQueryParams{})
+			require.NoError(t, err)
+			require.Empty(t, unlocks.Items)
+			require.Empty(t, unlocks.Days)
+
+			// state change: lock both accounts, then notify both in the same block
+			lockTs := math.Timestamp(6 * math.Year)
+			locks := make([]metatx.Transactable, 0, len(accts))
+			notifies := make([]metatx.Transactable, 0, len(accts))
+			for _, a := range accts {
+				locks = append(locks, NewLock(a.addr, 30*math.Day, 1, a.pvt...))
+				notifies = append(notifies, NewNotify(a.addr, 2, a.pvt...))
+			}
+			resps, _ := deliverTxsContext(t, app, locks, ddc(t).at(lockTs).atHeight(600))
+			for _, resp := range resps {
+				require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+			}
+
+			// locks which haven't been notified have no unlock time
+			unlocks, err = search.SearchUnlocks(srch.UnlocksQueryParams{})
+			require.NoError(t, err)
+			require.Empty(t, unlocks.Items)
+
+			notifyTs := lockTs + math.Timestamp(math.Day)
+			resps, _ = deliverTxsContext(t, app, notifies, ddc(t).at(notifyTs).atHeight(601))
+			for _, resp := range resps {
+				require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+			}
+			unlocksOn := notifyTs.Add(30 * math.Day)
+
+			// postcondition: search finds both unlocks, with a single daily total
+			unlocks, err = search.SearchUnlocks(srch.UnlocksQueryParams{})
+			require.NoError(t, err)
+			require.Equal(t, len(accts), len(unlocks.Items))
+			require.False(t, unlocks.More, "must not have unreturned items")
+			for _, unlock := range unlocks.Items {
+				require.Equal(t, unlocksOn, unlock.UnlocksOn)
+				require.Equal(t, uint64(601), unlock.Height)
+			}
+			require.Equal(t, 1, len(unlocks.Days))
+			require.Equal(t, uint64(len(accts)), unlocks.Days[0].Accounts)
+			require.Equal(t, accts[0].bal+accts[1].bal, unlocks.Days[0].Balance)
+
+			// paging doesn't affect the daily totals
+			unlocks, err = search.SearchUnlocks(srch.UnlocksQueryParams{Limit: 1})
+			require.NoError(t, err)
+			require.Equal(t, 1, len(unlocks.Items))
+			require.True(t, unlocks.More, "must have unreturned items")
+			require.Equal(t, uint64(len(accts)), unlocks.Days[0].Accounts)
+
+			// the range excludes unlocks outside it
+			unlocks, err = search.SearchUnlocks(srch.UnlocksQueryParams{
+				After: srch.RangeEndpoint{Timestamp: unlocksOn},
+			})
+			require.NoError(t, err)
+			require.Empty(t, unlocks.Items)
+
+			// state change: relocking an account cancels its unlock
+			relock := NewLock(accts[0].addr, 60*math.Day, 3, accts[0].pvt...)
+			resp, _ := deliverTxContext(t, app, relock, ddc(t).at(notifyTs+1).atHeight(602))
+			require.Equal(t, code.OK, code.ReturnCode(resp.Code))
+
+			unlocks, err = search.SearchUnlocks(srch.UnlocksQueryParams{})
+			require.NoError(t, err)
+			require.Equal(t, 1, len(unlocks.Items))
+			require.Equal(t, accts[1].addr.String(), unlocks.Items[0].Address)
+			require.Equal(t, accts[1].bal, unlocks.Items[0].Balance)
+			require.Equal(t, 1, len(unlocks.Days))
+			require.Equal(t, uint64(1), unlocks.Days[0].Accounts)
+			require.Equal(t, accts[1].bal, unlocks.Days[0].Balance)
+
+			// a range which cuts into a day only totals the unlocks within it
+			unlocks, err = search.SearchUnlocks(srch.UnlocksQueryParams{
+				Before: srch.RangeEndpoint{Timestamp: unlocksOn},
+			})
+			require.NoError(t, err)
+			require.Empty(t, unlocks.Items)
+			require.Empty(t, unlocks.Days)
+
+			// state change: time passes the unlock
+			deliverTxsContext(t, app, nil, ddc(t).at(unlocksOn+1).atHeight(603))
+
+			queryUnlocks := func(params srch.UnlocksQueryParams) srch.UnlocksQueryResults {
+				data, err := params.MarshalMsg(nil)
+				require.NoError(t, err)
+				resp := app.Query(abci.RequestQuery{
+					Path: query.UnlocksEndpoint,
+					Data: data,
+				})
+				require.Equal(t, code.OK, code.ReturnCode(resp.Code), resp.Log)
+				var results srch.UnlocksQueryResults
+				_, err = results.UnmarshalMsg(resp.Value)
+				require.NoError(t, err)
+				return results
+			}
+
+			// postcondition: the query hides past unlocks unless asked for them
+			unlocks = queryUnlocks(srch.UnlocksQueryParams{})
+			require.Empty(t, unlocks.Items)
+			require.Empty(t, unlocks.Days)
+
+			unlocks = queryUnlocks(srch.UnlocksQueryParams{
+				After: srch.RangeEndpoint{Timestamp: 1},
+			})
+			require.Equal(t, 1, len(unlocks.Items))
+			require.Equal(t, accts[1].addr.String(), unlocks.Items[0].Address)
+			require.Equal(t, 1, len(unlocks.Days))
+			require.Equal(t, accts[1].bal, unlocks.Days[0].Balance)
+
+			// state change: a new client rebuilds the daily totals of an older index
+			err = search.Client.Set("index.format", "1")
+			require.NoError(t, err)
+			_, err = srch.NewClient("localhost:"+port, 0, app)
+			require.NoError(t, err)
+
+			// postcondition: the totals are unchanged
+			unlocks, err = search.SearchUnlocks(srch.UnlocksQueryParams{})
+			require.NoError(t, err)
+			require.Equal(t, 1, len(unlocks.Days))
+			require.Equal(t, uint64(1), unlocks.Days[0].Accounts)
+			require.Equal(t, accts[1].bal, unlocks.Days[0].Balance)
+		})
+
+		t.Run("TestInitialIndexingUnlocks", func(t *testing.T) {
+			// setup: one account whose unlock is still to come, one whose unlock has passed
+			blockTs := math.Timestamp(7 * math.Year)
+			notify := func(period math.Duration, at math.Timestamp) address.Address {
+				public, _, err := signature.Generate(signature.Ed25519, nil)
+				require.NoError(t, err)
+				addr, err := address.Generate(address.KindUser, public.KeyBytes())
+				require.NoError(t, err)
+				modify(t, addr.String(), app, func(ad *backing.AccountData) {
+					ad.Balance = 1000 * constants.NapuPerNdau
+					ad.Lock = backing.NewLock(period, nil)
+					require.NoError(t, ad.Lock.Notify(at, 0))
+				})
+				return addr
+			}
+			future := notify(30*math.Day, blockTs-math.Timestamp(math.Day))
+			notify(math.Day, blockTs-2*math.Timestamp(math.Day))
+
+			search.Client.FlushDB()
+			_, _, err := search.IndexBlockchain(app.GetDB(), app.GetDS())
+			require.NoError(t, err)
+
+			// initial indexing leaves unlocks to the next block
+			past := srch.UnlocksQueryParams{After: srch.RangeEndpoint{Timestamp: 1}}
+			unlocks, err := search.SearchUnlocks(past)
+			require.NoError(t, err)
+			require.Empty(t, unlocks.Items)
+
+			// state change: the next block is indexed
+			deliverTxsContext(t, app, nil, ddc(t).at(blockTs).atHeight(700))
+
+			// postcondition: only the unlock still to come was indexed, from the previous block
+			unlocks, err = search.SearchUnlocks(past)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(unlocks.Items))
+			require.Equal(t, future.String(), unlocks.Items[0].Address)
+			require.Equal(t, uint64(699), unlocks.Items[0].Height)
+			require.Equal(t, 1, len(unlocks.Days))
+			require.Equal(t, uint64(1), unlocks.Days[0].Accounts)
+
+			// later blocks don't count them again
+			deliverTxsContext(t, app, nil, ddc(t).at(blockTs+1).atHeight(701))
+			unlocks, err = search.SearchUnlocks(past)
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), unlocks.Days[0].Accounts)
+		})
 	})
 }
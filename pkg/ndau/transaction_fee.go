@@ -10,6 +10,7 @@ package ndau
 // - -- --- ---- -----
 
 import (
+	"github.com/ndau/chaincode/pkg/vm"
 	metatx "github.com/ndau/metanode/pkg/meta/transaction"
 	"github.com/ndau/msgp-well-known-types/wkt"
 	"github.com/ndau/ndau/pkg/ndau/backing"
@@ -21,29 +22,38 @@ import (
 )
 
 func (app *App) calculateTxFee(tx metatx.Transactable) (math.Ndau, error) {
-	var script wkt.Bytes
-	err := app.System(sv.TxFeeScriptName, &script)
+	theVM, _, err := app.txFeeVM(tx)
 	if err != nil {
-		return 0, errors.Wrap(err, "fetching TxFeeScript system variable")
+		return 0, err
 	}
 
-	vm, err := BuildVMForTxFees(script, tx, app.BlockTime())
-	if err != nil {
-		return 0, errors.Wrap(err, "couldn't build vm for tx fee script")
-	}
-
-	err = vm.Run(nil)
+	err = theVM.Run(nil)
 	if err != nil {
 		return 0, errors.Wrap(err, "tx fee script")
 	}
 
-	vmReturn, err := vm.Stack().PopAsInt64()
+	vmReturn, err := theVM.Stack().PopAsInt64()
 	if err != nil {
 		return 0, errors.Wrap(err, "tx fee script exited without numeric top value")
 	}
 	return math.Ndau(vmReturn), nil
 }
 
+// txFeeVM builds the VM which calculates the fee of tx, returning it with the fee script
+func (app *App) txFeeVM(tx metatx.Transactable) (*vm.ChaincodeVM, wkt.Bytes, error) {
+	var script wkt.Bytes
+	err := app.System(sv.TxFeeScriptName, &script)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "fetching TxFeeScript system variable")
+	}
+
+	theVM, err := BuildVMForTxFees(script, tx, app.BlockTime())
+	if err != nil {
+		return nil, script, errors.Wrap(err, "couldn't build vm for tx fee script")
+	}
+	return theVM, script, nil
+}
+
 // Change in SIB application rules. Previously SIB was imposed except if the source was an authorized
 // exchange account. Now SIB will be imposed only if the source is not an authorized exchange account
 // and the destination is an authorized exchange account.
@@ -15,6 +15,7 @@ import (
 	"github.com/ndau/chaincode/pkg/vm"
 	metast "github.com/ndau/metanode/pkg/meta/state"
 	"github.com/ndau/ndau/pkg/ndau/backing"
+	"github.com/ndau/ndau/pkg/ndau/search"
 	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/ndau/ndaumath/pkg/signature"
 	math "github.com/ndau/ndaumath/pkg/types"
@@ -245,3 +246,10 @@ func (tx *ClaimNodeReward) GetSignatures() []signature.Signature {
 func (tx *ClaimNodeReward) ExtendSignatures(sa []signature.Signature) {
 	tx.Signatures = append(tx.Signatures, sa...)
 }
+
+// GetClaimingNode implements search.NodeRewardClaimIndexable
+func (tx *ClaimNodeReward) GetClaimingNode() address.Address {
+	return tx.Node
+}
+
+var _ search.NodeRewardClaimIndexable = (*ClaimNodeReward)(nil)
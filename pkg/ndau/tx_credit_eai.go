@@ -53,58 +53,18 @@ func (tx *CreditEAI) Validate(appI interface{}) error {
 func (tx *CreditEAI) Apply(appI interface{}) error {
 	app := appI.(*App)
 
-	unlockedTable := new(eai.RateTable)
-	err := app.System(sv.UnlockedRateTableName, unlockedTable)
+	params, err := NewEAIParams(app.GetState().(*backing.State).Sysvars, app.IsFeatureActive)
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error fetching %s system variable in CreditEAI.Apply", sv.UnlockedRateTableName))
+		return errors.Wrap(err, "in CreditEAI.Apply")
 	}
-
-	eaiOvertime := new(math.Duration)
-	err = app.System(sv.EAIOvertime, eaiOvertime)
-	if err != nil {
+	if params.Overtime == nil {
 		app.DecoratedTxLogger(tx).Info("could not get EAI Overtime sysvar; will not apply overtime limit")
-		err = nil
-		eaiOvertime = nil
-	}
-
-	/*
-		2022-05-01 Change to use current lock bonus table, not the bonus value stored in the account. Set a flag
-		here to be used later, because we have to do this test for every account in the credit EAI loop. The rate
-		table could change at any time, so we always have to check.
-	*/
-
-	lockedBonusRateTable := eai.RateTable{}
-	useCurrentLockBonus := app.IsFeatureActive("UseCurrentLockBonus")
-	if useCurrentLockBonus {
-		err = app.System(sv.LockedRateTableName, &lockedBonusRateTable)
-		if err != nil {
-			return err
-		}
-	}
-
-	// Exchange accounts get a flat rate for EAI.  To accomplish this, we make a 1-element rate
-	// table using the exchange account Rate (dependent on account) with a zero From field.
-	exchangeTable := make(eai.RateTable, 1, 1)
-
-	feeTable := new(sv.EAIFeeTable)
-	err = app.System(sv.EAIFeeTableName, feeTable)
-	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("Error fetching %s system variable in CreditEAI.Apply", sv.EAIFeeTableName))
-	}
-
-	// calculate the actual award per ndau of EAI, so we can reduce each account's
-	// award appropriately
-	awardPerNdau := math.Ndau(constants.QuantaPerUnit)
-	for _, fee := range *feeTable {
-		awardPerNdau -= fee.Fee
 	}
 
 	// accumulate the total EAI credited by this transaction so we can award
 	// fees appropriately
 	var totalEAICredited uint64
 
-	fixEAIUnlockBug := app.IsFeatureActive("FixEAIUnlockBug")
-
 	return app.UpdateState(
 		app.recalculateWAAs(tx),
 		app.applyTxDetails(tx),
@@ -118,7 +78,7 @@ func (tx *CreditEAI) Apply(appI interface{}) error {
 			logger := app.DecoratedTxLogger(tx).WithFields(log.Fields{
 				"node":          tx.Node.String(),
 				"blockTime":     app.BlockTime(),
-				"unlockedTable": unlockedTable,
+				"unlockedTable": params.UnlockedTable,
 			})
 
 			// for deterministic EAI calculations, it is necessary that the
@@ -168,58 +128,30 @@ func (tx *CreditEAI) Apply(appI interface{}) error {
 					return
 				}
 
-				err = acctData.WeightedAverageAge.UpdateWeightedAverageAge(
-					app.BlockTime().Since(acctData.LastWAAUpdate),
-					0,
-					acctData.Balance,
-				)
-				if handle(err) {
-					return
-				}
-				acctData.LastWAAUpdate = app.BlockTime()
-
-				// Select the appropriate age/rate table to use.
-				ageTable := unlockedTable
+				// Exchange accounts get a flat rate for EAI.
+				var exchangeRate func(backing.AccountData) (eai.Rate, error)
 				isExchangeAccount, err := app.GetState().(*backing.State).AccountHasAttribute(addr, sv.AccountAttributeExchange)
 				if handle(err) {
 					return
 				}
 				if isExchangeAccount {
-					exchangeTable[0].Rate, err = app.calculateExchangeEAIRate(acctData)
-					if handle(err) {
-						return
-					}
-					ageTable = &exchangeTable
+					exchangeRate = app.calculateExchangeEAIRate
 				}
 
-				// we have to add the uncredited EAI to the balance before calculating
-				// new EAI so that we grant the full amount. Failure to do so
-				// means that people won't earn EAI on what is currently uncredited.
-				pending, err := acctData.Balance.Add(acctData.UncreditedEAI)
+				result, err := params.CalculateCreditEAI(&acctData, app.BlockTime(), exchangeRate)
 				if handle(err) {
 					return
 				}
 
-				// when the EAI overtime duration is set, this is the maximum amount
-				// of EAI which can be applied by a CreditEAI transaction. This
-				// encourages node operators to issue the tx regularly.
-				lastUpdate := acctData.LastEAIUpdate
-				if eaiOvertime != nil && lastUpdate.Add(*eaiOvertime) < app.BlockTime() {
-					lastUpdate = app.BlockTime().Sub(*eaiOvertime)
-				}
-
-				tableRows := make([]string, 0, len(*ageTable))
-				for _, row := range *ageTable {
+				tableRows := make([]string, 0, len(result.Table))
+				for _, row := range result.Table {
 					rt, _ := row.MarshalText()
 					tableRows = append(tableRows, string(rt))
 				}
-				tableS := strings.Join(tableRows, "/")
 				logger := app.DecoratedTxLogger(tx).WithFields(log.Fields{
 					"sourceAcct":         addrS,
-					"pending":            pending.String(),
-					"lastUpdate":         lastUpdate.String(),
 					"weightedAverageAge": acctData.WeightedAverageAge.String(),
-					"ageTable":           tableS,
+					"ageTable":           strings.Join(tableRows, "/"),
 				})
 				if acctData.Lock == nil {
 					logger = logger.WithField("lock", "nil")
@@ -236,41 +168,17 @@ func (tx *CreditEAI) Apply(appI interface{}) error {
 
 					/*
 						2022-05-01 Change - always use the current lock bonus, not the saved one, and
-						update the account state with the new rate if it's different.
+						update the account state with the new rate.
 					*/
-
-					if useCurrentLockBonus {
-						currentLockBonus := lockedBonusRateTable.RateAt(acctData.Lock.NoticePeriod)
-						/*
-							2023-05-02 Remove uninteresting logging message for EVERY locked account on EVERY CreditEAI transaction!
-
-									app.DecoratedTxLogger(tx).WithFields(log.Fields{
-									"acctLockBonus":    acctData.Lock.Bonus.String(),
-									"currentLockBonus": currentLockBonus.String(),
-								}).Info("lock bonus update")
-						*/
-						if acctData.Lock.Bonus != currentLockBonus {
-							acctData.Lock.Bonus = currentLockBonus
-							state.Accounts[addrS].Lock.Bonus = acctData.Lock.Bonus
-						}
+					if params.UseCurrentLockBonus {
+						state.Accounts[addrS].Lock.Bonus = acctData.Lock.Bonus
 					}
 				}
 
-				logger.Debug("credit EAI calculation fields")
-
-				eaiAward, err := eai.Calculate(
-					pending, app.BlockTime(), lastUpdate,
-					acctData.WeightedAverageAge, acctData.Lock,
-					*ageTable, fixEAIUnlockBug,
-				)
-				if handle(err) {
-					return
-				}
-
-				app.DecoratedTxLogger(tx).WithFields(log.Fields{
-					"sourceAcct":    addrS,
-					"EAIAward":      eaiAward.String(),
+				logger.WithFields(log.Fields{
 					"uncreditedEAI": acctData.UncreditedEAI.String(),
+					"totalAward":    result.Award.String(),
+					"reducedAward":  result.Net.String(),
 				}).Debug("credit EAI calculation results")
 
 				if app.IsFeatureActive("CreditEAIUnlocksAccounts") {
@@ -286,34 +194,12 @@ func (tx *CreditEAI) Apply(appI interface{}) error {
 					}
 				}
 
-				eaiAward, err = eaiAward.Add(acctData.UncreditedEAI)
-				if handle(err) {
-					return
-				}
-
 				// add the total EAI credited BEFORE reducing it
-				totalEAICredited += uint64(eaiAward)
-
-				// now reduce the award to account for the fees
-				reducedAward, err := signed.MulDiv(
-					int64(eaiAward),
-					int64(awardPerNdau),
-					constants.QuantaPerUnit,
-				)
-				if handle(err) {
-					return
-				}
-
-				app.DecoratedTxLogger(tx).WithFields(log.Fields{
-					"sourceAcct":   addrS,
-					"totalAward":   eaiAward.String(),
-					"reducedAward": math.Ndau(reducedAward).String(),
-				}).Debug("credit EAI award reduction")
+				totalEAICredited += uint64(result.Award)
 
-				eaiAward = math.Ndau(reducedAward)
 				_, err = state.PayReward(
 					addr,
-					eaiAward,
+					result.Net,
 					app.BlockTime(),
 					app.getDefaultRecourseDuration(),
 					true,
@@ -323,7 +209,7 @@ func (tx *CreditEAI) Apply(appI interface{}) error {
 					return
 				}
 				logger.WithFields(log.Fields{
-					"award":         eaiAward,
+					"award":         result.Net,
 					"rewardsTarget": acctData.RewardsTarget,
 				}).Debug("awarded EAI")
 			}
@@ -349,7 +235,7 @@ func (tx *CreditEAI) Apply(appI interface{}) error {
 
 			// before considering the error list generated from the account iteration,
 			// we want to ensure that appropriate fees get credited regardless
-			for _, fee := range *feeTable {
+			for _, fee := range params.FeeTable {
 				feeAward, err := signed.MulDiv(
 					int64(totalEAICredited),
 					int64(fee.Fee),
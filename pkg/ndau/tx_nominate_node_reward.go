@@ -19,6 +19,7 @@ import (
 
 	metast "github.com/ndau/metanode/pkg/meta/state"
 	"github.com/ndau/ndau/pkg/ndau/backing"
+	"github.com/ndau/ndau/pkg/ndau/search"
 	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/ndau/ndaumath/pkg/signature"
 	math "github.com/ndau/ndaumath/pkg/types"
@@ -156,3 +157,10 @@ func (tx *NominateNodeReward) GetSignatures() []signature.Signature {
 func (tx *NominateNodeReward) ExtendSignatures(sa []signature.Signature) {
 	tx.Signatures = append(tx.Signatures, sa...)
 }
+
+// GetRandom implements search.NodeRewardNominationIndexable
+func (tx *NominateNodeReward) GetRandom() int64 {
+	return tx.Random
+}
+
+var _ search.NodeRewardNominationIndexable = (*NominateNodeReward)(nil)
@@ -12,6 +12,7 @@ package ndau
 import (
 	"fmt"
 
+	"github.com/ndau/chaincode/pkg/vm"
 	metast "github.com/ndau/metanode/pkg/meta/state"
 	"github.com/ndau/msgp-well-known-types/wkt"
 	"github.com/ndau/ndau/pkg/ndau/backing"
@@ -22,6 +23,7 @@ import (
 	"github.com/ndau/ndaumath/pkg/pricecurve"
 	"github.com/ndau/ndaumath/pkg/signature"
 	"github.com/ndau/ndaumath/pkg/signed"
+	math "github.com/ndau/ndaumath/pkg/types"
 	sv "github.com/ndau/system_vars/pkg/system_vars"
 	"github.com/pkg/errors"
 )
@@ -49,6 +51,11 @@ func (app *App) updatePricesAndSIB(marketPrice pricecurve.Nanocent) func(stateI
 
 func floorPrice(app *App, nav pricecurve.Nanocent) (fp pricecurve.Nanocent, err error) {
 	summary := getLastSummary(app)
+	return floorPriceFor(nav, summary.TotalCirculation)
+}
+
+// floorPriceFor computes the floor price implied by the given NAV and total ndau in circulation.
+func floorPriceFor(nav pricecurve.Nanocent, totalCirculation math.Ndau) (fp pricecurve.Nanocent, err error) {
 	// just dividing NAV (denominated in nanocents) by TotalCirculation (denominated
 	// in napu) gives us nanocents per napu, which is inconsistent with market
 	// price and target price (nanocents per ndau), and also small enough that
@@ -56,12 +63,12 @@ func floorPrice(app *App, nav pricecurve.Nanocent) (fp pricecurve.Nanocent, err
 	// nanocents per ndau without overflow.
 
 	// default zero: avoid divide by 0 errors
-	if summary.TotalCirculation != 0 {
+	if totalCirculation != 0 {
 		var floorPriceI int64
 		floorPriceI, err = signed.MulDiv(
 			int64(nav),
 			constants.NapuPerNdau,
-			int64(summary.TotalCirculation*2))
+			int64(totalCirculation*2))
 		if err != nil {
 			err = errors.Wrap(err, "computing floor price")
 			return
@@ -75,6 +82,32 @@ func floorPrice(app *App, nav pricecurve.Nanocent) (fp pricecurve.Nanocent, err
 //
 // It also returns the calculated target price.
 func (app *App) calculateCurrentSIB(state *backing.State, marketPrice, nav pricecurve.Nanocent) (sib eai.Rate, targetPrice pricecurve.Nanocent, err error) {
+	theVM, targetPrice, _, err := app.sibVM(state, marketPrice, nav)
+	if err != nil {
+		return
+	}
+
+	err = theVM.Run(nil)
+	if err != nil {
+		err = errors.Wrap(err, "computing SIB")
+		return
+	}
+
+	top, err := theVM.Stack().PopAsInt64()
+	if err != nil {
+		err = errors.Wrap(err, "retrieving SIB from VM")
+		return
+	}
+
+	sib = eai.Rate(top)
+	return
+}
+
+// sibVM builds the VM which calculates the SIB implied by the market price given
+// the current app state; negative prices are replaced by the current ones.
+//
+// It also returns the calculated target price, and the SIB script.
+func (app *App) sibVM(state *backing.State, marketPrice, nav pricecurve.Nanocent) (theVM *vm.ChaincodeVM, targetPrice pricecurve.Nanocent, sibScript wkt.Bytes, err error) {
 	if marketPrice < 0 {
 		marketPrice = state.MarketPrice
 	}
@@ -101,7 +134,6 @@ func (app *App) calculateCurrentSIB(state *backing.State, marketPrice, nav price
 	}
 
 	// get the script used to perform the calculation
-	var sibScript wkt.Bytes
 	err = app.System(sv.SIBScriptName, &sibScript)
 	if err != nil {
 		err = errors.Wrap(err, "fetching "+sv.SIBScriptName)
@@ -112,26 +144,10 @@ func (app *App) calculateCurrentSIB(state *backing.State, marketPrice, nav price
 		return
 	}
 
-	// compute SIB
-	vm, err := BuildVMForSIB(sibScript, uint64(targetPrice), uint64(marketPrice), uint64(fp), app.BlockTime())
+	theVM, err = BuildVMForSIB(sibScript, uint64(targetPrice), uint64(marketPrice), uint64(fp), app.BlockTime())
 	if err != nil {
 		err = errors.Wrap(err, "building vm for SIB calculation")
-		return
 	}
-
-	err = vm.Run(nil)
-	if err != nil {
-		err = errors.Wrap(err, "computing SIB")
-		return
-	}
-
-	top, err := vm.Stack().PopAsInt64()
-	if err != nil {
-		err = errors.Wrap(err, "retrieving SIB from VM")
-		return
-	}
-
-	sib = eai.Rate(top)
 	return
 }
 
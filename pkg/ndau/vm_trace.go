@@ -0,0 +1,74 @@
+package ndau
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"strings"
+
+	"github.com/ndau/chaincode/pkg/vm"
+	"github.com/ndau/ndau/pkg/query"
+)
+
+// maxTraceSteps bounds the trace of a single VM run; the VM's own instruction
+// limit is far higher than anyone will want to read.
+const maxTraceSteps = 10000
+
+// vmTracer is a vm.Dumper which records the state of a VM before each instruction
+type vmTracer struct {
+	steps []query.VMStep
+}
+
+// Dump implements vm.Dumper
+func (t *vmTracer) Dump(v *vm.ChaincodeVM) {
+	if len(t.steps) >= maxTraceSteps {
+		return
+	}
+	instruction, _ := v.Disassemble(v.IP())
+	t.steps = append(t.steps, query.VMStep{
+		Offset:      v.IP(),
+		Instruction: strings.TrimSpace(instruction),
+		Stack:       stackValues(v),
+	})
+}
+
+// stackValues lists the values on a VM's stack from the top down
+func stackValues(v *vm.ChaincodeVM) []string {
+	stack := v.Stack()
+	values := make([]string, 0, stack.Depth())
+	for i := 0; i < stack.Depth(); i++ {
+		value, err := stack.Get(i)
+		if err != nil {
+			break
+		}
+		values = append(values, value.String())
+	}
+	return values
+}
+
+// disassemble lists the instructions of a VM's program, whose code is given
+func disassemble(v *vm.ChaincodeVM, code []byte) []string {
+	lines := make([]string, 0, len(code))
+	for pc := 0; pc < len(code); {
+		line, n := v.Disassemble(pc)
+		lines = append(lines, strings.TrimSpace(line))
+		if n <= 0 {
+			break
+		}
+		pc += n
+	}
+	return lines
+}
+
+// runTraced runs a VM to completion, recording each step
+func runTraced(theVM *vm.ChaincodeVM) ([]query.VMStep, error) {
+	tracer := new(vmTracer)
+	err := theVM.Run(tracer)
+	return tracer.steps, err
+}
@@ -0,0 +1,274 @@
+package cfg
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/sirupsen/logrus"
+	cmn "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/rpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	defaultCacheBytes = 64 * 1024 * 1024
+
+	// The subscriber name the cache uses to hear about new blocks.
+	cacheSubscriber = "ndauapi-cache"
+
+	// How long to wait between attempts to subscribe to new blocks.
+	cacheRetryInterval = 10 * time.Second
+)
+
+// CacheableEndpoints are the ABCI query endpoints whose results only change from one block to the next.
+var CacheableEndpoints = map[string]bool{
+	query.AccountEndpoint:       true,
+	query.AccountsEndpoint:      true,
+	query.CurrencySeatsEndpoint: true,
+	query.DelegatesEndpoint:     true,
+	query.FeatureEndpoint:       true,
+	query.NodesEndpoint:         true,
+	query.SIBEndpoint:           true,
+	query.SummaryEndpoint:       true,
+	query.SysvarsEndpoint:       true,
+	query.TxSourceEndpoint:      true,
+	query.VersionEndpoint:       true,
+}
+
+// CacheStats reports how well a Cache is doing.
+type CacheStats struct {
+	Active    bool
+	Height    int64
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Bytes     int64
+	MaxBytes  int64
+}
+
+type cacheEntry struct {
+	key    string
+	result *rpctypes.ResultABCIQuery
+	size   int64
+}
+
+// A Cache is a TMClient which remembers the results of ABCI queries of CacheableEndpoints
+// until the next block is committed.
+//
+// It learns of new blocks by subscribing to the node's events. Until it has managed to,
+// queries pass straight through.
+type Cache struct {
+	TMClient
+	maxBytes int64
+	logger   logrus.FieldLogger
+
+	lock       sync.Mutex
+	active     bool
+	lastTry    time.Time
+	height     int64
+	generation uint64
+	entries    map[string]*list.Element
+	lru        *list.List // most recently used first
+	bytes      int64
+	hits       uint64
+	misses     uint64
+	evictions  uint64
+}
+
+// NewCache wraps a TMClient with a cache which holds at most maxBytes of query results.
+func NewCache(node TMClient, maxBytes int64, logger logrus.FieldLogger) *Cache {
+	return &Cache{
+		TMClient: node,
+		maxBytes: maxBytes,
+		logger:   logger,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Stats returns the cache's current statistics.
+func (c *Cache) Stats() CacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return CacheStats{
+		Active:    c.active,
+		Height:    c.height,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Entries:   len(c.entries),
+		Bytes:     c.bytes,
+		MaxBytes:  c.maxBytes,
+	}
+}
+
+// ensureActive subscribes to new blocks if we haven't already, and haven't tried too recently.
+// It reports whether the cache is usable.
+func (c *Cache) ensureActive() bool {
+	c.lock.Lock()
+	if c.active || time.Since(c.lastTry) < cacheRetryInterval {
+		defer c.lock.Unlock()
+		return c.active
+	}
+	c.lastTry = time.Now()
+	c.lock.Unlock()
+
+	headers, err := c.Subscribe(
+		context.Background(), cacheSubscriber, tmtypes.EventQueryNewBlockHeader.String(),
+	)
+	if err != nil {
+		c.logf(err, "cache could not subscribe to new blocks; not caching")
+		return false
+	}
+
+	c.lock.Lock()
+	c.active = true
+	c.lock.Unlock()
+	go c.run(headers)
+	return true
+}
+
+func (c *Cache) run(headers <-chan rpctypes.ResultEvent) {
+	for event := range headers {
+		height := int64(0)
+		if data, ok := event.Data.(tmtypes.EventDataNewBlockHeader); ok {
+			height = data.Header.Height
+		}
+		c.lock.Lock()
+		// A node we queried may already have told us of this block; see ABCIQueryWithOptions.
+		if height > c.height {
+			c.height = height
+			c.purge()
+		}
+		c.lock.Unlock()
+	}
+
+	// Without news of new blocks, anything we cached could go stale.
+	c.logf(nil, "cache lost its new block subscription; not caching")
+	c.lock.Lock()
+	c.active = false
+	c.purge()
+	c.lock.Unlock()
+}
+
+// purge empties the cache. It must be called with the lock held.
+func (c *Cache) purge() {
+	c.generation++
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	c.bytes = 0
+}
+
+func (c *Cache) logf(err error, msg string) {
+	if c.logger == nil {
+		return
+	}
+	if err != nil {
+		c.logger.WithError(err).Warn(msg)
+	} else {
+		c.logger.Warn(msg)
+	}
+}
+
+// Subscribe implements TMClient
+func (c *Cache) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan rpctypes.ResultEvent, error) {
+	err := startEvents(c.TMClient)
+	if err != nil {
+		return nil, err
+	}
+	return c.TMClient.Subscribe(ctx, subscriber, query, outCapacity...)
+}
+
+// ABCIQuery implements TMClient
+func (c *Cache) ABCIQuery(path string, data cmn.HexBytes) (*rpctypes.ResultABCIQuery, error) {
+	return c.ABCIQueryWithOptions(path, data, client.DefaultABCIQueryOptions)
+}
+
+// ABCIQueryWithOptions implements TMClient
+//
+// Only queries of the latest state without proofs are cached.
+func (c *Cache) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*rpctypes.ResultABCIQuery, error) {
+	if !CacheableEndpoints[path] || opts.Height != 0 || opts.Prove || !c.ensureActive() {
+		return c.TMClient.ABCIQueryWithOptions(path, data, opts)
+	}
+
+	key := path + "\x00" + string(data)
+
+	c.lock.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(elem)
+		c.hits++
+		result := *elem.Value.(*cacheEntry).result
+		c.lock.Unlock()
+		return &result, nil
+	}
+	c.misses++
+	generation := c.generation
+	c.lock.Unlock()
+
+	result, err := c.TMClient.ABCIQueryWithOptions(path, data, opts)
+	if err != nil || result == nil {
+		return result, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// Behind a Pool, queries may go to a node other than the one telling us of new blocks.
+	// If it is ahead, everything we hold is from before its latest block, and if the node
+	// we subscribed to has stalled, we would otherwise never hear of it.
+	if result.Response.Height > c.height {
+		c.height = result.Response.Height
+		c.purge()
+		generation = c.generation
+	}
+	// Don't keep a result which a new block may have made stale while we were asking, nor
+	// one from before the latest block: until it's committed, the node answers from the
+	// state before it, which the block's commit will change without telling us.
+	if generation == c.generation && result.Response.Height == c.height {
+		c.store(key, result)
+	}
+	copied := *result
+	return &copied, nil
+}
+
+// store adds a result to the cache, evicting the least recently used entries to make room.
+// It must be called with the lock held.
+func (c *Cache) store(key string, result *rpctypes.ResultABCIQuery) {
+	size := int64(len(key) + len(result.Response.Key) + len(result.Response.Value) +
+		len(result.Response.Log) + len(result.Response.Info))
+	if size > c.maxBytes {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.bytes -= elem.Value.(*cacheEntry).size
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+	}
+	for c.bytes+size > c.maxBytes {
+		oldest := c.lru.Back()
+		entry := oldest.Value.(*cacheEntry)
+		c.lru.Remove(oldest)
+		delete(c.entries, entry.key)
+		c.bytes -= entry.size
+		c.evictions++
+	}
+	stored := *result
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, result: &stored, size: size})
+	c.bytes += size
+}
+
+var _ TMClient = (*Cache)(nil)
@@ -0,0 +1,176 @@
+package cfg
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/stretchr/testify/require"
+	cmn "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/rpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// newCacheTest returns a cache in front of a node at height 1, which the cache knows
+func newCacheTest(t *testing.T, maxBytes int64) (*Cache, *fakeNode, func(height int64)) {
+	node := &fakeNode{headers: make(chan rpctypes.ResultEvent)}
+	cache := NewCache(node, maxBytes, nil)
+	require.True(t, cache.ensureActive())
+
+	// header announces a new block, and waits for the cache to hear of it
+	header := func(height int64) {
+		node.headers <- rpctypes.ResultEvent{Data: tmtypes.EventDataNewBlockHeader{
+			Header: tmtypes.Header{Height: height},
+		}}
+		for cache.Stats().Height != height {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	node.setHeight(1)
+	header(1)
+	return cache, node, header
+}
+
+func cacheQuery(t *testing.T, cache *Cache, path, data string) {
+	result, err := cache.ABCIQuery(path, cmn.HexBytes(data))
+	require.NoError(t, err)
+	require.Equal(t, data, string(result.Response.Value))
+}
+
+func TestCacheHitMiss(t *testing.T) {
+	cache, node, _ := newCacheTest(t, defaultCacheBytes)
+	defer close(node.headers)
+
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	cacheQuery(t, cache, query.AccountEndpoint, "b")
+	require.Equal(t, 2, node.queryCount())
+	stats := cache.Stats()
+	require.Equal(t, uint64(1), stats.Hits)
+	require.Equal(t, uint64(2), stats.Misses)
+	require.Equal(t, 2, stats.Entries)
+
+	// other endpoints, and historical or proven queries, always go to the node
+	cacheQuery(t, cache, query.SearchEndpoint, "a")
+	cacheQuery(t, cache, query.SearchEndpoint, "a")
+	require.Equal(t, 4, node.queryCount())
+	_, err := cache.ABCIQueryWithOptions(query.AccountEndpoint, cmn.HexBytes("a"), client.ABCIQueryOptions{Height: 1})
+	require.NoError(t, err)
+	_, err = cache.ABCIQueryWithOptions(query.AccountEndpoint, cmn.HexBytes("a"), client.ABCIQueryOptions{Prove: true})
+	require.NoError(t, err)
+	require.Equal(t, 6, node.queryCount())
+	require.Equal(t, uint64(1), cache.Stats().Hits)
+}
+
+func TestCachePurge(t *testing.T) {
+	cache, node, header := newCacheTest(t, defaultCacheBytes)
+	defer close(node.headers)
+
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	require.Equal(t, 1, node.queryCount())
+
+	// the node hasn't committed the new block yet, so what it says isn't kept
+	header(2)
+	require.Equal(t, 0, cache.Stats().Entries)
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	require.Equal(t, 3, node.queryCount())
+	require.Equal(t, 0, cache.Stats().Entries)
+
+	// once it has, it is
+	node.setHeight(2)
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	require.Equal(t, 4, node.queryCount())
+	require.Equal(t, 1, cache.Stats().Entries)
+}
+
+func TestCacheEviction(t *testing.T) {
+	// each entry is the key, which is the path, a separator and the data, plus the
+	// value, which echoes the data
+	entrySize := int64(len(query.AccountEndpoint) + 1 + 2*len("a"))
+	cache, node, _ := newCacheTest(t, 2*entrySize)
+	defer close(node.headers)
+
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	cacheQuery(t, cache, query.AccountEndpoint, "b")
+	cacheQuery(t, cache, query.AccountEndpoint, "c")
+	stats := cache.Stats()
+	require.Equal(t, 2, stats.Entries)
+	require.Equal(t, 2*entrySize, stats.Bytes)
+	require.Equal(t, uint64(1), stats.Evictions)
+
+	// a was least recently used
+	cacheQuery(t, cache, query.AccountEndpoint, "b")
+	require.Equal(t, 3, node.queryCount())
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	require.Equal(t, 4, node.queryCount())
+
+	// and then c was
+	cacheQuery(t, cache, query.AccountEndpoint, "b")
+	require.Equal(t, 4, node.queryCount())
+	cacheQuery(t, cache, query.AccountEndpoint, "c")
+	require.Equal(t, 5, node.queryCount())
+	require.Equal(t, uint64(3), cache.Stats().Evictions)
+
+	// results too large to cache at all are passed on
+	cacheQuery(t, cache, query.AccountEndpoint, "too large to cache")
+	cacheQuery(t, cache, query.AccountEndpoint, "too large to cache")
+	require.Equal(t, 7, node.queryCount())
+	require.Equal(t, 2, cache.Stats().Entries)
+}
+
+func TestCacheGenerationRace(t *testing.T) {
+	cache, node, header := newCacheTest(t, defaultCacheBytes)
+	defer close(node.headers)
+
+	// a block is committed while the node is answering
+	node.during = func() {
+		node.during = nil
+		node.setHeight(2)
+		header(2)
+	}
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	require.Equal(t, 0, cache.Stats().Entries)
+
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	require.Equal(t, 2, node.queryCount())
+	require.Equal(t, 1, cache.Stats().Entries)
+}
+
+func TestCacheFollowsQueriedHeight(t *testing.T) {
+	cache, node, header := newCacheTest(t, defaultCacheBytes)
+	defer close(node.headers)
+
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	cacheQuery(t, cache, query.AccountEndpoint, "b")
+	require.Equal(t, 2, cache.Stats().Entries)
+
+	// the node answering has committed blocks we haven't heard of: what we hold is stale
+	node.setHeight(3)
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	stats := cache.Stats()
+	require.Equal(t, int64(3), stats.Height)
+	require.Equal(t, 1, stats.Entries)
+	cacheQuery(t, cache, query.AccountEndpoint, "a")
+	require.Equal(t, 3, node.queryCount())
+
+	// late news of a block we already know of changes nothing
+	node.headers <- rpctypes.ResultEvent{Data: tmtypes.EventDataNewBlockHeader{
+		Header: tmtypes.Header{Height: 2},
+	}}
+	header(3)
+	require.Equal(t, int64(3), cache.Stats().Height)
+	require.Equal(t, 1, cache.Stats().Entries)
+}
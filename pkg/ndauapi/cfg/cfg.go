@@ -13,6 +13,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ndau/ndau/pkg/ndauapi/ws"
 	"github.com/pkg/errors"
@@ -32,7 +34,9 @@ const (
 // with something which mocks a block
 type TMClient interface {
 	client.ABCIClient
+	client.EventsClient
 	client.HistoryClient
+	client.MempoolClient
 	client.NetworkClient
 	client.StatusClient
 	Block(height *int64) (*rpctypes.ResultBlock, error)
@@ -55,21 +59,58 @@ func NewFromEnv() (Cfg, []string, error) {
 }
 
 // New initializes configuration and returns a config struct
+//
+// nodeAddr may be a comma-separated list of node addresses, in which case the API uses
+// a Pool of those nodes. NDAUAPI_NODE_MAX_LAG sets how many blocks a pooled node may
+// fall behind the others and still be used, and NDAUAPI_NODE_HEALTH_INTERVAL sets how
+// often pooled nodes are checked. NDAUAPI_CACHE_BYTES limits the memory used to cache
+// query results between blocks; 0 disables the cache.
 func New(nodeAddr string) (Cfg, []string, error) {
 	var warn []string
 
-	node, err := ws.Node(nodeAddr)
-	if err != nil {
-		return Cfg{}, nil, errors.Wrap(err, "connecting to TM node")
-	}
-
 	cf := Cfg{
 		// get configuration from env vars
-		Node:   node,
 		Port:   0,
 		Logger: logrus.New(),
 	}
 
+	var addrs []string
+	for _, addr := range strings.Split(nodeAddr, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return cf, warn, errors.New("no TM node address given")
+	}
+
+	maxLag := int64(defaultMaxLag)
+	if strLag := os.Getenv("NDAUAPI_NODE_MAX_LAG"); strLag != "" {
+		lag, err := strconv.ParseInt(strLag, 10, 64)
+		if err != nil || lag < 0 {
+			return cf, warn, fmt.Errorf("cannot use value '%s' for node max lag", strLag)
+		}
+		maxLag = lag
+	}
+	interval := defaultHealthInterval
+	if strInterval := os.Getenv("NDAUAPI_NODE_HEALTH_INTERVAL"); strInterval != "" {
+		d, err := time.ParseDuration(strInterval)
+		if err != nil || d <= 0 {
+			return cf, warn, fmt.Errorf("cannot use value '%s' for node health interval", strInterval)
+		}
+		interval = d
+	}
+
+	cacheBytes := int64(defaultCacheBytes)
+	if strBytes := os.Getenv("NDAUAPI_CACHE_BYTES"); strBytes != "" {
+		b, err := strconv.ParseInt(strBytes, 10, 64)
+		if err != nil || b < 0 {
+			return cf, warn, fmt.Errorf("cannot use value '%s' for cache bytes", strBytes)
+		}
+		cacheBytes = b
+	}
+
 	// validate
 	strPort := os.Getenv("NDAUAPI_PORT")
 	if strPort == "" {
@@ -85,5 +126,23 @@ func New(nodeAddr string) (Cfg, []string, error) {
 		return cf, warn, fmt.Errorf("port (%v) must be within the user or dynamic/private range. (1024-65535)", cf.Port)
 	}
 
+	// connect only once the config is known to be good, so a pool isn't left checking its nodes
+	if len(addrs) > 1 {
+		pool, err := NewPool(addrs, maxLag, interval, cf.Logger)
+		if err != nil {
+			return Cfg{}, nil, errors.Wrap(err, "connecting to TM node pool")
+		}
+		cf.Node = pool
+	} else {
+		node, err := ws.Node(addrs[0])
+		if err != nil {
+			return Cfg{}, nil, errors.Wrap(err, "connecting to TM node")
+		}
+		cf.Node = node
+	}
+	if cacheBytes > 0 {
+		cf.Node = NewCache(cf.Node, cacheBytes, cf.Logger)
+	}
+
 	return cf, warn, nil
 }
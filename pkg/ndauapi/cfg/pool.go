@@ -0,0 +1,400 @@
+package cfg
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ndau/ndau/pkg/ndauapi/ws"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	cmn "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/rpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	defaultMaxLag         = 5
+	defaultHealthInterval = 5 * time.Second
+)
+
+// PoolNodeState describes the most recent health check of one node in a Pool.
+type PoolNodeState struct {
+	URL         string
+	Healthy     bool
+	CatchingUp  bool
+	Height      int64
+	Lag         int64
+	Eligible    bool
+	Error       string `json:",omitempty"`
+	LastChecked time.Time
+}
+
+type poolNode struct {
+	url    string
+	client TMClient
+	state  PoolNodeState
+}
+
+// A Pool is a TMClient which spreads requests across several nodes.
+//
+// Queries go to nodes which are healthy, not catching up, and within MaxLag blocks of the
+// highest node in the pool. Broadcasts fail over to the next such node when one can't be reached.
+// If no node qualifies, the pool does the best it can with whatever nodes are healthy.
+type Pool struct {
+	MaxLag int64
+
+	lock  sync.RWMutex
+	nodes []*poolNode
+	next  int
+
+	// which node each event subscription went to
+	subs map[poolSub]*poolNode
+
+	logger logrus.FieldLogger
+	stop   chan struct{}
+	closer sync.Once
+}
+
+// poolSub identifies an event subscription
+type poolSub struct {
+	subscriber string
+	query      string
+}
+
+// NewPool connects to each of the given nodes, and checks their health every interval until closed.
+func NewPool(urls []string, maxLag int64, interval time.Duration, logger logrus.FieldLogger) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("node pool cannot be empty")
+	}
+	p := &Pool{
+		MaxLag: maxLag,
+		subs:   make(map[poolSub]*poolNode),
+		logger: logger,
+		stop:   make(chan struct{}),
+	}
+	for _, url := range urls {
+		node, err := ws.Node(url)
+		if err != nil {
+			return nil, errors.Wrapf(err, "connecting to TM node %s", url)
+		}
+		p.nodes = append(p.nodes, &poolNode{url: url, client: node})
+	}
+
+	p.Check()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.Check()
+			}
+		}
+	}()
+	return p, nil
+}
+
+// Close stops the pool's health checks.
+func (p *Pool) Close() {
+	p.closer.Do(func() { close(p.stop) })
+}
+
+// Check updates the health of every node in the pool.
+func (p *Pool) Check() {
+	states := make([]PoolNodeState, len(p.nodes))
+	var wg sync.WaitGroup
+	for i, node := range p.nodes {
+		wg.Add(1)
+		go func(i int, node *poolNode) {
+			defer wg.Done()
+			state := PoolNodeState{URL: node.url, LastChecked: time.Now()}
+			status, err := node.client.Status()
+			if err != nil {
+				state.Error = err.Error()
+			} else {
+				state.Healthy = true
+				state.CatchingUp = status.SyncInfo.CatchingUp
+				state.Height = status.SyncInfo.LatestBlockHeight
+			}
+			states[i] = state
+		}(i, node)
+	}
+	wg.Wait()
+
+	tip := int64(0)
+	for _, state := range states {
+		if state.Healthy && state.Height > tip {
+			tip = state.Height
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i, node := range p.nodes {
+		state := states[i]
+		if state.Healthy {
+			state.Lag = tip - state.Height
+			state.Eligible = !state.CatchingUp && state.Lag <= p.MaxLag
+		}
+		if node.state.Eligible != state.Eligible && p.logger != nil {
+			p.logger.WithFields(logrus.Fields{
+				"node":       node.url,
+				"healthy":    state.Healthy,
+				"catchingUp": state.CatchingUp,
+				"lag":        state.Lag,
+				"eligible":   state.Eligible,
+			}).Warn("node pool membership changed")
+		}
+		node.state = state
+	}
+}
+
+// State returns the result of the most recent health check of each node in the pool.
+func (p *Pool) State() []PoolNodeState {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	states := make([]PoolNodeState, len(p.nodes))
+	for i, node := range p.nodes {
+		states[i] = node.state
+	}
+	return states
+}
+
+// candidates lists the nodes to try, best first, starting from the next in rotation.
+func (p *Pool) candidates() []*poolNode {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	start := p.next
+	p.next = (p.next + 1) % len(p.nodes)
+
+	var eligible, healthy, rest []*poolNode
+	for i := range p.nodes {
+		node := p.nodes[(start+i)%len(p.nodes)]
+		switch {
+		case node.state.Eligible:
+			eligible = append(eligible, node)
+		case node.state.Healthy:
+			healthy = append(healthy, node)
+		default:
+			rest = append(rest, node)
+		}
+	}
+	return append(append(eligible, healthy...), rest...)
+}
+
+// pick returns the node which should handle a query.
+func (p *Pool) pick() TMClient {
+	return p.candidates()[0].client
+}
+
+// unreachable is true when err means a node couldn't be reached at all, rather than
+// that the node answered with an error of its own.
+func unreachable(err error) bool {
+	cause := errors.Cause(err)
+	if cause == io.EOF || cause == io.ErrUnexpectedEOF {
+		return true
+	}
+	_, ok := cause.(net.Error)
+	return ok
+}
+
+// failover tries each candidate node in turn until one can be reached.
+//
+// Any other error is the node's answer, and is returned without trying the rest.
+func (p *Pool) failover(try func(TMClient) error) error {
+	var err error
+	for _, node := range p.candidates() {
+		err = try(node.client)
+		if err == nil || !unreachable(err) {
+			return err
+		}
+		if p.logger != nil {
+			p.logger.WithError(err).WithField("node", node.url).Warn("node pool failing over")
+		}
+	}
+	return err
+}
+
+// ABCIInfo implements TMClient
+func (p *Pool) ABCIInfo() (*rpctypes.ResultABCIInfo, error) {
+	return p.pick().ABCIInfo()
+}
+
+// ABCIQuery implements TMClient
+func (p *Pool) ABCIQuery(path string, data cmn.HexBytes) (*rpctypes.ResultABCIQuery, error) {
+	return p.pick().ABCIQuery(path, data)
+}
+
+// ABCIQueryWithOptions implements TMClient
+func (p *Pool) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*rpctypes.ResultABCIQuery, error) {
+	return p.pick().ABCIQueryWithOptions(path, data, opts)
+}
+
+// BroadcastTxCommit implements TMClient
+//
+// Only failures to reach a node fail over; a node's verdict on the tx stands.
+func (p *Pool) BroadcastTxCommit(tx tmtypes.Tx) (result *rpctypes.ResultBroadcastTxCommit, err error) {
+	err = p.failover(func(node TMClient) (err error) {
+		result, err = node.BroadcastTxCommit(tx)
+		return
+	})
+	return
+}
+
+// BroadcastTxAsync implements TMClient
+func (p *Pool) BroadcastTxAsync(tx tmtypes.Tx) (result *rpctypes.ResultBroadcastTx, err error) {
+	err = p.failover(func(node TMClient) (err error) {
+		result, err = node.BroadcastTxAsync(tx)
+		return
+	})
+	return
+}
+
+// BroadcastTxSync implements TMClient
+func (p *Pool) BroadcastTxSync(tx tmtypes.Tx) (result *rpctypes.ResultBroadcastTx, err error) {
+	err = p.failover(func(node TMClient) (err error) {
+		result, err = node.BroadcastTxSync(tx)
+		return
+	})
+	return
+}
+
+// Subscribe implements TMClient
+//
+// Each subscription stays with the node it started on.
+func (p *Pool) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan rpctypes.ResultEvent, error) {
+	node := p.candidates()[0]
+
+	err := startEvents(node.client)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := node.client.Subscribe(ctx, subscriber, query, outCapacity...)
+	if err != nil {
+		return nil, err
+	}
+	p.lock.Lock()
+	p.subs[poolSub{subscriber, query}] = node
+	p.lock.Unlock()
+	return out, nil
+}
+
+// Unsubscribe implements TMClient
+func (p *Pool) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	p.lock.Lock()
+	sub := poolSub{subscriber, query}
+	node, ok := p.subs[sub]
+	delete(p.subs, sub)
+	p.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	return node.client.Unsubscribe(ctx, subscriber, query)
+}
+
+// UnsubscribeAll implements TMClient
+func (p *Pool) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	p.lock.Lock()
+	nodes := make(map[*poolNode]struct{})
+	for sub, node := range p.subs {
+		if sub.subscriber != subscriber {
+			continue
+		}
+		nodes[node] = struct{}{}
+		delete(p.subs, sub)
+	}
+	p.lock.Unlock()
+
+	var err error
+	for node := range nodes {
+		if uerr := node.client.UnsubscribeAll(ctx, subscriber); uerr != nil {
+			err = uerr
+		}
+	}
+	return err
+}
+
+// Genesis implements TMClient
+func (p *Pool) Genesis() (*rpctypes.ResultGenesis, error) {
+	return p.pick().Genesis()
+}
+
+// BlockchainInfo implements TMClient
+func (p *Pool) BlockchainInfo(minHeight, maxHeight int64) (*rpctypes.ResultBlockchainInfo, error) {
+	return p.pick().BlockchainInfo(minHeight, maxHeight)
+}
+
+// UnconfirmedTxs implements TMClient
+func (p *Pool) UnconfirmedTxs(limit int) (*rpctypes.ResultUnconfirmedTxs, error) {
+	return p.pick().UnconfirmedTxs(limit)
+}
+
+// NumUnconfirmedTxs implements TMClient
+func (p *Pool) NumUnconfirmedTxs() (*rpctypes.ResultUnconfirmedTxs, error) {
+	return p.pick().NumUnconfirmedTxs()
+}
+
+// NetInfo implements TMClient
+func (p *Pool) NetInfo() (*rpctypes.ResultNetInfo, error) {
+	return p.pick().NetInfo()
+}
+
+// DumpConsensusState implements TMClient
+func (p *Pool) DumpConsensusState() (*rpctypes.ResultDumpConsensusState, error) {
+	return p.pick().DumpConsensusState()
+}
+
+// ConsensusState implements TMClient
+func (p *Pool) ConsensusState() (*rpctypes.ResultConsensusState, error) {
+	return p.pick().ConsensusState()
+}
+
+// Health implements TMClient
+func (p *Pool) Health() (*rpctypes.ResultHealth, error) {
+	return p.pick().Health()
+}
+
+// Status implements TMClient
+func (p *Pool) Status() (*rpctypes.ResultStatus, error) {
+	return p.pick().Status()
+}
+
+// Block implements TMClient
+func (p *Pool) Block(height *int64) (*rpctypes.ResultBlock, error) {
+	return p.pick().Block(height)
+}
+
+var _ TMClient = (*Pool)(nil)
+
+// startEvents makes sure a node client is ready for event subscriptions.
+//
+// The HTTP client only talks websocket to tendermint once it's been started.
+func startEvents(node TMClient) error {
+	if s, ok := node.(interface {
+		IsRunning() bool
+		Start() error
+	}); ok && !s.IsRunning() {
+		err := s.Start()
+		if err != nil {
+			return errors.Wrap(err, "starting node event client")
+		}
+	}
+	return nil
+}
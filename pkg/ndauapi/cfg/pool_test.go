@@ -0,0 +1,167 @@
+package cfg
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func testPool(clients ...*fakeNode) *Pool {
+	p := &Pool{
+		MaxLag: 5,
+		subs:   make(map[poolSub]*poolNode),
+		stop:   make(chan struct{}),
+	}
+	for i, c := range clients {
+		p.nodes = append(p.nodes, &poolNode{url: string(rune('a' + i)), client: c})
+	}
+	return p
+}
+
+func TestPoolCheck(t *testing.T) {
+	p := testPool(
+		&fakeNode{height: 100},
+		&fakeNode{height: 97},
+		&fakeNode{height: 90},
+		&fakeNode{height: 100, catchingUp: true},
+		&fakeNode{down: true},
+	)
+	defer p.Close()
+	p.Check()
+
+	states := p.State()
+	require.Len(t, states, 5)
+
+	require.True(t, states[0].Healthy)
+	require.True(t, states[0].Eligible)
+	require.Equal(t, int64(0), states[0].Lag)
+
+	require.True(t, states[1].Eligible)
+	require.Equal(t, int64(3), states[1].Lag)
+
+	// too far behind
+	require.True(t, states[2].Healthy)
+	require.False(t, states[2].Eligible)
+	require.Equal(t, int64(10), states[2].Lag)
+
+	require.True(t, states[3].Healthy)
+	require.False(t, states[3].Eligible)
+
+	require.False(t, states[4].Healthy)
+	require.False(t, states[4].Eligible)
+	require.NotEmpty(t, states[4].Error)
+}
+
+func TestPoolCandidates(t *testing.T) {
+	p := testPool(
+		&fakeNode{down: true},
+		&fakeNode{height: 100},
+		&fakeNode{height: 90},
+		&fakeNode{height: 100},
+	)
+	defer p.Close()
+	p.Check()
+
+	urls := func() (urls []string) {
+		for _, node := range p.candidates() {
+			urls = append(urls, node.url)
+		}
+		return
+	}
+
+	// eligible nodes first, in rotation, then healthy ones, then the rest
+	require.Equal(t, []string{"b", "d", "c", "a"}, urls())
+	require.Equal(t, []string{"b", "d", "c", "a"}, urls())
+	require.Equal(t, []string{"d", "b", "c", "a"}, urls())
+	require.Equal(t, []string{"d", "b", "c", "a"}, urls())
+	require.Equal(t, []string{"b", "d", "c", "a"}, urls())
+}
+
+func TestPoolFailover(t *testing.T) {
+	tx := tmtypes.Tx("tx")
+
+	t.Run("unreachable", func(t *testing.T) {
+		first, second := &fakeNode{height: 100}, &fakeNode{height: 100}
+		p := testPool(first, second)
+		defer p.Close()
+		p.Check()
+
+		// the node goes down between health checks
+		first.down = true
+		result, err := p.BroadcastTxSync(tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), []byte(result.Hash))
+		require.Equal(t, 1, first.broadcasts)
+		require.Equal(t, 1, second.broadcasts)
+	})
+
+	t.Run("all unreachable", func(t *testing.T) {
+		first, second := &fakeNode{down: true}, &fakeNode{down: true}
+		p := testPool(first, second)
+		defer p.Close()
+		p.Check()
+
+		_, err := p.BroadcastTxSync(tx)
+		require.Error(t, err)
+		require.True(t, unreachable(err))
+		require.Equal(t, 1, first.broadcasts)
+		require.Equal(t, 1, second.broadcasts)
+	})
+
+	t.Run("verdict", func(t *testing.T) {
+		verdict := errors.New("response error: tx already exists in cache")
+		first := &fakeNode{height: 100, broadcast: verdict}
+		second := &fakeNode{height: 100}
+		p := testPool(first, second)
+		defer p.Close()
+		p.Check()
+
+		_, err := p.BroadcastTxSync(tx)
+		require.Equal(t, verdict, err)
+		require.Equal(t, 1, first.broadcasts)
+		require.Equal(t, 0, second.broadcasts)
+	})
+}
+
+func TestPoolClose(t *testing.T) {
+	// nothing listens on the discard port, so the pool starts with every node unhealthy
+	p, err := NewPool([]string{"tcp://127.0.0.1:9", "tcp://127.0.0.1:9"}, 5, time.Millisecond, nil)
+	require.NoError(t, err)
+	for _, state := range p.State() {
+		require.False(t, state.Healthy)
+	}
+	p.Close()
+	p.Close()
+}
+
+func TestPoolUnsubscribeAll(t *testing.T) {
+	node := &fakeNode{height: 100}
+	p := testPool(node)
+	defer p.Close()
+	p.Check()
+
+	ctx := context.Background()
+	query := tmtypes.EventQueryNewBlockHeader.String()
+	for _, subscriber := range []string{"mine", "theirs"} {
+		_, err := p.Subscribe(ctx, subscriber, query)
+		require.NoError(t, err)
+	}
+
+	// only our own subscription to the same query is forgotten
+	require.NoError(t, p.UnsubscribeAll(ctx, "mine"))
+	require.Equal(t, []string{"mine"}, node.unsubscribed)
+	require.Equal(t, map[poolSub]*poolNode{{"theirs", query}: p.nodes[0]}, p.subs)
+}
@@ -0,0 +1,104 @@
+package cfg
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/rpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// fakeNode is a node whose status, block events and broadcast results the test controls.
+// Its query results echo the query data. Anything else asked of it panics.
+type fakeNode struct {
+	TMClient
+	catchingUp bool
+	down       bool
+	broadcast  error // returned by broadcasts, unless down
+	broadcasts int
+	// block events for subscribers, if set
+	headers chan rpctypes.ResultEvent
+	// the subscribers which unsubscribed from everything
+	unsubscribed []string
+
+	lock    sync.Mutex
+	height  int64
+	queries int
+	during  func() // called while answering a query, if set
+}
+
+var errRefused = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+func (c *fakeNode) Status() (*rpctypes.ResultStatus, error) {
+	if c.down {
+		return nil, errors.Wrap(errRefused, "Post failed")
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return &rpctypes.ResultStatus{SyncInfo: rpctypes.SyncInfo{
+		LatestBlockHeight: c.height,
+		CatchingUp:        c.catchingUp,
+	}}, nil
+}
+
+func (c *fakeNode) BroadcastTxSync(tx tmtypes.Tx) (*rpctypes.ResultBroadcastTx, error) {
+	c.broadcasts++
+	if c.down {
+		return nil, errors.Wrap(errRefused, "Post failed")
+	}
+	if c.broadcast != nil {
+		return nil, c.broadcast
+	}
+	return &rpctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+}
+
+func (c *fakeNode) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan rpctypes.ResultEvent, error) {
+	if c.headers != nil {
+		return c.headers, nil
+	}
+	return make(chan rpctypes.ResultEvent), nil
+}
+
+func (c *fakeNode) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	c.unsubscribed = append(c.unsubscribed, subscriber)
+	return nil
+}
+
+func (c *fakeNode) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*rpctypes.ResultABCIQuery, error) {
+	c.lock.Lock()
+	c.queries++
+	during := c.during
+	c.lock.Unlock()
+	if during != nil {
+		during()
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return &rpctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: data, Height: c.height}}, nil
+}
+
+func (c *fakeNode) setHeight(height int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.height = height
+}
+
+func (c *fakeNode) queryCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.queries
+}
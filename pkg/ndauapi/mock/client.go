@@ -10,6 +10,8 @@ package mock
 // - -- --- ---- -----
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/ndau/ndau/pkg/ndau"
@@ -81,9 +83,36 @@ func (client) NetInfo() (*rpctypes.ResultNetInfo, error) {
 	return &rpctypes.ResultNetInfo{}, nil
 }
 
+// NumUnconfirmedTxs implements TMClient
+func (client) NumUnconfirmedTxs() (*rpctypes.ResultUnconfirmedTxs, error) {
+	return &rpctypes.ResultUnconfirmedTxs{}, nil
+}
+
 // Status implements TMClient
 func (client) Status() (*rpctypes.ResultStatus, error) {
 	return &rpctypes.ResultStatus{}, nil
 }
 
+// Subscribe implements TMClient
+//
+// The mock tendermint never produces events, so subscriptions always fail.
+func (client) Subscribe(context.Context, string, string, ...int) (<-chan rpctypes.ResultEvent, error) {
+	return nil, errors.New("mock client does not support event subscriptions")
+}
+
+// UnconfirmedTxs implements TMClient
+func (client) UnconfirmedTxs(int) (*rpctypes.ResultUnconfirmedTxs, error) {
+	return &rpctypes.ResultUnconfirmedTxs{}, nil
+}
+
+// Unsubscribe implements TMClient
+func (client) Unsubscribe(context.Context, string, string) error {
+	return nil
+}
+
+// UnsubscribeAll implements TMClient
+func (client) UnsubscribeAll(context.Context, string) error {
+	return nil
+}
+
 var _ cfg.TMClient = (*client)(nil)
@@ -18,9 +18,13 @@ import (
 var _ Responder = (*APIError)(nil)
 
 // ErrorBody represents a simple error message
+//
+// ErrCode is the node's return code (a metanode code.ReturnCode), when the node
+// rejected a tx.
 type ErrorBody struct {
 	Message string `json:"msg"`
 	Log     string `json:"log,omitempty"`
+	ErrCode int    `json:"err_code,omitempty"`
 }
 
 // APIError represents an error with a message and an http status code.
@@ -24,7 +24,6 @@ import (
 	"github.com/ndau/ndau/pkg/ndau/search"
 	"github.com/ndau/ndau/pkg/ndauapi/cfg"
 	"github.com/ndau/ndau/pkg/ndauapi/reqres"
-	"github.com/ndau/ndau/pkg/query"
 	"github.com/ndau/ndau/pkg/tool"
 	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/ndau/ndaumath/pkg/constants"
@@ -89,7 +88,15 @@ func HandleAccounts(cf cfg.Cfg) http.HandlerFunc {
 	}
 }
 
+// MaxAccountsBatch is the most addresses whose accounts may be requested at once.
+const MaxAccountsBatch = 100
+
 func processAccounts(w http.ResponseWriter, node cfg.TMClient, addresses []string) {
+	if len(addresses) > MaxAccountsBatch {
+		reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("%d addresses is more than the limit of %d", len(addresses), MaxAccountsBatch), http.StatusBadRequest))
+		return
+	}
+
 	addies := []address.Address{}
 	invalidAddies := []string{}
 
@@ -105,24 +112,17 @@ func processAccounts(w http.ResponseWriter, node cfg.TMClient, addresses []strin
 		return
 	}
 
+	accounts, _, err := tool.GetAccounts(node, addies)
+	if err != nil {
+		reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("Error fetching address data: %s", err), http.StatusInternalServerError))
+		return
+	}
+
+	// Only create responses for accounts that were found on the blockchain.
 	resp := make(map[string]backing.AccountData)
-	for _, oneAddy := range addies {
-		ad, queryResult, err := tool.GetAccount(node, oneAddy)
-		if err != nil {
-			reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("Error fetching address data: %s", err), http.StatusInternalServerError))
-			return
-		}
-		// Check to see if the account was found on the blockchain or if this record was created
-		// because it wasn't found. Only create responses for accounts that were found.
-		var exists bool
-		_, err = fmt.Sscanf(queryResult.Response.Info, query.AccountInfoFmt, &exists)
-		if err != nil {
-			// if it didn't scan we probably have a compatibility or version issue
-			reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("addr fetch (%s) didn't scan: %s", queryResult.Response.Info, err), http.StatusInternalServerError))
-			return
-		}
-		if exists {
-			resp[oneAddy.String()] = *ad
+	for addr, account := range accounts {
+		if account.Exists {
+			resp[addr] = account.Data
 		}
 	}
 	reqres.RespondJSON(w, reqres.OKResponse(resp))
@@ -250,16 +250,9 @@ func HandleAccountList(cf cfg.Cfg) http.HandlerFunc {
 // to return (default 3000).
 func HandleAccountCurrencySeats(cf cfg.Cfg) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		limit := 3000 // the number of currency seats eligible to vote in the 2nd tier election
-		qp := getQueryParms(r)
-		limitStr := qp["limit"]
-		if limitStr != "" {
-			pi, err := strconv.ParseInt(limitStr, 10, 32)
-			if err != nil {
-				reqres.RespondJSON(w, reqres.NewAPIError("limit must be a valid number", http.StatusBadRequest))
-				return
-			}
-			limit = int(pi)
+		limit, ok := currencySeatsLimit(w, r)
+		if !ok {
+			return
 		}
 
 		accts, err := tool.GetCurrencySeats(cf.Node)
@@ -274,3 +267,43 @@ func HandleAccountCurrencySeats(cf cfg.Cfg) http.HandlerFunc {
 		reqres.RespondJSON(w, reqres.OKResponse(accts))
 	}
 }
+
+// HandleAccountCurrencySeatDetails returns a HandlerFunc that returns the same
+// currency seats as HandleAccountCurrencySeats, each with the date it was acquired
+// and the account's balance.
+func HandleAccountCurrencySeatDetails(cf cfg.Cfg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, ok := currencySeatsLimit(w, r)
+		if !ok {
+			return
+		}
+
+		seats, _, err := tool.GetCurrencySeatDetails(cf.Node)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("Error fetching currency seats: %s", err), http.StatusInternalServerError))
+			return
+		}
+
+		if limit < len(seats) {
+			seats = seats[:limit]
+		}
+		reqres.RespondJSON(w, reqres.OKResponse(seats))
+	}
+}
+
+// currencySeatsLimit gets the maximum number of currency seats to return from a request.
+// If it is invalid, it responds with an error and returns false.
+func currencySeatsLimit(w http.ResponseWriter, r *http.Request) (int, bool) {
+	limit := 3000 // the number of currency seats eligible to vote in the 2nd tier election
+	qp := getQueryParms(r)
+	limitStr := qp["limit"]
+	if limitStr != "" {
+		pi, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewAPIError("limit must be a valid number", http.StatusBadRequest))
+			return 0, false
+		}
+		limit = int(pi)
+	}
+	return limit, true
+}
@@ -0,0 +1,35 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleAccountsLimitsBatch(t *testing.T) {
+	addrs := make([]string, 0, MaxAccountsBatch+1)
+	for i := 0; i <= MaxAccountsBatch; i++ {
+		addrs = append(addrs, streamTestAddress(t).String())
+	}
+	body, err := json.Marshal(addrs)
+	require.NoError(t, err)
+
+	// the request is rejected before the node is asked anything
+	w := httptest.NewRecorder()
+	HandleAccounts(cfg.Cfg{})(w, httptest.NewRequest("POST", "/account/accounts", bytes.NewReader(body)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
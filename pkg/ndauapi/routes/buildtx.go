@@ -0,0 +1,185 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-zoo/bone"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/signature"
+)
+
+// BuildResult is returned by the build endpoint. Tx is the completed but unsigned
+// transaction; SignableBytes are the base64-encoded bytes its signers must sign.
+type BuildResult struct {
+	TxType        string              `json:"txtype"`
+	Tx            metatx.Transactable `json:"tx"`
+	Source        string              `json:"source"`
+	FeeNapu       int64               `json:"fee_napu"`
+	SibNapu       int64               `json:"sib_napu"`
+	SignableBytes string              `json:"signable_bytes"`
+}
+
+// AttachRequest is the body of a request to the attach endpoint: a transaction as
+// returned by the build endpoint, and signatures of its signable bytes.
+type AttachRequest struct {
+	TxType     string                `json:"txtype"`
+	Tx         json.RawMessage       `json:"tx"`
+	Signatures []signature.Signature `json:"signatures"`
+}
+
+// AttachResult is returned by the attach endpoint. Tx is ready to be posted
+// to the submit endpoint for its type.
+type AttachResult struct {
+	TxType string              `json:"txtype"`
+	Tx     metatx.Transactable `json:"tx"`
+	TxHash string              `json:"hash"`
+}
+
+// HandleBuildTx generates a handler that implements the /tx/build endpoint.
+func HandleBuildTx(cf cfg.Cfg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		txtype := bone.GetValue(r, "txtype")
+		mtx, err := TxUnmarshal(txtype, r.Body)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("tx.Data did not unmarshal into a tx", err, http.StatusBadRequest))
+			return
+		}
+		tx, ok := mtx.(ndau.NTransactable)
+		if !ok {
+			reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("%s txs cannot be built", txtype), http.StatusBadRequest))
+			return
+		}
+
+		source, sequence, err := tool.TxSource(cf.Node, tx)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("could not get tx source", err, http.StatusBadRequest))
+			return
+		}
+
+		// Fill in the sequence unless the caller chose one.
+		if tx.GetSequence() == 0 {
+			err = tool.SetSequence(tx, sequence+1)
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr("could not set sequence", err, http.StatusBadRequest))
+				return
+			}
+		}
+
+		// Don't help anyone lock themselves out of their account.
+		if cv, ok := tx.(*ndau.ChangeValidation); ok {
+			_, err = tool.CheckChangeValidation(cf.Node, cv)
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr("refusing to change validation", err, http.StatusBadRequest))
+				return
+			}
+		}
+
+		// An unsigned tx won't validate, but the fee and SIB are reported regardless,
+		// so long as the node could compute them.
+		fee, sib, resp, err := tool.Prevalidate(cf.Node, tx, cf.Logger)
+		if err != nil && (resp == nil || resp.Response.Info == "") {
+			reqres.RespondJSON(w, reqres.NewFromErr("could not estimate fee and sib", err, http.StatusBadRequest))
+			return
+		}
+
+		result := BuildResult{
+			TxType:        metatx.NameOf(tx),
+			Tx:            tx,
+			Source:        source.String(),
+			FeeNapu:       int64(fee),
+			SibNapu:       int64(sib),
+			SignableBytes: base64.StdEncoding.EncodeToString(tx.SignableBytes()),
+		}
+		reqres.RespondJSON(w, reqres.OKResponse(result))
+	}
+}
+
+// HandleAttachTx generates a handler that implements the /tx/attach endpoint.
+func HandleAttachTx(cf cfg.Cfg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req AttachRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("could not decode request", err, http.StatusBadRequest))
+			return
+		}
+		if len(req.Signatures) == 0 {
+			reqres.RespondJSON(w, reqres.NewAPIError("at least one signature is required", http.StatusBadRequest))
+			return
+		}
+
+		mtx, err := TxUnmarshal(req.TxType, bytes.NewReader(req.Tx))
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("tx did not unmarshal into a tx", err, http.StatusBadRequest))
+			return
+		}
+		tx, ok := mtx.(ndau.Signable)
+		if !ok {
+			reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("%s txs cannot be signed", req.TxType), http.StatusBadRequest))
+			return
+		}
+
+		sigs, err := newSignatures(mtx, req.Signatures)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("invalid signature", err, http.StatusBadRequest))
+			return
+		}
+		tx.ExtendSignatures(sigs)
+
+		result := AttachResult{
+			TxType: metatx.NameOf(mtx),
+			Tx:     mtx,
+			TxHash: metatx.Hash(mtx),
+		}
+		reqres.RespondJSON(w, reqres.OKResponse(result))
+	}
+}
+
+// newSignatures returns those of sigs which the tx doesn't already have, without duplicates.
+func newSignatures(tx metatx.Transactable, sigs []signature.Signature) ([]signature.Signature, error) {
+	seen := make(map[string]struct{})
+	if signed, ok := tx.(ndau.Signeder); ok {
+		for _, sig := range signed.GetSignatures() {
+			text, err := sig.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			seen[string(text)] = struct{}{}
+		}
+	}
+
+	out := make([]signature.Signature, 0, len(sigs))
+	for _, sig := range sigs {
+		text, err := sig.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		if len(text) == 0 {
+			return nil, errors.New("empty signature")
+		}
+		if _, ok := seen[string(text)]; ok {
+			continue
+		}
+		seen[string(text)] = struct{}{}
+		out = append(out, sig)
+	}
+	return out, nil
+}
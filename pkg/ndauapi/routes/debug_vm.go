@@ -0,0 +1,74 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-zoo/bone"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/address"
+)
+
+// VMDebugRequest is the body of a request to the vm debug endpoint: a transaction
+// type and transaction, as for the attach endpoint, or the address of a node.
+type VMDebugRequest struct {
+	TxType string          `json:"txtype,omitempty"`
+	Tx     json.RawMessage `json:"tx,omitempty"`
+	Node   string          `json:"node,omitempty"`
+}
+
+// HandleDebugVM generates a handler that implements the /debug/vm endpoint.
+func HandleDebugVM(cf cfg.Cfg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vm := bone.GetValue(r, "vm")
+
+		var req VMDebugRequest
+		if r.ContentLength != 0 {
+			err := json.NewDecoder(r.Body).Decode(&req)
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr("could not decode request", err, http.StatusBadRequest))
+				return
+			}
+		}
+
+		var tx metatx.Transactable
+		if req.TxType != "" {
+			var err error
+			tx, err = TxUnmarshal(req.TxType, bytes.NewReader(req.Tx))
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr("tx did not unmarshal into a tx", err, http.StatusBadRequest))
+				return
+			}
+		}
+		var node address.Address
+		if req.Node != "" {
+			var err error
+			node, err = address.Validate(req.Node)
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("could not validate address: %s", err), http.StatusBadRequest))
+				return
+			}
+		}
+
+		result, _, err := tool.DebugVM(cf.Node, vm, tx, node)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("could not debug vm", err, http.StatusBadRequest))
+			return
+		}
+		reqres.RespondJSON(w, reqres.OKResponse(result))
+	}
+}
@@ -11,35 +11,160 @@ package routes
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"time"
 
+	"github.com/ndau/msgp-well-known-types/wkt"
+	"github.com/ndau/ndau/pkg/ndau"
 	"github.com/ndau/ndau/pkg/ndau/backing"
 	"github.com/ndau/ndau/pkg/ndauapi/cfg"
 	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/ndau/ndaumath/pkg/eai"
 	"github.com/ndau/ndaumath/pkg/types"
+	sv "github.com/ndau/system_vars/pkg/system_vars"
+	"github.com/pkg/errors"
 )
 
 // EAIRateRequest is the type of a single instance of the rate request (the API takes
 // an array).
+//
+// When FromAccount is set, Address must be the address of an existing account, and its
+// weighted average age and lock are taken from the account rather than the request.
 type EAIRateRequest struct {
-	Address string          `json:"address"`
-	WAA     types.Duration  `json:"weightedAverageAge"`
-	Lock    backing.Lock    `json:"lock"`
-	At      types.Timestamp `json:"at"`
+	Address     string          `json:"address"`
+	WAA         types.Duration  `json:"weightedAverageAge"`
+	Lock        backing.Lock    `json:"lock"`
+	At          types.Timestamp `json:"at"`
+	FromAccount bool            `json:"fromAccount,omitempty"`
 }
 
 // EAIRateResponse is a single instance of a rate response (it returns an array of them)
+//
+// EAIRate is the gross rate; NetEAIRate is what remains of it after EAI fees.
+//
+// EAI is only set for FromAccount requests: it is the EAI the account would be credited
+// if a CreditEAI tx were applied at the requested time.
 type EAIRateResponse struct {
-	Address string `json:"address"`
-	EAIRate uint64 `json:"eairate"`
+	Address    string      `json:"address"`
+	EAIRate    uint64      `json:"eairate"`
+	NetEAIRate uint64      `json:"neteairate"`
+	EAI        *types.Ndau `json:"eai,omitempty"`
+}
+
+// eaiParams are the system variables and features which determine how the chain pays EAI.
+type eaiParams struct {
+	*ndau.EAIParams
+
+	// enough of the chain's state to recognize exchange accounts
+	state backing.State
+}
+
+// getEAIParams fetches what we need to know to calculate EAI the way CreditEAI.Apply does.
+func getEAIParams(cf cfg.Cfg) (*eaiParams, error) {
+	svs, _, err := tool.Sysvars(
+		cf.Node,
+		sv.UnlockedRateTableName,
+		sv.LockedRateTableName,
+		sv.EAIFeeTableName,
+		sv.EAIOvertime,
+		sv.AccountAttributesName,
+		sv.ExchangeEAIScriptName,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching system variables")
+	}
+
+	features := make(map[string]bool)
+	for _, feature := range []string{"UseCurrentLockBonus", "FixEAIUnlockBug"} {
+		features[feature], err = tool.IsFeatureActive(cf.Node, feature)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("querying %s feature", feature))
+		}
+	}
+
+	params, err := ndau.NewEAIParams(svs, func(feature string) bool {
+		return features[feature]
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &eaiParams{
+		EAIParams: params,
+		state: backing.State{
+			Sysvars:  svs,
+			Accounts: make(map[string]backing.AccountData),
+		},
+	}, nil
+}
+
+// currentLock applies the current lock bonus to a lock, if the chain does so.
+func (p *eaiParams) currentLock(lock *backing.Lock) *backing.Lock {
+	if lock == nil || lock.NoticePeriod == 0 || !p.UseCurrentLockBonus {
+		return lock
+	}
+	current := *lock
+	current.Bonus = p.LockedTable.RateAt(lock.NoticePeriod)
+	return &current
+}
+
+// exchangeRate calculates the flat rate of an exchange account with the exchange EAI script.
+func (p *eaiParams) exchangeRate(cf cfg.Cfg) func(backing.AccountData) (eai.Rate, error) {
+	return func(acct backing.AccountData) (eai.Rate, error) {
+		var script wkt.Bytes
+		_, err := script.UnmarshalMsg(p.state.Sysvars[sv.ExchangeEAIScriptName])
+		if err != nil {
+			return 0, errors.Wrap(err, "Could not fetch ExchangeEAIScript system variable")
+		}
+		sib, _, err := tool.GetSIB(cf.Node)
+		if err != nil {
+			return 0, errors.Wrap(err, "Could not fetch SIB")
+		}
+		return ndau.ExchangeEAIRate(script, acct, sib.SIB)
+	}
+}
+
+// project calculates the rate an account would earn and the EAI it would be credited
+// at the given time, as CreditEAI.Apply would.
+func (p *eaiParams) project(
+	cf cfg.Cfg, addr address.Address, acct backing.AccountData, at types.Timestamp,
+) (eai.Rate, types.Ndau, error) {
+	p.state.Accounts[addr.String()] = acct
+	isExchangeAccount, err := p.state.AccountHasAttribute(addr, sv.AccountAttributeExchange)
+	if err != nil {
+		return 0, 0, err
+	}
+	var exchangeRate func(backing.AccountData) (eai.Rate, error)
+	if isExchangeAccount {
+		exchangeRate = p.exchangeRate(cf)
+	}
+
+	// the calculation updates the lock bonus, which mustn't touch the caller's account
+	if acct.Lock != nil {
+		lock := *acct.Lock
+		acct.Lock = &lock
+	}
+	result, err := p.CalculateCreditEAI(&acct, at, exchangeRate)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "calculating EAI")
+	}
+
+	rate := eai.CalculateEAIRate(acct.WeightedAverageAge, acct.Lock, result.Table, at)
+	return rate, result.Net, nil
 }
 
 // GetEAIRate returns the EAI rates for a collection of rate requests, each of which has
 // an address (merely a string that is not examined, simply copied to the output), a
-// weighted average age, and optional lock information (if the account is locked)
+// weighted average age, and optional lock information (if the account is locked).
+//
+// Requests with FromAccount set instead use the state of the account at that address,
+// and also project the EAI it would be credited.
+//
+// Rates are calculated from the chain's current rate tables. Each response carries both
+// the gross rate and the rate net of EAI fees.
 func GetEAIRate(cf cfg.Cfg) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var requests []EAIRateRequest
@@ -58,23 +183,39 @@ func GetEAIRate(cf cfg.Cfg) http.HandlerFunc {
 			return
 		}
 
-		// FIXME: need to actually query the chaos chain
-		// These are just the default values
-		unlockedTable := eai.DefaultUnlockedEAI
-
-		// Below is code that came from the ndau app but can't run because we don't have
-		// app or config objects.
-
-		// sc, err := cache.NewSystemCache(config)
-		// if err != nil {
-		// 	reqres.RespondJSON(w, reqres.NewFromErr("couldn't create cache", err, http.StatusInternalServerError))
-		// 	return
-		// }
+		// look up every account we need at once
+		addrs := make([]address.Address, 0)
+		for _, request := range requests {
+			if !request.FromAccount {
+				continue
+			}
+			addr, err := address.Validate(request.Address)
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr(fmt.Sprintf("could not validate address %s", request.Address), err, http.StatusBadRequest))
+				return
+			}
+			addrs = append(addrs, addr)
+		}
+		var accounts map[string]backing.AccountData
+		if len(addrs) > 0 {
+			found, _, err := tool.GetAccounts(cf.Node, addrs)
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr("could not get accounts", err, http.StatusInternalServerError))
+				return
+			}
+			accounts = make(map[string]backing.AccountData)
+			for addr, account := range found {
+				if account.Exists {
+					accounts[addr] = account.Data
+				}
+			}
+		}
 
-		// err = app.System(sv.UnlockedRateTableName, unlockedTable)
-		// if err != nil {
-		// 	return errors.Wrap(err, fmt.Sprintf("Error fetching %s system variable in CreditEAI.Apply", sv.UnlockedRateTableName))
-		// }
+		params, err := getEAIParams(cf)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("could not get EAI parameters", err, http.StatusInternalServerError))
+			return
+		}
 
 		now, err := types.TimestampFrom(time.Now())
 		if err != nil {
@@ -83,14 +224,38 @@ func GetEAIRate(cf cfg.Cfg) http.HandlerFunc {
 		}
 
 		response := make([]EAIRateResponse, len(requests))
-		for i := range requests {
-			response[i].Address = requests[i].Address
-			if requests[i].At == 0 {
-				requests[i].At = now
+		for i, request := range requests {
+			response[i].Address = request.Address
+			if request.At == 0 {
+				request.At = now
+			}
+
+			var rate eai.Rate
+			if request.FromAccount {
+				addr, _ := address.Validate(request.Address)
+				acct, ok := accounts[addr.String()]
+				if !ok {
+					reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("no such account: %s", request.Address), http.StatusNotFound))
+					return
+				}
+				var award types.Ndau
+				rate, award, err = params.project(cf, addr, acct, request.At)
+				if err != nil {
+					reqres.RespondJSON(w, reqres.NewFromErr(fmt.Sprintf("could not project EAI for %s", request.Address), err, http.StatusInternalServerError))
+					return
+				}
+				response[i].EAI = &award
+			} else {
+				rate = eai.CalculateEAIRate(request.WAA, params.currentLock(&request.Lock), params.UnlockedTable, request.At)
+			}
+
+			net, err := params.AfterFees(int64(rate))
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr("could not apply EAI fees", err, http.StatusInternalServerError))
+				return
 			}
-			response[i].EAIRate = uint64(
-				eai.CalculateEAIRate(requests[i].WAA, &requests[i].Lock, unlockedTable, requests[i].At),
-			)
+			response[i].EAIRate = uint64(rate)
+			response[i].NetEAIRate = uint64(net)
 		}
 		reqres.RespondJSON(w, reqres.OKResponse(response))
 	}
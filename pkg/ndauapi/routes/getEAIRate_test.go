@@ -0,0 +1,90 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"testing"
+
+	"github.com/ndau/metanode/pkg/meta/app/code"
+	metast "github.com/ndau/metanode/pkg/meta/state"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/ndau/backing"
+	"github.com/ndau/ndau/pkg/ndauapi/mock"
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/constants"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/ndau/ndaumath/pkg/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestProjectMatchesCreditEAI(t *testing.T) {
+	// borrow the system variables of a mock genesis
+	genesis, _, err := ndau.InitMockApp()
+	require.NoError(t, err)
+	sysvars := genesis.GetState().(*backing.State).Sysvars
+
+	nodePublic, nodePrivate, err := signature.Generate(signature.Ed25519, nil)
+	require.NoError(t, err)
+	node, err := address.Generate(address.KindUser, nodePublic.KeyBytes())
+	require.NoError(t, err)
+	addr := streamTestAddress(t)
+
+	var app *ndau.App
+	cf := mock.Cfg(t, func(a abci.Application) {
+		app = a.(*ndau.App)
+		err := app.UpdateStateImmediately(func(stI metast.State) (metast.State, error) {
+			st := stI.(*backing.State)
+			st.Sysvars = sysvars
+			st.Nodes[node.String()] = backing.Node{Active: true}
+			st.Accounts[node.String()] = backing.AccountData{
+				ValidationKeys: []signature.PublicKey{nodePublic},
+			}
+			// the lock's bonus is stale, so the chain's current bonus must be used
+			st.Accounts[addr.String()] = backing.AccountData{
+				Balance:            1000 * constants.QuantaPerUnit,
+				UncreditedEAI:      5 * constants.QuantaPerUnit,
+				WeightedAverageAge: 60 * types.Day,
+				Lock:               backing.NewLock(180*types.Day, nil),
+				DelegationNode:     &node,
+			}
+			st.Delegates[node.String()] = map[string]struct{}{addr.String(): {}}
+			return st, nil
+		})
+		require.NoError(t, err)
+	})
+
+	acct, _, err := tool.GetAccount(cf.Node, addr)
+	require.NoError(t, err)
+	require.NotNil(t, acct)
+
+	at := types.Timestamp(45 * types.Day)
+	params, err := getEAIParams(cf)
+	require.NoError(t, err)
+	_, award, err := params.project(cf, addr, *acct, at)
+	require.NoError(t, err)
+	require.NotZero(t, award)
+
+	bytes, err := metatx.Marshal(ndau.NewCreditEAI(node, 1, nodePrivate), ndau.TxIDs)
+	require.NoError(t, err)
+	app.BeginBlock(abci.RequestBeginBlock{
+		Header: abci.Header{Time: at.AsTime(), Height: 1},
+		Hash:   []byte("projectMatchesCreditEAI"),
+	})
+	resp := app.DeliverTx(abci.RequestDeliverTx{Tx: bytes})
+	require.Equal(t, code.OK, code.ReturnCode(resp.Code), resp.Log)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	credited := app.GetState().(*backing.State).Accounts[addr.String()]
+	require.Equal(t, acct.Balance+award, credited.Balance)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/ndau/ndau/pkg/ndauapi/cfg"
 	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
 )
 
 // HealthStatus gives us the ability to add more status information later without messing up clients
@@ -23,8 +24,14 @@ type HealthStatus struct {
 }
 
 // HealthResponse is the response from the /health endpoint.
+//
+// Index is only present when the node has a search index, Pool only when the API is
+// using a pool of nodes, and Cache only when it caches query results.
 type HealthResponse struct {
-	Ndau HealthStatus
+	Ndau  HealthStatus
+	Index *HealthStatus       `json:",omitempty"`
+	Pool  []cfg.PoolNodeState `json:",omitempty"`
+	Cache *cfg.CacheStats     `json:",omitempty"`
 }
 
 // GetHealth returns health indicators from Tendermint.
@@ -37,6 +44,35 @@ func GetHealth(cf cfg.Cfg) http.HandlerFunc {
 			return
 		}
 
-		reqres.RespondJSON(w, reqres.OKResponse(HealthResponse{HealthStatus{"Ok"}}))
+		response := HealthResponse{Ndau: HealthStatus{"Ok"}}
+
+		// Searches may return incomplete results while initial indexing catches up.
+		behind, _, err := tool.IndexBehind(cf.Node)
+		if err == nil {
+			response.Index = &HealthStatus{"Ok"}
+			if behind {
+				response.Index.Status = "Behind"
+			}
+		}
+
+		node := cf.Node
+		if cache, ok := node.(*cfg.Cache); ok {
+			stats := cache.Stats()
+			response.Cache = &stats
+			node = cache.TMClient
+		}
+		if pool, ok := node.(*cfg.Pool); ok {
+			response.Pool = pool.State()
+			eligible := false
+			for _, node := range response.Pool {
+				eligible = eligible || node.Eligible
+			}
+			if !eligible {
+				// We're getting by with nodes which are behind or catching up.
+				response.Ndau.Status = "Degraded"
+			}
+		}
+
+		reqres.RespondJSON(w, reqres.OKResponse(response))
 	}
 }
@@ -0,0 +1,91 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-zoo/bone"
+	"github.com/ndau/ndau/pkg/ndau/search"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/address"
+)
+
+// NodeRewardHistory is used by the node rewards endpoint to return the nominations
+// a node has won, and whether and when each was claimed.
+type NodeRewardHistory struct {
+	Items []search.NodeRewardValueData
+	Next  string
+}
+
+// HandleNodeRewardHistory returns a HandlerFunc that returns the node reward history
+// of the node whose address is specified in the URL.
+func HandleNodeRewardHistory(cf cfg.Cfg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addressString := bone.GetValue(r, "address")
+		if addressString == "" {
+			reqres.RespondJSON(w, reqres.NewAPIError("address parameter required", http.StatusBadRequest))
+			return
+		}
+
+		addr, err := address.Validate(addressString)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("could not validate address: %s", err), http.StatusBadRequest))
+			return
+		}
+
+		limit, afters, err := getPagingParams(r, 100)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("paging parms", err, http.StatusBadRequest))
+			return
+		}
+
+		after := uint64(0)
+		if afters != "" {
+			after, err = strconv.ParseUint(afters, 10, 64)
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr("parsing 'after'", err, http.StatusBadRequest))
+				return
+			}
+		}
+
+		params := search.NodeRewardHistoryParams{
+			Address:     addr.String(),
+			Limit:       limit,
+			AfterHeight: after,
+		}
+
+		nrhr, err := tool.NodeRewardHistory(cf.Node, params)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("Error fetching node reward history: %s", err), http.StatusInternalServerError))
+			return
+		}
+
+		result := NodeRewardHistory{Items: nrhr.Rewards}
+		if nrhr.More && len(result.Items) > 0 {
+			next, err := url.Parse(".")
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr("could not parse identity url", err, http.StatusInternalServerError))
+				return
+			}
+			query := r.URL.Query()
+			query.Set("after", fmt.Sprint(result.Items[len(result.Items)-1].Height))
+			next.RawQuery = query.Encode()
+			result.Next = r.URL.ResolveReference(next).String()
+		}
+
+		reqres.RespondJSON(w, reqres.OKResponse(result))
+	}
+}
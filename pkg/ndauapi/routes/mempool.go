@@ -0,0 +1,131 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+	"net/http"
+
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/tendermint/tendermint/rpc/client"
+)
+
+// MempoolTx describes a tx waiting in the mempool.
+//
+// Source is empty for txs which don't come from an account.
+type MempoolTx struct {
+	TxType   string `json:"txtype"`
+	TxHash   string `json:"hash"`
+	Source   string `json:"source,omitempty"`
+	Sequence uint64 `json:"sequence"`
+}
+
+// MempoolResult is returned by the mempool endpoint.
+//
+// Count and TotalBytes describe the whole mempool. Txs is only listed on request;
+// it includes at most the first 100 txs in the mempool, and Truncated is set if
+// there were more.
+type MempoolResult struct {
+	Count      int         `json:"count"`
+	TotalBytes int64       `json:"total_bytes"`
+	Txs        []MempoolTx `json:"txs,omitempty"`
+	Truncated  bool        `json:"truncated,omitempty"`
+}
+
+// HandleMempool generates a handler that implements the /node/mempool endpoint.
+func HandleMempool(cf cfg.Cfg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		listTxs := query.Get("txs") != ""
+
+		var source *address.Address
+		if sourceString := query.Get("source"); sourceString != "" {
+			addr, err := address.Validate(sourceString)
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("could not validate address: %s", err), http.StatusBadRequest))
+				return
+			}
+			source = &addr
+			listTxs = true
+		}
+
+		if !listTxs {
+			num, err := cf.Node.NumUnconfirmedTxs()
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr("could not count unconfirmed txs", err, http.StatusInternalServerError))
+				return
+			}
+			reqres.RespondJSON(w, reqres.OKResponse(MempoolResult{
+				Count:      num.Total,
+				TotalBytes: num.TotalBytes,
+			}))
+			return
+		}
+
+		unconfirmed, err := cf.Node.UnconfirmedTxs(mempoolScanLimit)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("could not get unconfirmed txs", err, http.StatusInternalServerError))
+			return
+		}
+		result := MempoolResult{
+			Count:      unconfirmed.Total,
+			TotalBytes: unconfirmed.TotalBytes,
+			Txs:        make([]MempoolTx, 0, len(unconfirmed.Txs)),
+			Truncated:  unconfirmed.Total > len(unconfirmed.Txs),
+		}
+		for offset, txbytes := range unconfirmed.Txs {
+			txdata, err := buildTransactionData("", txbytes, 0, offset, "")
+			if err != nil {
+				// Anything in the mempool got there by passing CheckTx, so this shouldn't happen.
+				continue
+			}
+			mtx := MempoolTx{
+				TxType: txdata.TxType,
+				TxHash: txdata.TxHash,
+			}
+			if seqr, ok := txdata.TxData.(ndau.Sequencer); ok {
+				mtx.Sequence = seqr.GetSequence()
+			}
+			// this fails for txs which don't come from an account
+			if txsource, err := mempoolTxSource(cf.Node, txdata.TxData); err == nil {
+				mtx.Source = txsource.String()
+			}
+			if source != nil && mtx.Source != source.String() {
+				continue
+			}
+			result.Txs = append(result.Txs, mtx)
+		}
+		reqres.RespondJSON(w, reqres.OKResponse(result))
+	}
+}
+
+// mempoolTxSource gets the source address of a tx in the mempool.
+//
+// Most txs name their source themselves, so we can find it without asking the node. Only the
+// rest, mostly those whose source is a system account, cost a query; the node's cache keeps
+// their answers until the next block.
+func mempoolTxSource(node client.ABCIClient, tx metatx.Transactable) (address.Address, error) {
+	switch tx.(type) {
+	case *ndau.Burn, *ndau.ChangeRecoursePeriod, *ndau.ChangeValidation, *ndau.ClaimNodeReward,
+		*ndau.CreateChildAccount, *ndau.CreditEAI, *ndau.Delegate, *ndau.Lock, *ndau.Notify,
+		*ndau.RegisterNode, *ndau.ResolveStake, *ndau.SetRewardsDestination, *ndau.SetStakeRules,
+		*ndau.SetValidation, *ndau.Stake, *ndau.Transfer, *ndau.TransferAndLock,
+		*ndau.UnregisterNode, *ndau.Unstake:
+		// these never look at the app
+		return tx.(ndau.Sourcer).GetSource(nil)
+	}
+	source, _, err := tool.TxSource(node, tx)
+	return source, err
+}
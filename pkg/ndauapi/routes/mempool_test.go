@@ -0,0 +1,87 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleMempool(t *testing.T) {
+	alice := streamTestAddress(t)
+	bob := streamTestAddress(t)
+	rfeSource := streamTestAddress(t)
+
+	node := &fakeNode{sources: make(map[string]address.Address)}
+	hashes := make([]string, 0)
+	add := func(tx metatx.Transactable) string {
+		bytes, err := metatx.Marshal(tx, ndau.TxIDs)
+		require.NoError(t, err)
+		node.mempool = append(node.mempool, bytes)
+		hashes = append(hashes, metatx.Hash(tx))
+		return string(bytes)
+	}
+	add(ndau.NewTransfer(alice, bob, 1, 1))
+	add(ndau.NewLock(bob, 1, 2))
+	add(ndau.NewTransfer(alice, bob, 1, 3))
+	node.sources[add(ndau.NewReleaseFromEndowment(alice, 1, 4))] = rfeSource
+	node.mempool = append(node.mempool, []byte("not a tx"))
+
+	handler := HandleMempool(cfg.Cfg{Node: node})
+	mempool := func(target string) MempoolResult {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", target, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var result MempoolResult
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+		return result
+	}
+
+	t.Run("all", func(t *testing.T) {
+		node.queries = 0
+		result := mempool("/node/mempool?txs=1")
+		require.Equal(t, len(node.mempool), result.Count)
+		require.False(t, result.Truncated)
+		require.Equal(t, []MempoolTx{
+			{TxType: "Transfer", TxHash: hashes[0], Source: alice.String(), Sequence: 1},
+			{TxType: "Lock", TxHash: hashes[1], Source: bob.String(), Sequence: 2},
+			{TxType: "Transfer", TxHash: hashes[2], Source: alice.String(), Sequence: 3},
+			{TxType: "ReleaseFromEndowment", TxHash: hashes[3], Source: rfeSource.String(), Sequence: 4},
+		}, result.Txs)
+		// only the system tx needs the node to find its source
+		require.Equal(t, 1, node.queries)
+	})
+
+	t.Run("source", func(t *testing.T) {
+		result := mempool("/node/mempool?source=" + alice.String())
+		require.Equal(t, len(node.mempool), result.Count)
+		require.Equal(t, 2, len(result.Txs))
+		for _, tx := range result.Txs {
+			require.Equal(t, "Transfer", tx.TxType)
+			require.Equal(t, alice.String(), tx.Source)
+		}
+		require.Equal(t, uint64(1), result.Txs[0].Sequence)
+		require.Equal(t, uint64(3), result.Txs[1].Sequence)
+	})
+
+	t.Run("bad source", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", "/node/mempool?source=foo", nil))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
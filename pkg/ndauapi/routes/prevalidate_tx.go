@@ -21,6 +21,9 @@ import (
 
 // PrevalidateResult returns the prevalidation status of a transaction without
 // attempting to commit it.
+//
+// ErrCode is the node's return code (a metanode code.ReturnCode) for a tx which
+// failed prevalidation, or -1 if the node could not be asked.
 type PrevalidateResult struct {
 	FeeNapu int64  `json:"fee_napu"`
 	SibNapu int64  `json:"sib_napu"`
@@ -63,13 +66,16 @@ func HandlePrevalidateTx(cf cfg.Cfg) http.HandlerFunc {
 			code = http.StatusAccepted
 		} else {
 			// run the prevalidation query
-			fee, sib, _, err := tool.Prevalidate(cf.Node, tx, cf.Logger)
+			fee, sib, resp, err := tool.Prevalidate(cf.Node, tx, cf.Logger)
 			result.FeeNapu = int64(fee)
 			result.SibNapu = int64(sib)
 			if err != nil {
 				cf.Logger.WithError(err).Info("prevalidate returned an error")
 				result.Err = err.Error()
 				result.ErrCode = -1
+				if resp != nil && resp.Response.Code != 0 {
+					result.ErrCode = int(resp.Response.Code)
+				}
 				result.Code = EndpointResultFail
 				code = http.StatusBadRequest
 			}
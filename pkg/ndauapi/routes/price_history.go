@@ -18,6 +18,8 @@ import (
 	"github.com/ndau/ndau/pkg/ndauapi/cfg"
 	"github.com/ndau/ndau/pkg/ndauapi/reqres"
 	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/types"
+	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/rpc/client"
 )
 
@@ -37,33 +39,89 @@ func HandlePriceMarketHistory(cf cfg.Cfg) http.HandlerFunc {
 	return priceHistory(cf, tool.MarketPriceHistory)
 }
 
-func priceHistory(
-	cf cfg.Cfg,
-	tf func(
-		node client.ABCIClient,
-		params srch.PriceQueryParams,
-	) (srch.PriceQueryResults, error),
-) func(http.ResponseWriter, *http.Request) {
+// HandlePriceNAVHistory handles endowment NAV history
+func HandlePriceNAVHistory(cf cfg.Cfg) http.HandlerFunc {
+	return priceHistory(cf, tool.EndowmentNAVHistory)
+}
+
+// SIBHistoryResults encapsulates a set of SIB history data in a json-friendly way
+type SIBHistoryResults struct {
+	Items []srch.SIBQueryResult `json:"items"`
+	Next  string                `json:"next"`
+}
+
+// HandlePriceSIBHistory handles SIB and floor price history
+func HandlePriceSIBHistory(cf cfg.Cfg) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		defer r.Body.Close()
-		bdata, err := ioutil.ReadAll(r.Body)
+		params, err := readPriceQueryParams(r)
 		if err != nil {
 			reqres.RespondJSON(w, reqres.NewFromErr("reading params", err, http.StatusBadRequest))
 			return
 		}
 
-		var params srch.PriceQueryParams
-		err = json.Unmarshal(bdata, &params)
+		sqr, err := tool.SIBHistory(cf.Node, params)
 		if err != nil {
-			reqres.RespondJSON(w, reqres.NewFromErr("unmarshaling params", err, http.StatusBadRequest))
+			reqres.RespondJSON(w, reqres.NewFromErr("searching history", err, http.StatusInternalServerError))
 			return
 		}
 
-		if params.Limit == 0 {
-			params.Limit = 100
+		out := SIBHistoryResults{
+			Items: sqr.Items,
+		}
+		if sqr.More && len(sqr.Items) > 0 {
+			out.Next = nextPriceQueryParams(params, sqr.Items[len(sqr.Items)-1].Timestamp)
 		}
-		if params.Limit > 1000 {
-			params.Limit = 1000
+
+		reqres.RespondJSON(w, reqres.OKResponse(out))
+	}
+}
+
+// readPriceQueryParams reads price query params from the request body, clamping the limit
+func readPriceQueryParams(r *http.Request) (params srch.PriceQueryParams, err error) {
+	defer r.Body.Close()
+	bdata, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return params, errors.Wrap(err, "reading request body")
+	}
+
+	err = json.Unmarshal(bdata, &params)
+	if err != nil {
+		return params, errors.Wrap(err, "unmarshaling params")
+	}
+
+	if params.Limit == 0 {
+		params.Limit = 100
+	}
+	if params.Limit > 1000 {
+		params.Limit = 1000
+	}
+	return params, nil
+}
+
+// nextPriceQueryParams returns the encoded params for the page following the given timestamp
+//
+// If they can't be encoded, it returns an empty string; this is a convenience, not essential.
+func nextPriceQueryParams(params srch.PriceQueryParams, last types.Timestamp) string {
+	params.After = srch.RangeEndpoint{Timestamp: last}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func priceHistory(
+	cf cfg.Cfg,
+	tf func(
+		node client.ABCIClient,
+		params srch.PriceQueryParams,
+	) (srch.PriceQueryResults, error),
+) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := readPriceQueryParams(r)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("reading params", err, http.StatusBadRequest))
+			return
 		}
 
 		pqr, err := tf(cf.Node, params)
@@ -76,12 +134,7 @@ func priceHistory(
 			Items: pqr.Items,
 		}
 		if pqr.More && len(pqr.Items) > 0 {
-			params.After = srch.RangeEndpoint{Timestamp: pqr.Items[len(pqr.Items)-1].Timestamp}
-			data, err := json.Marshal(params)
-			if err == nil {
-				// otherwise, just forget it; this is a convenience, not essential
-				out.Next = string(data)
-			}
+			out.Next = nextPriceQueryParams(params, pqr.Items[len(pqr.Items)-1].Timestamp)
 		}
 
 		reqres.RespondJSON(w, reqres.OKResponse(out))
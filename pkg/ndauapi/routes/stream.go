@@ -0,0 +1,602 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau/backing"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/constants"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// These are the types of events that stream clients can receive.
+const (
+	StreamEventBlock   = "block"
+	StreamEventTx      = "tx"
+	StreamEventAddress = "address"
+	StreamEventError   = "error"
+)
+
+// StreamBlock summarizes a newly committed block.
+type StreamBlock struct {
+	Height    int64
+	Hash      string
+	Timestamp string
+	NumTxs    int
+}
+
+// StreamAddress reports that transactions in a block touched a watched address,
+// along with the account's data after the block.
+type StreamAddress struct {
+	Address  string
+	TxHashes []string
+	Account  *backing.AccountData
+}
+
+// StreamEvent is a single message pushed to stream clients.
+// The field set depends on Type.
+type StreamEvent struct {
+	Type    string
+	Height  int64            `json:",omitempty"`
+	Block   *StreamBlock     `json:",omitempty"`
+	Tx      *TransactionData `json:",omitempty"`
+	Address *StreamAddress   `json:",omitempty"`
+	Error   string           `json:",omitempty"`
+}
+
+// StreamWatchRequest may be sent by websocket clients to change the set of addresses they watch.
+type StreamWatchRequest struct {
+	Watch   []string
+	Unwatch []string
+}
+
+// MaxStreamWatch is the most addresses a single stream client may watch.
+const MaxStreamWatch = 100
+
+// How many events may queue up for a client before we give up on it.
+const streamBuffer = 256
+
+// The subscriber name we use for tendermint event subscriptions.
+const streamSubscriber = "ndauapi"
+
+// A StreamHub fans a single tendermint event subscription out to any number of stream clients.
+//
+// It subscribes when the first client connects, and again whenever the subscription is lost.
+type StreamHub struct {
+	cf cfg.Cfg
+
+	lock    sync.Mutex
+	running bool
+	subs    map[*streamSub]struct{}
+}
+
+// A streamSub is a single stream client.
+type streamSub struct {
+	out     chan StreamEvent
+	dropped chan struct{} // closed when the hub is done with this client
+
+	lock   sync.Mutex
+	events map[string]bool
+	watch  map[string]address.Address
+}
+
+// NewStreamHub creates a StreamHub which gets its events from the configured node.
+func NewStreamHub(cf cfg.Cfg) *StreamHub {
+	if cf.Logger == nil {
+		cf.Logger = logrus.New()
+	}
+	return &StreamHub{
+		cf:   cf,
+		subs: make(map[*streamSub]struct{}),
+	}
+}
+
+func (h *StreamHub) subscribe(events map[string]bool, watch map[string]address.Address) (*streamSub, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if !h.running {
+		err := h.start()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sub := &streamSub{
+		out:     make(chan StreamEvent, streamBuffer),
+		dropped: make(chan struct{}),
+		events:  events,
+		watch:   watch,
+	}
+	h.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+func (h *StreamHub) unsubscribe(sub *streamSub) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.drop(sub)
+}
+
+// drop must be called with the lock held.
+func (h *StreamHub) drop(sub *streamSub) {
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		close(sub.dropped)
+	}
+}
+
+// start must be called with the lock held.
+func (h *StreamHub) start() error {
+	// The HTTP client only talks websocket to tendermint once it's been started.
+	if s, ok := h.cf.Node.(interface {
+		IsRunning() bool
+		Start() error
+	}); ok && !s.IsRunning() {
+		err := s.Start()
+		if err != nil {
+			return errors.Wrap(err, "starting node event client")
+		}
+	}
+
+	query := tmtypes.EventQueryNewBlock.String()
+	// Clear out a subscription we may have lost track of; the node only allows one per query.
+	h.cf.Node.Unsubscribe(context.Background(), streamSubscriber, query)
+	events, err := h.cf.Node.Subscribe(context.Background(), streamSubscriber, query, streamBuffer)
+	if err != nil {
+		return errors.Wrap(err, "subscribing to new blocks")
+	}
+
+	h.running = true
+	go h.run(events)
+	return nil
+}
+
+func (h *StreamHub) run(events <-chan rpctypes.ResultEvent) {
+	for event := range events {
+		data, ok := event.Data.(tmtypes.EventDataNewBlock)
+		if !ok || data.Block == nil {
+			continue
+		}
+		h.publish(data.Block)
+	}
+
+	// We lost the subscription.  Let everyone go so they can reconnect, which resubscribes.
+	h.cf.Logger.Warn("stream lost its node event subscription")
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.running = false
+	for sub := range h.subs {
+		h.drop(sub)
+	}
+}
+
+// publish sends the events for a newly committed block to every client that wants them.
+func (h *StreamHub) publish(block *tmtypes.Block) {
+	height := block.Height
+	timestamp := block.Time.Format(constants.TimestampFormat)
+
+	events := []StreamEvent{{
+		Type:   StreamEventBlock,
+		Height: height,
+		Block: &StreamBlock{
+			Height:    height,
+			Hash:      fmt.Sprintf("%x", block.Hash()),
+			Timestamp: timestamp,
+			NumTxs:    len(block.Data.Txs),
+		},
+	}}
+
+	touched := make(map[string][]string)
+	for offset, txbytes := range block.Data.Txs {
+		txdata, err := buildTransactionData(timestamp, txbytes, height, offset, "")
+		if err != nil {
+			h.cf.Logger.WithError(err).WithField("height", height).Warn("stream could not decode tx")
+			continue
+		}
+		events = append(events, StreamEvent{
+			Type:   StreamEventTx,
+			Height: height,
+			Tx:     txdata,
+		})
+		for _, addr := range txAddresses(txdata.TxData) {
+			touched[addr] = append(touched[addr], txdata.TxHash)
+		}
+	}
+
+	h.lock.Lock()
+	subs := make([]*streamSub, 0, len(h.subs))
+	for sub := range h.subs {
+		subs = append(subs, sub)
+	}
+	h.lock.Unlock()
+
+	// Look up each touched account at most once, and only if somebody is watching it.
+	accounts := make(map[string]*StreamAddress)
+	lookup := make([]address.Address, 0)
+	for _, sub := range subs {
+		for addr, a := range sub.watched() {
+			txhashes, ok := touched[addr]
+			if !ok || accounts[addr] != nil {
+				continue
+			}
+			accounts[addr] = &StreamAddress{
+				Address:  addr,
+				TxHashes: txhashes,
+			}
+			lookup = append(lookup, a)
+		}
+	}
+	if len(lookup) > 0 {
+		found, _, err := tool.GetAccounts(h.cf.Node, lookup)
+		if err != nil {
+			h.cf.Logger.WithError(err).Warn("stream could not get accounts")
+		}
+		for addr, account := range found {
+			if sa, ok := accounts[addr]; ok {
+				ad := account.Data
+				sa.Account = &ad
+			}
+		}
+	}
+
+	for _, sub := range subs {
+		wants := sub.wants()
+		for _, event := range events {
+			if wants[event.Type] && !h.send(sub, event) {
+				break
+			}
+		}
+		if !wants[StreamEventAddress] {
+			continue
+		}
+		watched := sub.watched()
+		addrs := make([]string, 0, len(watched))
+		for addr := range watched {
+			addrs = append(addrs, addr)
+		}
+		sort.Strings(addrs)
+		for _, addr := range addrs {
+			sa := accounts[addr]
+			if sa == nil {
+				continue
+			}
+			if !h.send(sub, StreamEvent{Type: StreamEventAddress, Height: height, Address: sa}) {
+				break
+			}
+		}
+	}
+}
+
+// send queues an event for a client without blocking.
+// A client which has fallen too far behind is dropped; it can reconnect.
+func (h *StreamHub) send(sub *streamSub, event StreamEvent) bool {
+	select {
+	case sub.out <- event:
+		return true
+	default:
+		h.cf.Logger.Warn("stream client fell behind; dropping it")
+		h.unsubscribe(sub)
+		return false
+	}
+}
+
+func (sub *streamSub) wants() map[string]bool {
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+	wants := make(map[string]bool, len(sub.events))
+	for event, want := range sub.events {
+		wants[event] = want
+	}
+	return wants
+}
+
+func (sub *streamSub) watched() map[string]address.Address {
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+	watched := make(map[string]address.Address, len(sub.watch))
+	for addr, a := range sub.watch {
+		watched[addr] = a
+	}
+	return watched
+}
+
+// update applies a watch request.  Watching anything turns on address events.
+//
+// A request which would leave the client watching more than MaxStreamWatch addresses
+// is rejected.
+func (sub *streamSub) update(req StreamWatchRequest) error {
+	watch, err := validateAddresses(req.Watch)
+	if err != nil {
+		return err
+	}
+	unwatch, err := validateAddresses(req.Unwatch)
+	if err != nil {
+		return err
+	}
+
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+	watching := len(sub.watch)
+	for addr := range watch {
+		if _, ok := sub.watch[addr]; !ok {
+			if _, ok := unwatch[addr]; !ok {
+				watching++
+			}
+		}
+	}
+	for addr := range unwatch {
+		if _, ok := sub.watch[addr]; ok {
+			watching--
+		}
+	}
+	err = checkStreamWatch(watching)
+	if err != nil {
+		return err
+	}
+
+	for addr, a := range watch {
+		sub.watch[addr] = a
+	}
+	for addr := range unwatch {
+		delete(sub.watch, addr)
+	}
+	if len(watch) > 0 {
+		sub.events[StreamEventAddress] = true
+	}
+	return nil
+}
+
+// checkStreamWatch rejects watching more addresses than a client may.
+func checkStreamWatch(watching int) error {
+	if watching > MaxStreamWatch {
+		return fmt.Errorf("watching %d addresses is more than the limit of %d", watching, MaxStreamWatch)
+	}
+	return nil
+}
+
+var addressType = reflect.TypeOf(address.Address{})
+
+// txAddresses returns every address referenced by a transaction, in sorted order.
+func txAddresses(tx metatx.Transactable) []string {
+	found := make(map[string]struct{})
+	collectAddresses(reflect.ValueOf(tx), found)
+	addrs := make([]string, 0, len(found))
+	for addr := range found {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+func collectAddresses(v reflect.Value, found map[string]struct{}) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			collectAddresses(v.Elem(), found)
+		}
+	case reflect.Struct:
+		if v.Type() == addressType {
+			if addr := v.Interface().(address.Address).String(); addr != "" {
+				found[addr] = struct{}{}
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				collectAddresses(v.Field(i), found)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		// Don't bother walking keys, signatures and the like byte by byte.
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			collectAddresses(v.Index(i), found)
+		}
+	}
+}
+
+func validateAddresses(addrs []string) (map[string]address.Address, error) {
+	valid := make(map[string]address.Address, len(addrs))
+	for _, addr := range addrs {
+		a, err := address.Validate(addr)
+		if err != nil {
+			return nil, fmt.Errorf("could not validate address %q: %s", addr, err)
+		}
+		valid[a.String()] = a
+	}
+	return valid, nil
+}
+
+// splitList splits a comma-separated query parameter, ignoring empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// parseStreamParams reads the events and watch query parameters shared by the stream endpoints.
+func parseStreamParams(r *http.Request) (map[string]bool, map[string]address.Address, error) {
+	qp := getQueryParms(r)
+
+	watch, err := validateAddresses(splitList(qp["watch"]))
+	if err != nil {
+		return nil, nil, err
+	}
+	err = checkStreamWatch(len(watch))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(map[string]bool)
+	names := splitList(qp["events"])
+	if len(names) == 0 {
+		names = []string{StreamEventBlock, StreamEventTx}
+		if len(watch) > 0 {
+			names = append(names, StreamEventAddress)
+		}
+	}
+	for _, name := range names {
+		switch name {
+		case StreamEventBlock, StreamEventTx, StreamEventAddress:
+			events[name] = true
+		default:
+			return nil, nil, fmt.Errorf("unknown event type %q", name)
+		}
+	}
+	return events, watch, nil
+}
+
+// HandleStreamSSE returns a HandlerFunc which streams events to the client as Server-Sent Events.
+func HandleStreamSSE(hub *StreamHub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		events, watch, err := parseStreamParams(r)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("invalid stream parameters", err, http.StatusBadRequest))
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			reqres.RespondJSON(w, reqres.NewAPIError("streaming is not supported", http.StatusInternalServerError))
+			return
+		}
+
+		sub, err := hub.subscribe(events, watch)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("could not subscribe to node events", err, http.StatusServiceUnavailable))
+			return
+		}
+		defer hub.unsubscribe(sub)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Robots-Tag", "noindex")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-sub.dropped:
+				return
+			case event := <-sub.out:
+				eventB, err := json.Marshal(event)
+				if err != nil {
+					hub.cf.Logger.WithError(err).Error("could not marshal stream event")
+					continue
+				}
+				_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, eventB)
+				if err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// The API is public and read-only, so we accept websocket connections from any origin.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(*http.Request) bool { return true },
+}
+
+// HandleStreamWS returns a HandlerFunc which streams events to the client over a websocket.
+//
+// Clients may send StreamWatchRequest messages to change the addresses they watch, up to
+// MaxStreamWatch of them. A request over the limit is answered with an error event and
+// changes nothing.
+func HandleStreamWS(hub *StreamHub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		events, watch, err := parseStreamParams(r)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("invalid stream parameters", err, http.StatusBadRequest))
+			return
+		}
+
+		// On failure, Upgrade has already replied to the client.
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		sub, err := hub.subscribe(events, watch)
+		if err != nil {
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(
+				websocket.CloseTryAgainLater, err.Error()))
+			return
+		}
+		defer hub.unsubscribe(sub)
+
+		// Only this goroutine writes to the connection; the reader reports problems through the hub.
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				_, msg, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				var req StreamWatchRequest
+				err = json.Unmarshal(msg, &req)
+				if err == nil {
+					err = sub.update(req)
+				}
+				if err != nil {
+					hub.send(sub, StreamEvent{Type: StreamEventError, Error: err.Error()})
+				}
+			}
+		}()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case <-sub.dropped:
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(
+					websocket.CloseTryAgainLater, "stream interrupted; please reconnect"))
+				return
+			case event := <-sub.out:
+				err := conn.WriteJSON(event)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+}
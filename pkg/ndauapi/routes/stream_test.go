@@ -0,0 +1,91 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxAddresses(t *testing.T) {
+	source := streamTestAddress(t)
+	dest := streamTestAddress(t)
+
+	tx := ndau.NewTransfer(source, dest, 1, 1)
+	addrs := txAddresses(tx)
+	require.ElementsMatch(t, []string{source.String(), dest.String()}, addrs)
+}
+
+func TestParseStreamParams(t *testing.T) {
+	addr := streamTestAddress(t)
+
+	t.Run("defaults", func(t *testing.T) {
+		events, watch, err := parseStreamParams(httptest.NewRequest("GET", "/", nil))
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{StreamEventBlock: true, StreamEventTx: true}, events)
+		require.Empty(t, watch)
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		events, watch, err := parseStreamParams(httptest.NewRequest("GET", "/?watch="+addr.String(), nil))
+		require.NoError(t, err)
+		require.True(t, events[StreamEventAddress])
+		require.Contains(t, watch, addr.String())
+	})
+
+	t.Run("events", func(t *testing.T) {
+		events, _, err := parseStreamParams(httptest.NewRequest("GET", "/?events=tx", nil))
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{StreamEventTx: true}, events)
+	})
+
+	t.Run("bad event", func(t *testing.T) {
+		_, _, err := parseStreamParams(httptest.NewRequest("GET", "/?events=foo", nil))
+		require.Error(t, err)
+	})
+
+	t.Run("bad address", func(t *testing.T) {
+		_, _, err := parseStreamParams(httptest.NewRequest("GET", "/?watch=foo", nil))
+		require.Error(t, err)
+	})
+}
+
+func TestStreamWatchLimit(t *testing.T) {
+	addrs := make([]string, 0, MaxStreamWatch+1)
+	for i := 0; i <= MaxStreamWatch; i++ {
+		addrs = append(addrs, streamTestAddress(t).String())
+	}
+
+	_, _, err := parseStreamParams(httptest.NewRequest("GET", "/?watch="+strings.Join(addrs, ","), nil))
+	require.Error(t, err)
+
+	sub := &streamSub{
+		events: make(map[string]bool),
+		watch:  make(map[string]address.Address),
+	}
+	require.NoError(t, sub.update(StreamWatchRequest{Watch: addrs[:MaxStreamWatch]}))
+
+	// one too many is rejected, and changes nothing
+	err = sub.update(StreamWatchRequest{Watch: addrs[MaxStreamWatch:]})
+	require.Error(t, err)
+	require.Len(t, sub.watched(), MaxStreamWatch)
+	require.NotContains(t, sub.watched(), addrs[MaxStreamWatch])
+
+	// unless it makes room in the same request
+	err = sub.update(StreamWatchRequest{Watch: addrs[MaxStreamWatch:], Unwatch: addrs[:1]})
+	require.NoError(t, err)
+	require.Len(t, sub.watched(), MaxStreamWatch)
+	require.Contains(t, sub.watched(), addrs[MaxStreamWatch])
+}
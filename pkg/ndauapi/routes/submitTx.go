@@ -65,13 +65,12 @@ func HandleSubmitTx(cf cfg.Cfg) http.HandlerFunc {
 			result.Code = EndpointResultTxAlreadyCommitted
 			code = http.StatusAccepted
 		} else {
-			// commit it synchronously; if we ever want to do this asynchronously, we'll need a
-			// new endpoint in part because we already use code http.StatusAccepted (202) above.
+			// commit it synchronously; HandleSubmitTxAsync implements the asynchronous version.
 			cr, err := tool.SendCommit(cf.Node, tx)
 			if err != nil {
 				// chances are high that if this fails, it's the user's fault, so let's
 				// blame them, not ourselves
-				reqres.RespondJSON(w, reqres.NewFromErr("error from commit", err, http.StatusBadRequest, tool.ResultLog(cr)))
+				reqres.RespondJSON(w, txRejection("error from commit", err, cr))
 				return
 			}
 		}
@@ -79,3 +78,11 @@ func HandleSubmitTx(cf cfg.Cfg) http.HandlerFunc {
 		reqres.RespondJSON(w, reqres.Response{Bd: result, Sts: code})
 	}
 }
+
+// txRejection is the response to a tx which the node rejected, which includes
+// the node's return code.
+func txRejection(msg string, err error, result interface{}) reqres.APIError {
+	apierr := reqres.NewFromErr(msg, err, http.StatusBadRequest, tool.ResultLog(result))
+	apierr.ErrorBody.ErrCode = int(tool.ResultCode(result))
+	return apierr
+}
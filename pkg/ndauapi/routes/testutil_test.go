@@ -0,0 +1,90 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ndau/ndau/pkg/ndau/search"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/bytes"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// streamTestAddress generates the address of a fresh user account.
+func streamTestAddress(t *testing.T) address.Address {
+	public, _, err := signature.Generate(signature.Ed25519, nil)
+	require.NoError(t, err)
+	addr, err := address.Generate(address.KindUser, public.KeyBytes())
+	require.NoError(t, err)
+	return addr
+}
+
+// fakeNode is a node whose mempool, tx index and tx sources the test controls, and
+// whose sync broadcasts return what the test says. Anything else asked of it panics.
+type fakeNode struct {
+	cfg.TMClient
+	mempool  tmtypes.Txs
+	total    int                           // if more than len(mempool), the node hides the rest
+	sources  map[string]address.Address    // keyed by tx bytes
+	indexed  map[string]search.TxValueData // keyed by tx hash
+	queries  int                           // how many queries the node has answered
+	checkTx  *rpctypes.ResultBroadcastTx   // what a sync broadcast returns
+	checkErr error                         // or the error it fails with
+}
+
+func (c *fakeNode) ABCIQuery(path string, data cmn.HexBytes) (*rpctypes.ResultABCIQuery, error) {
+	c.queries++
+	switch path {
+	case query.TxSourceEndpoint:
+		return &rpctypes.ResultABCIQuery{Response: abci.ResponseQuery{
+			Value: []byte(c.sources[string(data)].String()),
+			Info:  fmt.Sprintf(query.TxSourceInfoFmt, 1),
+		}}, nil
+	case query.SearchEndpoint:
+		var params search.QueryParams
+		err := json.Unmarshal(data, &params)
+		if err != nil {
+			return nil, err
+		}
+		vd := c.indexed[params.Hash]
+		return &rpctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: []byte(vd.Marshal())}}, nil
+	default:
+		panic("unexpected query: " + path)
+	}
+}
+
+func (c *fakeNode) Block(height *int64) (*rpctypes.ResultBlock, error) {
+	return &rpctypes.ResultBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: *height}}}, nil
+}
+
+func (c *fakeNode) UnconfirmedTxs(limit int) (*rpctypes.ResultUnconfirmedTxs, error) {
+	txs := c.mempool
+	if len(txs) > limit {
+		txs = txs[:limit]
+	}
+	total := c.total
+	if total < len(c.mempool) {
+		total = len(c.mempool)
+	}
+	return &rpctypes.ResultUnconfirmedTxs{Count: len(txs), Total: total, Txs: txs}, nil
+}
+
+func (c *fakeNode) BroadcastTxSync(tx tmtypes.Tx) (*rpctypes.ResultBroadcastTx, error) {
+	return c.checkTx, c.checkErr
+}
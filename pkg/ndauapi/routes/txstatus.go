@@ -0,0 +1,256 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-zoo/bone"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// These are the states reported by the tx status endpoint.
+//
+// A tx is dropped when CheckTx accepted it, but it has since left the mempool without
+// being committed. It may be resubmitted.
+const (
+	TxStatusPending   = "pending"
+	TxStatusCommitted = "committed"
+	TxStatusRejected  = "rejected"
+	TxStatusDropped   = "dropped"
+)
+
+// TxStatus is returned by the tx status endpoint.
+//
+// Height, offset, fee and SIB are only meaningful for committed transactions;
+// log and the node's return code are only set for rejected ones.
+type TxStatus struct {
+	TxHash      string `json:"hash"`
+	Status      string `json:"status"`
+	BlockHeight int64  `json:"height"`
+	TxOffset    int    `json:"offset"`
+	Fee         uint64 `json:"fee"`
+	SIB         uint64 `json:"sib"`
+	Log         string `json:"log,omitempty"`
+	ErrCode     int    `json:"err_code,omitempty"`
+}
+
+// Tendermint never returns more than this many unconfirmed txs at once.
+const mempoolScanLimit = 100
+
+// How many async submissions a TxTracker remembers, and for how long.
+const (
+	maxTrackedTxs     = 10000
+	trackedTxLifetime = time.Hour
+)
+
+// A tx accepted by CheckTx which we can't find in the mempool is only considered dropped
+// once it's been this long since it was submitted. Other nodes take a moment to hear of it.
+const mempoolGrace = 10 * time.Second
+
+type trackedTx struct {
+	rejected bool
+	log      string
+	code     uint32
+	at       time.Time
+}
+
+// A TxTracker remembers the outcome of CheckTx for recent async submissions,
+// so that the status endpoint can report on txs which never made it into a block.
+type TxTracker struct {
+	lock  sync.Mutex
+	txs   map[string]trackedTx
+	order []string // oldest first, for eviction
+	now   func() time.Time
+}
+
+// NewTxTracker creates an empty TxTracker.
+func NewTxTracker() *TxTracker {
+	return &TxTracker{
+		txs: make(map[string]trackedTx),
+		now: time.Now,
+	}
+}
+
+// expire forgets txs which are too old. It must be called with the lock held.
+func (t *TxTracker) expire() {
+	for len(t.order) > 0 && t.now().Sub(t.txs[t.order[0]].at) > trackedTxLifetime {
+		delete(t.txs, t.order[0])
+		t.order = t.order[1:]
+	}
+}
+
+func (t *TxTracker) track(txhash string, tx trackedTx) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	tx.at = t.now()
+	if _, ok := t.txs[txhash]; ok {
+		// it was resubmitted; it now expires last
+		for i, hash := range t.order {
+			if hash == txhash {
+				t.order = append(t.order[:i], t.order[i+1:]...)
+				break
+			}
+		}
+	} else if len(t.order) >= maxTrackedTxs {
+		delete(t.txs, t.order[0])
+		t.order = t.order[1:]
+	}
+	t.order = append(t.order, txhash)
+	t.txs[txhash] = tx
+	t.expire()
+}
+
+func (t *TxTracker) get(txhash string) (trackedTx, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.expire()
+	tx, ok := t.txs[txhash]
+	return tx, ok
+}
+
+// inMempool reports whether the tx with the given hash is waiting in the node's mempool,
+// and if not, whether we could see the whole mempool to be sure of that.
+func inMempool(node cfg.TMClient, txhash string) (found bool, complete bool, err error) {
+	unconfirmed, err := node.UnconfirmedTxs(mempoolScanLimit)
+	if err != nil {
+		return false, false, err
+	}
+	for offset, txbytes := range unconfirmed.Txs {
+		txdata, err := buildTransactionData("", txbytes, 0, offset, "")
+		if err != nil {
+			// Anything in the mempool got there by passing CheckTx, so this shouldn't happen;
+			// but it isn't what we're looking for either way.
+			continue
+		}
+		if txdata.TxHash == txhash {
+			return true, true, nil
+		}
+	}
+	// The node only lists the first few txs in its mempool.
+	return false, unconfirmed.Total <= len(unconfirmed.Txs), nil
+}
+
+// HandleSubmitTxAsync generates a handler that implements the /tx/submitasync endpoint.
+//
+// Unlike /tx/submit, it returns as soon as the node has checked the tx, without waiting
+// for it to be committed. Use /tx/status to find out what became of it.
+func HandleSubmitTxAsync(cf cfg.Cfg, tracker *TxTracker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		txtype := bone.GetValue(r, "txtype")
+		tx, err := TxUnmarshal(txtype, r.Body)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("tx.Data did not unmarshal into a tx", err, http.StatusBadRequest))
+			return
+		}
+		txhash := metatx.Hash(tx)
+
+		// Check if the tx has already been indexed.
+		block, _, _, _, err := searchTxHash(cf.Node, txhash)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("txhash search failed", err, http.StatusInternalServerError))
+			return
+		}
+		if block != nil {
+			result := SubmitResult{
+				TxHash: txhash,
+				Msg:    "tx already committed",
+				Code:   EndpointResultTxAlreadyCommitted,
+			}
+			reqres.RespondJSON(w, reqres.OKResponse(result))
+			return
+		}
+
+		// Sync broadcasts return once CheckTx has run, without waiting for a block.
+		cr, err := tool.SendSync(cf.Node, tx)
+		if err != nil {
+			if checked, ok := cr.(*rpctypes.ResultBroadcastTx); ok && checked != nil && checked.Code != 0 {
+				tracker.track(txhash, trackedTx{rejected: true, log: checked.Log, code: checked.Code})
+				reqres.RespondJSON(w, txRejection("error from checktx", err, cr))
+				return
+			}
+			// we couldn't ask the node, so we don't know what became of the tx
+			reqres.RespondJSON(w, reqres.NewFromErr("could not submit tx", err, http.StatusInternalServerError))
+			return
+		}
+		tracker.track(txhash, trackedTx{})
+
+		result := SubmitResult{
+			TxHash: txhash,
+			Msg:    "tx accepted into the mempool",
+			Code:   EndpointResultOK,
+		}
+		reqres.RespondJSON(w, reqres.Response{Bd: result, Sts: http.StatusAccepted})
+	}
+}
+
+// HandleTxStatus generates a handler that implements the /tx/status endpoint.
+func HandleTxStatus(cf cfg.Cfg, tracker *TxTracker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		txhash := bone.GetValue(r, "txhash")
+		if txhash == "" {
+			reqres.RespondJSON(w, reqres.NewAPIError("txhash parameter required", http.StatusBadRequest))
+			return
+		}
+
+		result := TxStatus{TxHash: txhash}
+
+		// Look in the mempool before the index: a tx is indexed before it leaves the mempool,
+		// so in this order we can't miss one that is committed while we look.
+		pending, complete, err := inMempool(cf.Node, txhash)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("mempool search failed", err, http.StatusInternalServerError))
+			return
+		}
+
+		block, txoffset, fee, sib, err := searchTxHash(cf.Node, txhash)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("txhash search failed", err, http.StatusInternalServerError))
+			return
+		}
+
+		tracked, isTracked := tracker.get(txhash)
+
+		switch {
+		case block != nil:
+			result.Status = TxStatusCommitted
+			result.BlockHeight = block.Height
+			result.TxOffset = txoffset
+			result.Fee = fee
+			result.SIB = sib
+		case pending:
+			result.Status = TxStatusPending
+		case isTracked && tracked.rejected:
+			result.Status = TxStatusRejected
+			result.Log = tracked.log
+			result.ErrCode = int(tracked.code)
+		case isTracked && complete && tracker.now().Sub(tracked.at) > mempoolGrace:
+			// CheckTx accepted it, but it has left the mempool without being committed.
+			result.Status = TxStatusDropped
+		case isTracked:
+			// CheckTx accepted it, but it's not among the mempool txs we can see; either
+			// the node only shows us the first few, or it's only just been submitted.
+			result.Status = TxStatusPending
+		default:
+			reqres.RespondJSON(w, reqres.NewAPIError(fmt.Sprintf("unknown tx: %s", txhash), http.StatusNotFound))
+			return
+		}
+
+		reqres.RespondJSON(w, reqres.OKResponse(result))
+	}
+}
@@ -0,0 +1,175 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-zoo/bone"
+	"github.com/ndau/metanode/pkg/meta/app/code"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/ndau/search"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestHandleTxStatus(t *testing.T) {
+	addr := streamTestAddress(t)
+	newTx := func(seq uint64) (string, []byte) {
+		tx := ndau.NewLock(addr, 1, seq)
+		bytes, err := metatx.Marshal(tx, ndau.TxIDs)
+		require.NoError(t, err)
+		return metatx.Hash(tx), bytes
+	}
+
+	committed, _ := newTx(1)
+	pending, pendingBytes := newTx(2)
+	rejected, _ := newTx(3)
+	dropped, _ := newTx(4)
+	unknown, _ := newTx(5)
+
+	node := &fakeNode{
+		mempool: tmtypes.Txs{pendingBytes},
+		indexed: map[string]search.TxValueData{
+			committed: search.TxValueData{BlockHeight: 12, TxOffset: 1, Fee: 3, SIB: 4},
+		},
+	}
+	now := time.Now()
+	tracker := NewTxTracker()
+	tracker.now = func() time.Time { return now }
+
+	mux := bone.New()
+	mux.Get("/tx/status/:txhash", HandleTxStatus(cfg.Cfg{Node: node}, tracker))
+	status := func(txhash string) (int, TxStatus) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "/tx/status/"+txhash, nil))
+		var result TxStatus
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+		}
+		return w.Code, result
+	}
+
+	t.Run("committed", func(t *testing.T) {
+		code, result := status(committed)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, TxStatusCommitted, result.Status)
+		require.Equal(t, int64(12), result.BlockHeight)
+		require.Equal(t, 1, result.TxOffset)
+		require.Equal(t, uint64(3), result.Fee)
+		require.Equal(t, uint64(4), result.SIB)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		code, result := status(pending)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, TxStatusPending, result.Status)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		tracker.track(rejected, trackedTx{rejected: true, log: "nope"})
+		code, result := status(rejected)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, TxStatusRejected, result.Status)
+		require.Equal(t, "nope", result.Log)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		code, _ := status(unknown)
+		require.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("dropped", func(t *testing.T) {
+		tracker.track(dropped, trackedTx{})
+
+		// other nodes may not have heard of it yet
+		code, result := status(dropped)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, TxStatusPending, result.Status)
+
+		now = now.Add(time.Minute)
+		code, result = status(dropped)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, TxStatusDropped, result.Status)
+
+		// we can't be sure it's gone if we can't see the whole mempool
+		node.total = mempoolScanLimit + 1
+		code, result = status(dropped)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, TxStatusPending, result.Status)
+		node.total = 0
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(trackedTxLifetime + time.Second)
+		code, _ := status(dropped)
+		require.Equal(t, http.StatusNotFound, code)
+		code, _ = status(rejected)
+		require.Equal(t, http.StatusNotFound, code)
+		require.Empty(t, tracker.order)
+	})
+}
+
+func TestHandleSubmitTxAsyncRejected(t *testing.T) {
+	tx := ndau.NewLock(streamTestAddress(t), 1, 1)
+	body, err := json.Marshal(tx)
+	require.NoError(t, err)
+	txhash := metatx.Hash(tx)
+
+	node := &fakeNode{checkTx: &rpctypes.ResultBroadcastTx{
+		Code: uint32(code.InvalidTransaction),
+		Log:  "nope",
+	}}
+	tracker := NewTxTracker()
+	mux := bone.New()
+	mux.Post("/tx/submitasync/:txtype", HandleSubmitTxAsync(cfg.Cfg{Node: node}, tracker))
+
+	// the node's code is passed on
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/tx/submitasync/Lock", bytes.NewReader(body)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var eb reqres.ErrorBody
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&eb))
+	require.Equal(t, int(code.InvalidTransaction), eb.ErrCode)
+	require.Equal(t, "nope", eb.Log)
+
+	tracked, ok := tracker.get(txhash)
+	require.True(t, ok)
+	require.True(t, tracked.rejected)
+	require.Equal(t, uint32(code.InvalidTransaction), tracked.code)
+}
+
+func TestHandleSubmitTxAsyncNodeError(t *testing.T) {
+	tx := ndau.NewLock(streamTestAddress(t), 1, 1)
+	body, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	node := &fakeNode{checkErr: errors.New("connection refused")}
+	tracker := NewTxTracker()
+	mux := bone.New()
+	mux.Post("/tx/submitasync/:txtype", HandleSubmitTxAsync(cfg.Cfg{Node: node}, tracker))
+
+	// the node never said what it made of the tx, so it isn't known to be rejected
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/tx/submitasync/Lock", bytes.NewReader(body)))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	_, ok := tracker.get(metatx.Hash(tx))
+	require.False(t, ok)
+}
@@ -0,0 +1,77 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	srch "github.com/ndau/ndau/pkg/ndau/search"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
+)
+
+// UnlocksResults encapsulates a set of scheduled unlocks in a json-friendly way
+type UnlocksResults struct {
+	Items []srch.UnlockValueData `json:"items"`
+	Days  []srch.UnlockDayTotal  `json:"days"`
+	Next  string                 `json:"next"`
+}
+
+// HandleUnlocks handles scheduled unlocks
+func HandleUnlocks(cf cfg.Cfg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		bdata, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("reading params", err, http.StatusBadRequest))
+			return
+		}
+
+		var params srch.UnlocksQueryParams
+		if len(bdata) > 0 {
+			err = json.Unmarshal(bdata, &params)
+			if err != nil {
+				reqres.RespondJSON(w, reqres.NewFromErr("unmarshaling params", err, http.StatusBadRequest))
+				return
+			}
+		}
+
+		if params.Limit == 0 {
+			params.Limit = 100
+		}
+		if params.Limit > 1000 {
+			params.Limit = 1000
+		}
+
+		uqr, err := tool.Unlocks(cf.Node, params)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("searching unlocks", err, http.StatusInternalServerError))
+			return
+		}
+
+		out := UnlocksResults{
+			Items: uqr.Items,
+			Days:  uqr.Days,
+		}
+		if uqr.More && len(uqr.Items) > 0 {
+			params.Offset += uint(len(uqr.Items))
+			data, err := json.Marshal(params)
+			if err == nil {
+				// otherwise, just forget it; this is a convenience, not essential
+				out.Next = string(data)
+			}
+		}
+
+		reqres.RespondJSON(w, reqres.OKResponse(out))
+	}
+}
@@ -0,0 +1,64 @@
+package routes
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/tool"
+	"github.com/ndau/ndaumath/pkg/signature"
+)
+
+// ValidationScriptRequest is the body of a request to the validation script endpoint.
+//
+// Script is run against the sample tx as though the validation keys whose bits are set
+// in Signatures had signed it. If ValidationKeys is set, it stands in for the source
+// account's validation keys.
+type ValidationScriptRequest struct {
+	Script         []byte                `json:"script"`
+	TxType         string                `json:"txtype"`
+	Tx             json.RawMessage       `json:"tx"`
+	Signatures     uint64                `json:"signatures"`
+	ValidationKeys []signature.PublicKey `json:"validation_keys,omitempty"`
+}
+
+// HandleValidationScript generates a handler that implements the /tx/validationscript endpoint.
+func HandleValidationScript(cf cfg.Cfg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ValidationScriptRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("could not decode request", err, http.StatusBadRequest))
+			return
+		}
+		if !ndau.IsChaincode(req.Script) {
+			reqres.RespondJSON(w, reqres.NewAPIError("script is not chaincode", http.StatusBadRequest))
+			return
+		}
+
+		tx, err := TxUnmarshal(req.TxType, bytes.NewReader(req.Tx))
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("tx did not unmarshal into a tx", err, http.StatusBadRequest))
+			return
+		}
+
+		result, _, err := tool.DryRunValidationScript(cf.Node, req.Script, tx, req.Signatures, req.ValidationKeys)
+		if err != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("could not run validation script", err, http.StatusBadRequest))
+			return
+		}
+		reqres.RespondJSON(w, reqres.OKResponse(result))
+	}
+}
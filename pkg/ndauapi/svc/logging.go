@@ -10,6 +10,9 @@ package svc
 // - -- --- ---- -----
 
 import (
+	"bufio"
+	"errors"
+	"net"
 	"net/http"
 	"time"
 
@@ -36,6 +39,25 @@ func (w *LogWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Flush proxies http.Flusher, so that streaming responses work through the logger.
+func (w *LogWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack proxies http.Hijacker, so that websocket upgrades work through the logger.
+func (w *LogWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("underlying ResponseWriter does not support hijacking")
+	}
+	if w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return h.Hijack()
+}
+
 // LogMW wraps a regular handler and replaces the writer with some logging middleware.
 func LogMW(handler http.Handler, logger logrus.FieldLogger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,612 @@
+package svc
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kentquirk/boneful"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/ndauapi/reqres"
+	"github.com/ndau/ndau/pkg/ndauapi/routes"
+	"github.com/ndau/ndau/pkg/version"
+)
+
+// A documentedService is a boneful.Service which remembers how its routes are documented,
+// so that it can describe them in an OpenAPI document as well.
+//
+// Its methods mirror those of boneful.Service, so routes are declared the same way.
+type documentedService struct {
+	*boneful.Service
+	doc    string
+	routes []*documentedRoute
+
+	once       sync.Once
+	openAPI    *openAPIDocument
+	openAPIErr error
+}
+
+func newDocumentedService() *documentedService {
+	return &documentedService{Service: new(boneful.Service)}
+}
+
+// Path mirrors boneful.Service.Path
+func (s *documentedService) Path(root string) *documentedService {
+	s.Service = s.Service.Path(root)
+	return s
+}
+
+// Doc mirrors boneful.Service.Doc
+func (s *documentedService) Doc(doc string) *documentedService {
+	s.doc = doc
+	s.Service = s.Service.Doc(doc)
+	return s
+}
+
+// GET mirrors boneful.Service.GET
+func (s *documentedService) GET(path string) *documentedRoute {
+	return &documentedRoute{RouteBuilder: s.Service.GET(path), method: http.MethodGet, path: path}
+}
+
+// POST mirrors boneful.Service.POST
+func (s *documentedService) POST(path string) *documentedRoute {
+	return &documentedRoute{RouteBuilder: s.Service.POST(path), method: http.MethodPost, path: path}
+}
+
+// Route mirrors boneful.Service.Route
+func (s *documentedService) Route(r *documentedRoute) {
+	s.routes = append(s.routes, r)
+	s.Service.Route(r.RouteBuilder)
+}
+
+// A documentedRoute is a boneful.RouteBuilder which remembers its documentation.
+type documentedRoute struct {
+	*boneful.RouteBuilder
+	method    string
+	path      string
+	doc       string
+	notes     string
+	operation string
+	params    []*parameter
+	consumes  []string
+	produces  []string
+	reads     interface{}
+	writes    interface{}
+}
+
+// To mirrors boneful.RouteBuilder.To
+func (r *documentedRoute) To(handler http.HandlerFunc) *documentedRoute {
+	r.RouteBuilder = r.RouteBuilder.To(handler)
+	return r
+}
+
+// Doc mirrors boneful.RouteBuilder.Doc
+func (r *documentedRoute) Doc(doc string) *documentedRoute {
+	r.doc = doc
+	r.RouteBuilder = r.RouteBuilder.Doc(doc)
+	return r
+}
+
+// Notes mirrors boneful.RouteBuilder.Notes
+func (r *documentedRoute) Notes(notes string) *documentedRoute {
+	r.notes = notes
+	r.RouteBuilder = r.RouteBuilder.Notes(notes)
+	return r
+}
+
+// Operation mirrors boneful.RouteBuilder.Operation
+func (r *documentedRoute) Operation(operation string) *documentedRoute {
+	r.operation = operation
+	r.RouteBuilder = r.RouteBuilder.Operation(operation)
+	return r
+}
+
+// Param mirrors boneful.RouteBuilder.Param
+func (r *documentedRoute) Param(p *parameter) *documentedRoute {
+	r.params = append(r.params, p)
+	r.RouteBuilder = r.RouteBuilder.Param(p.Parameter)
+	return r
+}
+
+// Consumes mirrors boneful.RouteBuilder.Consumes
+func (r *documentedRoute) Consumes(mimeType string) *documentedRoute {
+	r.consumes = append(r.consumes, mimeType)
+	r.RouteBuilder = r.RouteBuilder.Consumes(mimeType)
+	return r
+}
+
+// Produces mirrors boneful.RouteBuilder.Produces
+func (r *documentedRoute) Produces(mimeType string) *documentedRoute {
+	r.produces = append(r.produces, mimeType)
+	r.RouteBuilder = r.RouteBuilder.Produces(mimeType)
+	return r
+}
+
+// Reads mirrors boneful.RouteBuilder.Reads
+func (r *documentedRoute) Reads(sample interface{}) *documentedRoute {
+	r.reads = sample
+	r.RouteBuilder = r.RouteBuilder.Reads(sample)
+	return r
+}
+
+// Writes mirrors boneful.RouteBuilder.Writes
+func (r *documentedRoute) Writes(sample interface{}) *documentedRoute {
+	r.writes = sample
+	r.RouteBuilder = r.RouteBuilder.Writes(sample)
+	return r
+}
+
+// A parameter is a boneful.Parameter which remembers its documentation.
+type parameter struct {
+	*boneful.Parameter
+	in          string
+	name        string
+	description string
+	dataType    string
+	required    bool
+}
+
+// pathParameter mirrors boneful.PathParameter
+func pathParameter(name, description string) *parameter {
+	return &parameter{
+		Parameter:   boneful.PathParameter(name, description),
+		in:          "path",
+		name:        name,
+		description: description,
+	}
+}
+
+// queryParameter mirrors boneful.QueryParameter
+func queryParameter(name, description string) *parameter {
+	return &parameter{
+		Parameter:   boneful.QueryParameter(name, description),
+		in:          "query",
+		name:        name,
+		description: description,
+	}
+}
+
+// DataType mirrors boneful.Parameter.DataType
+func (p *parameter) DataType(dataType string) *parameter {
+	p.dataType = dataType
+	p.Parameter = p.Parameter.DataType(dataType)
+	return p
+}
+
+// Required mirrors boneful.Parameter.Required
+func (p *parameter) Required(required bool) *parameter {
+	p.required = required
+	p.Parameter = p.Parameter.Required(required)
+	return p
+}
+
+// HandleOpenAPI returns a HandlerFunc which serves the OpenAPI document describing the service.
+//
+// The document is generated on first use, by which time every route has been declared.
+func (s *documentedService) HandleOpenAPI() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.once.Do(func() {
+			s.openAPI, s.openAPIErr = s.generateOpenAPI()
+		})
+		if s.openAPIErr != nil {
+			reqres.RespondJSON(w, reqres.NewFromErr("generating OpenAPI document", s.openAPIErr, http.StatusInternalServerError))
+			return
+		}
+		reqres.RespondJSON(w, reqres.OKResponse(s.openAPI))
+	}
+}
+
+type schema map[string]interface{}
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]schema `json:"schemas"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *openAPIBody                `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponses `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+	Schema      schema `json:"schema"`
+}
+
+type openAPIBody struct {
+	Required bool                        `json:"required,omitempty"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponses struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema  schema          `json:"schema"`
+	Example json.RawMessage `json:"example,omitempty"`
+}
+
+// The name of the schema which can be any ndau transaction.
+const transactionSchema = "Transaction"
+
+// The path parameter through which routes accept any ndau transaction.
+const txTypeParam = "txtype"
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	transactableType  = reflect.TypeOf((*metatx.Transactable)(nil)).Elem()
+)
+
+func (s *documentedService) generateOpenAPI() (*openAPIDocument, error) {
+	v, err := version.Get()
+	if err != nil {
+		v = "unknown"
+	}
+
+	sb := newSchemaBuilder()
+	doc := &openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:       "ndau API",
+			Description: unindent(s.doc),
+			Version:     v,
+		},
+		Paths: make(map[string]map[string]*openAPIOperation),
+	}
+
+	for _, r := range s.routes {
+		openAPIPath, op, err := r.operationOf(sb)
+		if err != nil {
+			return nil, err
+		}
+		if doc.Paths[openAPIPath] == nil {
+			doc.Paths[openAPIPath] = make(map[string]*openAPIOperation)
+		}
+		doc.Paths[openAPIPath][strings.ToLower(r.method)] = op
+	}
+
+	doc.Components.Schemas = sb.schemas
+	return doc, nil
+}
+
+// operationOf describes a route as an OpenAPI operation, returning it with its OpenAPI path.
+//
+// It fails if a documented path parameter doesn't appear in the route's path.
+func (r *documentedRoute) operationOf(sb *schemaBuilder) (string, *openAPIOperation, error) {
+	op := &openAPIOperation{
+		OperationID: r.operation,
+		Summary:     r.doc,
+		Description: unindent(r.notes),
+		Responses:   make(map[string]openAPIResponses),
+	}
+
+	// Group operations by their top-level path element, as the service doc does.
+	segments := strings.Split(strings.Trim(r.path, "/"), "/")
+	op.Tags = []string{strings.TrimSuffix(segments[0], ".json")}
+
+	// OpenAPI writes path parameters as {name} rather than :name.
+	documented := make(map[string]bool)
+	for _, p := range r.params {
+		documented[p.name] = true
+		op.Parameters = append(op.Parameters, p.openAPI())
+	}
+	takesTx := false
+	inPath := make(map[string]bool)
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		name := strings.TrimPrefix(segment, ":")
+		inPath[name] = true
+		segments[i] = "{" + name + "}"
+		if name == txTypeParam {
+			takesTx = true
+		}
+		if !documented[name] {
+			op.Parameters = append(op.Parameters, openAPIParameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   schema{"type": "string"},
+			})
+		}
+	}
+	for _, p := range r.params {
+		if p.in == "path" && !inPath[p.name] {
+			return "", nil, fmt.Errorf("%s %s documents path parameter %q, which is not in its path", r.method, r.path, p.name)
+		}
+	}
+	for i := range op.Parameters {
+		if op.Parameters[i].Name == txTypeParam && op.Parameters[i].In == "path" {
+			op.Parameters[i].Schema = txNameSchema()
+		}
+	}
+
+	if r.reads != nil || takesTx {
+		body := openAPIBody{
+			Required: true,
+			Content:  make(map[string]openAPIMediaType),
+		}
+		media := openAPIMediaType{Example: example(r.reads)}
+		if takesTx {
+			// These routes accept any tx type, named by the path.
+			media.Schema = sb.ref(transactableType)
+		} else {
+			media.Schema = sb.schemaFor(reflect.TypeOf(r.reads))
+		}
+		for _, mimeType := range orJSON(r.consumes) {
+			body.Content[mimeType] = media
+		}
+		op.RequestBody = &body
+	}
+
+	response := openAPIResponses{Description: "OK"}
+	if r.writes != nil {
+		response.Content = make(map[string]openAPIMediaType)
+		media := openAPIMediaType{
+			Schema:  sb.schemaFor(reflect.TypeOf(r.writes)),
+			Example: example(r.writes),
+		}
+		for _, mimeType := range orJSON(r.produces) {
+			response.Content[mimeType] = media
+		}
+	}
+	op.Responses["200"] = response
+	op.Responses["default"] = openAPIResponses{
+		Description: "Error",
+		Content: map[string]openAPIMediaType{
+			JSON: {Schema: sb.schemaFor(reflect.TypeOf(reqres.ErrorBody{}))},
+		},
+	}
+
+	return "/" + path.Join(segments...), op, nil
+}
+
+func (p *parameter) openAPI() openAPIParameter {
+	t := p.dataType
+	switch t {
+	case "", "string":
+		t = "string"
+	case "int", "int64", "uint64":
+		t = "integer"
+	case "bool":
+		t = "boolean"
+	}
+	return openAPIParameter{
+		Name:        p.name,
+		In:          p.in,
+		Description: p.description,
+		// OpenAPI requires every path parameter to be required.
+		Required: p.required || p.in == "path",
+		Schema:   schema{"type": t},
+	}
+}
+
+func txNameSchema() schema {
+	names := routes.TxNames()
+	enum := make([]interface{}, len(names))
+	for i, name := range names {
+		enum[i] = name
+	}
+	return schema{"type": "string", "enum": enum}
+}
+
+func orJSON(mimeTypes []string) []string {
+	if len(mimeTypes) == 0 {
+		return []string{JSON}
+	}
+	return mimeTypes
+}
+
+// example returns the JSON encoding of a sample, or nil if there isn't one.
+func example(sample interface{}) json.RawMessage {
+	if sample == nil {
+		return nil
+	}
+	ex, err := json.Marshal(sample)
+	if err != nil {
+		return nil
+	}
+	return ex
+}
+
+// unindent strips the leading indentation from each line of a doc string written in Go source.
+func unindent(doc string) string {
+	lines := strings.Split(strings.TrimSpace(doc), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimLeft(line, "\t ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// A schemaBuilder derives JSON schemas from go types, collecting named types as components.
+type schemaBuilder struct {
+	schemas map[string]schema
+	names   map[reflect.Type]string
+	types   map[string]reflect.Type
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		schemas: make(map[string]schema),
+		names:   make(map[reflect.Type]string),
+		types:   make(map[string]reflect.Type),
+	}
+}
+
+// schemaName names the component schema for a named go type.
+//
+// Types are named for their package and type names. Should that name already belong to a
+// type from another package with the same name, the full package path is used instead.
+func (sb *schemaBuilder) schemaName(t reflect.Type) string {
+	if t == transactableType {
+		return transactionSchema
+	}
+	if name, ok := sb.names[t]; ok {
+		return name
+	}
+	name := path.Base(t.PkgPath()) + "." + t.Name()
+	if _, taken := sb.types[name]; taken {
+		// Component names may not contain slashes.
+		name = strings.Replace(t.PkgPath(), "/", ".", -1) + "." + t.Name()
+	}
+	sb.names[t] = name
+	sb.types[name] = t
+	return name
+}
+
+// ref returns a reference to the component schema for a named type, defining it if need be.
+func (sb *schemaBuilder) ref(t reflect.Type) schema {
+	name := sb.schemaName(t)
+	if _, ok := sb.schemas[name]; !ok {
+		// Reserve the name first, so that recursive types terminate.
+		sb.schemas[name] = schema{}
+		if t == transactableType {
+			sb.schemas[name] = sb.transactionSchema()
+		} else {
+			sb.schemas[name] = sb.structSchema(t)
+		}
+	}
+	return schema{"$ref": "#/components/schemas/" + name}
+}
+
+// transactionSchema can be any ndau tx, each of which gets its own component schema.
+func (sb *schemaBuilder) transactionSchema() schema {
+	types := make([]reflect.Type, 0, len(ndau.TxIDs))
+	for _, tx := range ndau.TxIDs {
+		types = append(types, reflect.TypeOf(tx).Elem())
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name() < types[j].Name() })
+
+	oneOf := make([]interface{}, 0, len(types))
+	for _, t := range types {
+		oneOf = append(oneOf, sb.ref(t))
+	}
+	return schema{
+		"description": "Any ndau transaction. Its type is given separately, usually by the path.",
+		"oneOf":       oneOf,
+	}
+}
+
+func (sb *schemaBuilder) schemaFor(t reflect.Type) schema {
+	if t == nil {
+		return schema{}
+	}
+	if t == transactableType {
+		return sb.ref(t)
+	}
+	// Types which encode themselves as text are strings, whatever they are underneath.
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return schema{"type": "string"}
+	}
+	// We can't know what other self-encoding types look like.
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return sb.schemaFor(t.Elem())
+	case reflect.Bool:
+		return schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return schema{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return schema{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return schema{"type": "number"}
+	case reflect.String:
+		return schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return schema{"type": "string", "format": "byte"}
+		}
+		return schema{"type": "array", "items": sb.schemaFor(t.Elem())}
+	case reflect.Map:
+		return schema{"type": "object", "additionalProperties": sb.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return sb.structSchema(t)
+		}
+		return sb.ref(t)
+	}
+	// Interfaces, and anything else we don't know how to describe.
+	return schema{}
+}
+
+// structSchema describes a struct the way encoding/json encodes it.
+func (sb *schemaBuilder) structSchema(t reflect.Type) schema {
+	properties := make(map[string]interface{})
+	sb.addProperties(t, properties)
+	return schema{"type": "object", "properties": properties}
+}
+
+func (sb *schemaBuilder) addProperties(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		// Untagged embedded structs have their fields promoted.
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				sb.addProperties(ft, properties)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = sb.schemaFor(field.Type)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/tendermint/tendermint/p2p"
 
@@ -85,11 +86,15 @@ var dummyResultBlock = rpctypes.ResultBlock{
 
 // End Note
 
+var dummyTime, _ = time.Parse(time.RFC3339, dummyTimestamp)
+
 func dummyParsedTimestamp() types.Timestamp {
 	x, _ := types.ParseTimestamp(dummyTimestamp)
 	return x
 }
 
+var dummyEAI = types.Ndau(123456789)
+
 var dummyLockTx = ndau.NewLock(dummyAddress, 30*types.Day, 1234)
 
 var dummyTransactionResultDeprecated = routes.TransactionDataDeprecated{
@@ -127,7 +132,7 @@ var dummyPrevalidateResult = routes.PrevalidateResult{
 
 // New returns a new boneful Service with routes.
 func New(cf cfg.Cfg) *boneful.Service {
-	svc := new(boneful.Service).
+	svc := newDocumentedService().
 		Path("/").
 		Doc(`This service provides the ndau API, used to retrieve information about and manage the ndau blockchain and
 		its Tendermint consensus engine.
@@ -139,10 +144,12 @@ func New(cf cfg.Cfg) *boneful.Service {
 		* /node provides information about node operations
 		* /price returns information related to the ndau monetary system
 		* /state provides dynamic system state information
+		* /stream pushes new blocks, transactions and account changes as they happen
 		* /system rqueries or sets system variables
 		* /transaction queries individual transactions on the blockchain
 		* /tx provides tools to build, prevalidate, and submit transactions
 		* /version returns current system version information
+		* /openapi.json returns an OpenAPI 3 document describing this service
 
 		Each of these, in turn, has several endpoints within it.
 		`)
@@ -156,7 +163,8 @@ func New(cf cfg.Cfg) *boneful.Service {
 
 	svc.Route(svc.POST("/account/accounts").To(routes.HandleAccounts(cf)).
 		Doc("Returns current state of several accounts given a list of addresses.").
-		Notes("Only returns data for accounts that actively exist on the blockchain.").
+		Notes(`Only returns data for accounts that actively exist on the blockchain.
+		At most 100 addresses may be requested at once.`).
 		Operation("AccountsFromList").
 		Consumes(JSON).
 		Reads([]string{dummyAddress.String()}).
@@ -168,9 +176,9 @@ func New(cf cfg.Cfg) *boneful.Service {
 		Notes(`The history includes the timestamp, new balance, and transaction ID of each change to the account's balance.
 		The result is sorted chronologically.`).
 		Operation("AccountHistory").
-		Param(boneful.PathParameter("address", "The address of the account for which to return history").DataType("string").Required(true)).
-		Param(boneful.QueryParameter("after", "The block height after which results should start.").DataType("string").Required(false)).
-		Param(boneful.QueryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
+		Param(pathParameter("address", "The address of the account for which to return history").DataType("string").Required(true)).
+		Param(queryParameter("after", "The block height after which results should start.").DataType("string").Required(false)).
+		Param(queryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
 		Produces(JSON).
 		Writes(routes.AccountHistoryItems{Items: []routes.AccountHistoryItem{{
 			Balance:   123000000,
@@ -184,8 +192,8 @@ func New(cf cfg.Cfg) *boneful.Service {
 		alphabetically. A maximum of 1000 accounts can be returned in a single
 		request. The results are sorted by address.`).
 		Operation("AccountList").
-		Param(boneful.QueryParameter("after", "The address after which (sorted alphabetically) results should start.").DataType("string").Required(false)).
-		Param(boneful.QueryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
+		Param(queryParameter("after", "The address after which (sorted alphabetically) results should start.").DataType("string").Required(false)).
+		Param(queryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
 		Produces(JSON).
 		Writes(query.AccountListQueryResponse{
 			NumAccounts: 1,
@@ -199,16 +207,26 @@ func New(cf cfg.Cfg) *boneful.Service {
 		Doc("Returns a list of ndau 'currency seats', the oldest 3000 accounts containing more than 1000 ndau.").
 		Notes(`The ndau currency seats are accounts containing more than 1000 ndau. The seniority of
 		a currency seat is determined by how long it has been above the 1000 threshold, so this endpoint
-		also sorts the result by age (oldest first). It does not return detailed account information.`).
+		also sorts the result by age (oldest first). It does not return detailed account information;
+		/account/currencyseats/details also returns the dates the seats were acquired.`).
 		Operation("AccountCurrencySeats").
-		Param(boneful.QueryParameter("limit", "The max number of items to return (default=3000)").DataType("int").Required(false)).
-		Produces(JSON).
-		Writes(query.AccountListQueryResponse{
-			NumAccounts: 1,
-			FirstIndex:  1,
-			After:       dummyAddress.String(),
-			NextAfter:   dummyAddress.String(),
-			Accounts:    []string{dummyAddress.String()},
+		Param(queryParameter("limit", "The max number of items to return (default=3000)").DataType("int").Required(false)).
+		Produces(JSON).
+		Writes([]string{dummyAddress.String()}))
+
+	svc.Route(svc.GET("/account/currencyseats/details").To(routes.HandleAccountCurrencySeatDetails(cf)).
+		Doc("Returns the ndau 'currency seats' as /account/currencyseats does, with the date each was acquired and its balance.").
+		Notes(`Seats are sorted by the date they were acquired, oldest first. The balance is the
+		account's current balance, in napu.`).
+		Operation("AccountCurrencySeatDetails").
+		Param(queryParameter("limit", "The max number of items to return (default=3000)").DataType("int").Required(false)).
+		Produces(JSON).
+		Writes(query.CurrencySeatsResponse{
+			query.CurrencySeat{
+				Address:  dummyAddress,
+				SeatDate: dummyParsedTimestamp(),
+				Balance:  200000000000,
+			},
 		}))
 
 	svc.Route(svc.POST("/account/eai/rate").To(routes.GetEAIRate(cf)).
@@ -220,18 +238,18 @@ func New(cf cfg.Cfg) *boneful.Service {
 	svc.Route(svc.GET("/account/votes/:address/:date").To(routes.HandleAccountVotes(cf)).
 		Operation("AccountVotes").
 		Doc("Returns the number of votes to which an account is entitled on a valid ndau DAO election date").
-		Param(boneful.PathParameter("address", "The address of the account for which to return votes").DataType("string").Required(true)).
-		Param(boneful.PathParameter("DATE", "Timestamp (ISO 3339) of DAO vote (only YYYY-MM-DD is used).").DataType("string").Required(true)).
+		Param(pathParameter("address", "The address of the account for which to return votes").DataType("string").Required(true)).
+		Param(pathParameter("date", "Timestamp (ISO 3339) of DAO vote (only YYYY-MM-DD is used).").DataType("string").Required(true)).
 		Produces(JSON).
 		Writes(dummyAccount))
 
 	svc.Route(svc.GET("/block/before/:height").To(routes.HandleBlockBefore(cf)).
 		Operation("BlockBefore").
 		Doc("Returns a (possibly filtered) sequence of block metadata for blocks on or before a given height.").
-		Param(boneful.PathParameter("height", "Blocks greater than this height will not be returned.").DataType("int").Required(true)).
-		Param(boneful.QueryParameter("filter", "Set to 'noempty' to exclude empty blocks.").DataType("string").Required(true)).
-		Param(boneful.QueryParameter("after", "The block height after which no more results should be returned.").DataType("int").Required(false)).
-		Param(boneful.QueryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
+		Param(pathParameter("height", "Blocks greater than this height will not be returned.").DataType("int").Required(true)).
+		Param(queryParameter("filter", "Set to 'noempty' to exclude empty blocks.").DataType("string").Required(true)).
+		Param(queryParameter("after", "The block height after which no more results should be returned.").DataType("int").Required(false)).
+		Param(queryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
 		Produces(JSON).
 		Writes(rpctypes.ResultBlockchainInfo{
 			LastHeight: 12345,
@@ -247,23 +265,23 @@ func New(cf cfg.Cfg) *boneful.Service {
 	svc.Route(svc.GET("/block/hash/:blockhash").To(routes.HandleBlockHash(cf)).
 		Operation("BlockHash").
 		Doc("Returns the block in the chain with the given hash.").
-		Param(boneful.PathParameter("blockhash", "Hex hash of the block in chain to return.").DataType("string").Required(true)).
+		Param(pathParameter("blockhash", "Hex hash of the block in chain to return.").DataType("string").Required(true)).
 		Produces(JSON).
 		Writes(dummyResultBlock))
 
 	svc.Route(svc.GET("/block/height/:height").To(routes.HandleBlockHeight(cf)).
 		Operation("BlockHeight").
 		Doc("Returns the block in the chain at the given height.").
-		Param(boneful.PathParameter("height", "Height of the block in chain to return.").DataType("int").Required(true)).
+		Param(pathParameter("height", "Height of the block in chain to return.").DataType("int").Required(true)).
 		Produces(JSON).
 		Writes(dummyResultBlock))
 
 	svc.Route(svc.GET("/block/range/:first/:last").To(routes.HandleBlockRange(cf)).
 		Operation("BlockRange").
 		Doc("Returns a sequence of block metadata starting at first and ending at last").
-		Param(boneful.PathParameter("first", "Height at which to begin retrieval of blocks.").DataType("int").Required(true)).
-		Param(boneful.PathParameter("last", "Height at which to end retrieval of blocks.").DataType("int").Required(true)).
-		Param(boneful.QueryParameter("noempty", "Set to nonblank value to exclude empty blocks").DataType("string").Required(true)).
+		Param(pathParameter("first", "Height at which to begin retrieval of blocks.").DataType("int").Required(true)).
+		Param(pathParameter("last", "Height at which to end retrieval of blocks.").DataType("int").Required(true)).
+		Param(queryParameter("noempty", "Set to nonblank value to exclude empty blocks").DataType("string").Required(true)).
 		Produces(JSON).
 		Writes(rpctypes.ResultBlockchainInfo{
 			LastHeight: 12345,
@@ -273,24 +291,51 @@ func New(cf cfg.Cfg) *boneful.Service {
 	svc.Route(svc.GET("/block/transactions/:height").To(routes.HandleBlockTransactions(cf)).
 		Operation("BlockTransactions").
 		Doc("Returns transaction hashes for a given block. These can be used to fetch data for individual transactions.").
-		Param(boneful.PathParameter("height", "Height of the block in chain containing transactions.").DataType("int").Required(true)).
+		Param(pathParameter("height", "Height of the block in chain containing transactions.").DataType("int").Required(true)).
 		Produces(JSON).
 		Writes([]string{dummyTxHash}))
 
 	svc.Route(svc.GET("/block/daterange/:first/:last").To(routes.HandleBlockDateRange(cf)).
 		Operation("BlockDateRange").
 		Doc("Returns a sequence of block metadata starting at first date and ending at last date").
-		Param(boneful.PathParameter("first", "Timestamp (ISO 3339) at which to begin (inclusive) retrieval of blocks.").DataType("string").Required(true)).
-		Param(boneful.PathParameter("last", "Timestamp (ISO 3339) at which to end (exclusive) retrieval of blocks.").DataType("string").Required(true)).
-		Param(boneful.QueryParameter("noempty", "Set to nonblank value to exclude empty blocks").DataType("string").Required(true)).
-		Param(boneful.QueryParameter("after", "The timestamp after which results should start (use the last value from the previous page).").DataType("string").Required(false)).
-		Param(boneful.QueryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
+		Param(pathParameter("first", "Timestamp (ISO 3339) at which to begin (inclusive) retrieval of blocks.").DataType("string").Required(true)).
+		Param(pathParameter("last", "Timestamp (ISO 3339) at which to end (exclusive) retrieval of blocks.").DataType("string").Required(true)).
+		Param(queryParameter("noempty", "Set to nonblank value to exclude empty blocks").DataType("string").Required(true)).
+		Param(queryParameter("after", "The timestamp after which results should start (use the last value from the previous page).").DataType("string").Required(false)).
+		Param(queryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
 		Produces(JSON).
 		Writes(rpctypes.ResultBlockchainInfo{
 			LastHeight: 12345,
 			BlockMetas: []*tmtypes.BlockMeta{&dummyBlockMeta},
 		}))
 
+	svc.Route(svc.POST("/debug/vm/:vm").To(routes.HandleDebugVM(cf)).
+		Operation("DebugVM").
+		Doc("Re-runs one of the node's chaincode VMs at the current height, and traces its run.").
+		Notes(`The VM is one of txvalidation, txfees, rulesvalidation, nodegoodness, or sib. It is
+		built exactly as the node builds it: the first three for the transaction given in the body,
+		by its type and JSON as for /tx/attach; nodegoodness for the node whose address is given in
+		the body; and sib from the current prices, with no body. The response has the VM's program
+		disassembled one instruction per line, its stack before each instruction it executed, the
+		values it left on its stack, and the error which stopped it, if any. Use this when a tx is
+		rejected by a validation script or fee script, or a node's goodness can't be computed.`).
+		Param(pathParameter("vm", "The VM to run").DataType("string").Required(true)).
+		Consumes(JSON).
+		Reads(routes.VMDebugRequest{
+			TxType: "Lock",
+		}).
+		Produces(JSON).
+		Writes(query.VMDebugResponse{
+			VM:          query.VMSIB,
+			Disassembly: []string{"handler 00", "zero", "enddef"},
+			Trace: []query.VMStep{{
+				Offset:      2,
+				Instruction: "zero",
+				Stack:       []string{"1000", "1500", "500"},
+			}},
+			Stack: []string{"0", "1000", "1500", "500"},
+		}))
+
 	svc.Route(svc.GET("/node/status").To(routes.GetStatus(cf)).
 		Operation("NodeStatus").
 		Doc("Returns the status of the current node.").
@@ -300,8 +345,44 @@ func New(cf cfg.Cfg) *boneful.Service {
 	svc.Route(svc.GET("/node/health").To(routes.GetHealth(cf)).
 		Operation("NodeHealth").
 		Doc("Returns the health of the current node by doing a simple test for connectivity and response.").
-		Produces(JSON).
-		Writes(routes.HealthResponse{}))
+		Notes(`When the node has a search index, the response includes its status: "Behind" while
+		initial indexing is still catching up, during which searches may return incomplete
+		results. When the API is configured with a pool of nodes, the response also includes the
+		most recent health check of each node: whether it responded, whether it is catching up,
+		its height and how far it lags the highest node, and whether it is eligible to serve
+		requests. If no node is eligible, the status is "Degraded". When query results are
+		cached between blocks, the response also includes the cache's statistics.`).
+		Produces(JSON).
+		Writes(routes.HealthResponse{
+			Ndau:  routes.HealthStatus{Status: "Ok"},
+			Index: &routes.HealthStatus{Status: "Ok"},
+			Pool: []cfg.PoolNodeState{
+				cfg.PoolNodeState{
+					URL:         "http://node-0:26657",
+					Healthy:     true,
+					Height:      1234,
+					Eligible:    true,
+					LastChecked: dummyTime,
+				},
+				cfg.PoolNodeState{
+					URL:         "http://node-1:26657",
+					Healthy:     true,
+					CatchingUp:  true,
+					Height:      1000,
+					Lag:         234,
+					LastChecked: dummyTime,
+				},
+			},
+			Cache: &cfg.CacheStats{
+				Active:   true,
+				Height:   1234,
+				Hits:     980,
+				Misses:   20,
+				Entries:  20,
+				Bytes:    8192,
+				MaxBytes: 64 * 1024 * 1024,
+			},
+		}))
 
 	svc.Route(svc.GET("/node/net").To(routes.GetNetInfo(cf)).
 		Operation("NodeNetInfo").
@@ -327,6 +408,27 @@ func New(cf cfg.Cfg) *boneful.Service {
 		Produces(JSON).
 		Writes(rpctypes.ResultDumpConsensusState{}))
 
+	svc.Route(svc.GET("/node/mempool").To(routes.HandleMempool(cf)).
+		Operation("NodeMempool").
+		Doc("Returns the number of txs waiting in the mempool, and optionally the txs themselves.").
+		Notes(`Txs are only listed when requested, and then only the first 100 in the mempool;
+		truncated is set if there were more. Each tx is described by its type, hash, sequence,
+		and the address of the account it comes from. Wallets can use this to show pending
+		outgoing txs, and to avoid submitting the same tx twice.`).
+		Param(queryParameter("txs", "Set to nonblank value to list the pending txs").DataType("string").Required(false)).
+		Param(queryParameter("source", "List only the pending txs from this address; implies txs").DataType("string").Required(false)).
+		Produces(JSON).
+		Writes(routes.MempoolResult{
+			Count:      2,
+			TotalBytes: 512,
+			Txs: []routes.MempoolTx{{
+				TxType:   "Transfer",
+				TxHash:   dummyTxHash,
+				Source:   dummyAddress.String(),
+				Sequence: 7,
+			}},
+		}))
+
 	svc.Route(svc.GET("/node/nodes").To(routes.GetNodeList(cf)).
 		Operation("DEPRECATED:NodeList").
 		Doc("deprecated: please use /node/registerednodes").
@@ -350,10 +452,33 @@ func New(cf cfg.Cfg) *boneful.Service {
 		}),
 	)
 
+	svc.Route(svc.GET("/node/rewards/:address").To(routes.HandleNodeRewardHistory(cf)).
+		Operation("NodeRewardHistory").
+		Doc("Returns the node reward nominations won by a node, and whether and when each was claimed.").
+		Notes(`Each item is a single NominateNodeReward transaction won by the node at the given address,
+		including the random number used to select the winner and the reward amount. If the node
+		claimed the reward, the claim height, timestamp, and ClaimNodeReward transaction hash are
+		also included. The result is sorted chronologically.`).
+		Param(pathParameter("address", "The address of the node for which to return reward history").DataType("string").Required(true)).
+		Param(queryParameter("after", "The block height after which results should start.").DataType("string").Required(false)).
+		Param(queryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
+		Produces(JSON).
+		Writes(routes.NodeRewardHistory{Items: []srch.NodeRewardValueData{{
+			Height:         1234,
+			Timestamp:      dummyParsedTimestamp(),
+			Random:         5678,
+			Winner:         dummyAddress.String(),
+			Amount:         123000000,
+			Claimed:        true,
+			ClaimHeight:    1235,
+			ClaimTimestamp: dummyParsedTimestamp(),
+			ClaimTxHash:    dummyTxHash,
+		}}}))
+
 	svc.Route(svc.GET("/node/:id").To(routes.GetNode(cf)).
 		Operation("NodeID").
 		Doc("Returns a single node.").
-		Param(boneful.PathParameter("id", "the NodeID as a hex string")).
+		Param(pathParameter("id", "the NodeID as a hex string")).
 		Produces(JSON).
 		Writes(p2p.NodeInfo.NetAddress))
 
@@ -424,6 +549,187 @@ func New(cf cfg.Cfg) *boneful.Service {
 		Produces(JSON).
 		Writes(""))
 
+	svc.Route(svc.POST("/price/nav/history").To(routes.HandlePriceNAVHistory(cf)).
+		Operation("PriceNAVHistory").
+		Doc("Returns an array of data at each change point of the endowment NAV over time, sorted chronologically.").
+		Consumes(JSON).
+		Reads(srch.PriceQueryParams{
+			After:  srch.RangeEndpoint{Height: 1234},
+			Before: srch.RangeEndpoint{Timestamp: 20 * types.Year},
+			Limit:  1,
+		}).
+		Produces(JSON).
+		Writes(routes.PriceHistoryResults{
+			Items: []srch.PriceQueryResult{
+				srch.PriceQueryResult{
+					Price:     10000 * pricecurve.Dollar,
+					Height:    1235,
+					Timestamp: 19*types.Year + 8*types.Month + 12*types.Day,
+				},
+			},
+		}))
+
+	svc.Route(svc.POST("/price/sib/history").To(routes.HandlePriceSIBHistory(cf)).
+		Operation("PriceSIBHistory").
+		Doc("Returns an array of data at each change point of the SIB rate or floor price over time, sorted chronologically.").
+		Notes(`Each item includes the SIB rate in effect and the floor price as of the end of that block.
+		The SIB is only recalculated when a price is recorded, so it may have been calculated from
+		an earlier floor price.
+
+		History is only recorded as blocks are committed; it is not backfilled for blocks
+		from before the node's index was created.`).
+		Consumes(JSON).
+		Reads(srch.PriceQueryParams{
+			After:  srch.RangeEndpoint{Height: 1234},
+			Before: srch.RangeEndpoint{Timestamp: 20 * types.Year},
+			Limit:  1,
+		}).
+		Produces(JSON).
+		Writes(routes.SIBHistoryResults{
+			Items: []srch.SIBQueryResult{
+				srch.SIBQueryResult{
+					SIBValueData: srch.SIBValueData{
+						SIB:        9876543210,
+						FloorPrice: 2 * pricecurve.Dollar,
+					},
+					Height:    1235,
+					Timestamp: 19*types.Year + 8*types.Month + 12*types.Day,
+				},
+			},
+		}))
+
+	svc.Route(svc.POST("/state/supply/history").To(routes.HandleSupplyHistory(cf)).
+		Operation("StateSupplyHistory").
+		Doc("Returns an array of the ndau supply totals at each block where they changed, sorted chronologically.").
+		Notes(`The supply totals are those each block ended with. They include total ndau, total ndau
+		in circulation, total released from the endowment, total issued, total burned, the SIB rate,
+		and the endowment NAV.
+
+		The range may be specified by block height or by timestamp. Results are paged; when
+		more results are available, the "next" field contains the parameters for the next page.
+
+		History is only recorded as blocks are committed; it is not backfilled for blocks
+		from before the node's index was created.`).
+		Consumes(JSON).
+		Reads(srch.SupplyQueryParams{
+			After:  srch.RangeEndpoint{Height: 1234},
+			Before: srch.RangeEndpoint{Timestamp: 20 * types.Year},
+			Limit:  1,
+		}).
+		Produces(JSON).
+		Writes(routes.SupplyHistoryResults{
+			Items: []srch.SupplyQueryResult{
+				srch.SupplyQueryResult{
+					SupplyValueData: srch.SupplyValueData{
+						TotalNdau:        3141593 * 100000000,
+						TotalCirculation: 3141593 * 100000000,
+						TotalRFE:         2919000 * 100000000,
+						TotalIssue:       2919000 * 100000000,
+						TotalBurned:      123 * 100000000,
+						SIB:              9876543210,
+						EndowmentNAV:     10000 * pricecurve.Dollar,
+						FloorPrice:       2 * pricecurve.Dollar,
+					},
+					Height:    1235,
+					Timestamp: 19*types.Year + 8*types.Month + 12*types.Day,
+				},
+			},
+		}))
+
+	svc.Route(svc.POST("/state/unlocks").To(routes.HandleUnlocks(cf)).
+		Operation("StateUnlocks").
+		Doc("Returns the notified locks which unlock within a range of times, sorted by unlock time, with per-day totals.").
+		Notes(`Each item is an account whose lock has been notified, the time at which it unlocks,
+		and its balance as of the most recent transaction affecting it; uncredited EAI is not
+		included. Relocking an account before it unlocks removes it from the results.
+
+		The range selects on unlock time, and should be specified by timestamp. When "after" is
+		omitted, only unlocks after the current block time are returned; pass an earlier "after"
+		to see unlocks which have already happened. The per-day totals cover the whole range. Results are paged; when more results are available, the "next"
+		field contains the parameters for the next page.`).
+		Consumes(JSON).
+		Reads(srch.UnlocksQueryParams{
+			After:  srch.RangeEndpoint{Timestamp: 19 * types.Year},
+			Before: srch.RangeEndpoint{Timestamp: 20 * types.Year},
+			Limit:  1,
+		}).
+		Produces(JSON).
+		Writes(routes.UnlocksResults{
+			Items: []srch.UnlockValueData{
+				srch.UnlockValueData{
+					Address:   dummyAddress.String(),
+					UnlocksOn: 19*types.Year + 8*types.Month + 12*types.Day,
+					Balance:   123000000,
+					Height:    1234,
+				},
+			},
+			Days: []srch.UnlockDayTotal{
+				srch.UnlockDayTotal{
+					Day:      19*types.Year + 8*types.Month + 12*types.Day,
+					Accounts: 2,
+					Balance:  456000000,
+				},
+			},
+		}))
+
+	hub := routes.NewStreamHub(cf)
+	streamEvents := []routes.StreamEvent{
+		routes.StreamEvent{
+			Type:   routes.StreamEventBlock,
+			Height: 1234,
+			Block: &routes.StreamBlock{
+				Height:    1234,
+				Hash:      "0123456789abcdef0123456789abcdef01234567",
+				Timestamp: dummyTimestamp,
+				NumTxs:    1,
+			},
+		},
+		routes.StreamEvent{
+			Type:   routes.StreamEventTx,
+			Height: 1234,
+			Tx:     &dummyTransactionResult,
+		},
+		routes.StreamEvent{
+			Type:   routes.StreamEventAddress,
+			Height: 1234,
+			Address: &routes.StreamAddress{
+				Address:  dummyAddress.String(),
+				TxHashes: []string{"123abc34099f"},
+				Account:  &dummyAccount,
+			},
+		},
+	}
+
+	svc.Route(svc.GET("/stream/events").To(routes.HandleStreamSSE(hub)).
+		Operation("StreamEvents").
+		Doc("Streams new blocks, transactions and changes to watched accounts as Server-Sent Events.").
+		Notes(`Each event's name is its type, and its data is the JSON-encoded event. Block events
+		summarize each new block; tx events carry each decoded transaction in it; address events
+		report that a block's transactions touched a watched address, with the account's new data.
+
+		Clients which fall too far behind are disconnected, and should reconnect.`).
+		Param(queryParameter("events", "Comma-separated event types to receive: block, tx, address. Defaults to block and tx, plus address if any addresses are watched.").DataType("string").Required(false)).
+		Param(queryParameter("watch", fmt.Sprintf("Comma-separated addresses to watch, at most %d.", routes.MaxStreamWatch)).DataType("string").Required(false)).
+		Produces("text/event-stream").
+		Writes(streamEvents))
+
+	svc.Route(svc.GET("/stream/ws").To(routes.HandleStreamWS(hub)).
+		Operation("StreamWebsocket").
+		Doc("Streams new blocks, transactions and changes to watched accounts over a websocket.").
+		Notes(`The events and query parameters are the same as for /stream/events; each websocket
+		message is one JSON-encoded event. Clients may change the set of addresses they watch by
+		sending a message such as {"Watch": ["ndaaddr..."], "Unwatch": ["ndaaddr..."]}; watching
+		an address turns on address events. Invalid requests, and requests which would watch more
+		addresses than the limit, are answered with an error event and change nothing.`).
+		Param(queryParameter("events", "Comma-separated event types to receive: block, tx, address. Defaults to block and tx, plus address if any addresses are watched.").DataType("string").Required(false)).
+		Param(queryParameter("watch", fmt.Sprintf("Comma-separated addresses to watch, at most %d.", routes.MaxStreamWatch)).DataType("string").Required(false)).
+		Reads(routes.StreamWatchRequest{
+			Watch:   []string{dummyAddress.String()},
+			Unwatch: []string{dummyAddress2.String()},
+		}).
+		Produces(JSON).
+		Writes(streamEvents))
+
 	svc.Route(svc.GET("/system/all").To(routes.HandleSystemAll(cf)).
 		Operation("SystemAll").
 		Doc("Returns the names and current values of all currently-defined system variables.").
@@ -433,7 +739,7 @@ func New(cf cfg.Cfg) *boneful.Service {
 	svc.Route(svc.GET("/system/get/:sysvars").To(routes.HandleSystemGet(cf)).
 		Doc("Return the names and current values of some currently definted system variables.").
 		Operation("SystemGet").
-		Param(boneful.PathParameter("sysvars", "A comma-separated list of system variables of interest.").DataType("string").Required(true)).
+		Param(pathParameter("sysvars", "A comma-separated list of system variables of interest.").DataType("string").Required(true)).
 		Produces(JSON).
 		Writes(""))
 
@@ -448,7 +754,7 @@ func New(cf cfg.Cfg) *boneful.Service {
 		responsibility to update this transaction with appropriate sequence and
 		signatures and then send it at the normal endpoint (/tx/submit/setsysvar).`).
 		Operation("SystemSet").
-		Param(boneful.PathParameter("sysvar", "The name of the system variable to return").DataType("string").Required(true)).
+		Param(pathParameter("sysvar", "The name of the system variable to return").DataType("string").Required(true)).
 		Consumes(JSON).
 		Produces(JSON).
 		Writes(""))
@@ -458,9 +764,9 @@ func New(cf cfg.Cfg) *boneful.Service {
 		Notes(`The history includes the height and value of each change to the system variable.
 		The result is sorted chronologically.`).
 		Operation("SystemHistory").
-		Param(boneful.PathParameter("sysvar", "The name of the system variable for which to return history").DataType("string").Required(true)).
-		Param(boneful.QueryParameter("after", "The block height after which results should start.").DataType("string").Required(false)).
-		Param(boneful.QueryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
+		Param(pathParameter("sysvar", "The name of the system variable for which to return history").DataType("string").Required(true)).
+		Param(queryParameter("after", "The block height after which results should start.").DataType("string").Required(false)).
+		Param(queryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
 		Produces(JSON).
 		Writes(query.SysvarHistoryResponse{History: []query.SysvarHistoricalValue{{
 			Height: 12345,
@@ -480,8 +786,17 @@ func New(cf cfg.Cfg) *boneful.Service {
 		event the account is locked and has a non-nil "unlocksOn" value.
 		If the timestamp field is omitted, the current time is used.
 
+		If "fromAccount" is set, the address must be that of an existing
+		account; its weighted average age and lock are used instead of
+		those in the request, and the response also includes the EAI the
+		account would be credited if EAI were credited at the given time.
+
+		Rates are calculated from the chain's current rate tables, including
+		the current lock bonus where the chain uses it.
+
 		EAIRate in the response is an integer equal to the fractional EAI
-		rate times 10^12.
+		rate times 10^12. NetEAIRate is the same rate net of EAI fees, so it
+		matches what the chain actually pays.
 		`).
 		Consumes(JSON).
 		Reads([]routes.EAIRateRequest{routes.EAIRateRequest{
@@ -489,11 +804,21 @@ func New(cf cfg.Cfg) *boneful.Service {
 			WAA:     90 * types.Day,
 			Lock:    *backing.NewLock(180*types.Day, eai.DefaultLockBonusEAI),
 			At:      dummyParsedTimestamp(),
+		}, routes.EAIRateRequest{
+			Address:     dummyAddress2.String(),
+			At:          dummyParsedTimestamp(),
+			FromAccount: true,
 		}}).
 		Produces(JSON).
 		Writes([]routes.EAIRateResponse{routes.EAIRateResponse{
-			Address: dummyAddress.String(),
-			EAIRate: 60000000000,
+			Address:    dummyAddress.String(),
+			EAIRate:    60000000000,
+			NetEAIRate: 51000000000,
+		}, routes.EAIRateResponse{
+			Address:    dummyAddress2.String(),
+			EAIRate:    40000000000,
+			NetEAIRate: 34000000000,
+			EAI:        &dummyEAI,
 		}}))
 
 	svc.Route(svc.GET("/transaction/:txhash").To(routes.HandleTransactionFetchDeprecated(cf)).
@@ -511,12 +836,77 @@ func New(cf cfg.Cfg) *boneful.Service {
 	svc.Route(svc.GET("/transaction/before/:txhash").To(routes.HandleTransactionBefore(cf)).
 		Operation("TransactionBefore").
 		Doc("Returns a sequence of transaction metadata for transactions on or before a given transaction hash.").
-		Param(boneful.PathParameter("txhash", "Only transactions on or before this will be returned. Use 'start' to get the most recent page of transactions. Use a numeric block height to get transactions in and before that block").DataType("string").Required(false)).
-		Param(boneful.QueryParameter("type", "Case-insensitive transaction type name to filter by. Use multiple instances of this parameter to get results for multiple transaction types. Leave off to get transactions of any type").DataType("string").Required(false)).
-		Param(boneful.QueryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
+		Param(pathParameter("txhash", "Only transactions on or before this will be returned. Use 'start' to get the most recent page of transactions. Use a numeric block height to get transactions in and before that block").DataType("string").Required(false)).
+		Param(queryParameter("type", "Case-insensitive transaction type name to filter by. Use multiple instances of this parameter to get results for multiple transaction types. Leave off to get transactions of any type").DataType("string").Required(false)).
+		Param(queryParameter("limit", "The maximum number of items to return. Use a positive limit, or 0 for getting max results; default=0, max=100").DataType("int").Required(false)).
 		Produces(JSON).
 		Writes(dummyTransactionList))
 
+	svc.Route(svc.POST("/tx/build/:txtype").To(routes.HandleBuildTx(cf)).
+		Doc("Completes a partial transaction and returns the bytes its signers must sign.").
+		Notes(`The body is JSON for any defined transaction type (see submit), without signatures.
+		If the sequence is omitted or 0, it is filled in with the next sequence of the transaction's
+		source account. The response contains the completed transaction, its estimated fee and SIB
+		in napu, and its base64-encoded signable bytes. Sign those bytes, then use /tx/attach to
+		add the signatures.`).
+		Operation("TxBuild").
+		Consumes(JSON).
+		Reads(dummyLockTx).
+		Produces(JSON).
+		Writes(routes.BuildResult{
+			TxType:        "Lock",
+			Tx:            dummyLockTx,
+			Source:        dummyAddress.String(),
+			FeeNapu:       100,
+			SibNapu:       10,
+			SignableBytes: "c2lnbmFibGUgYnl0ZXM=",
+		}))
+
+	svc.Route(svc.POST("/tx/attach").To(routes.HandleAttachTx(cf)).
+		Doc("Attaches signatures to a transaction, producing a body ready for /tx/submit.").
+		Notes(`The body contains the transaction type and transaction, as returned by /tx/build,
+		and the signatures of its signable bytes. Signatures the transaction already has are
+		not added again. The resulting transaction can be posted as-is to /tx/submit/:txtype.`).
+		Operation("TxAttach").
+		Consumes(JSON).
+		Reads(routes.AttachRequest{
+			TxType: "Lock",
+		}).
+		Produces(JSON).
+		Writes(routes.AttachResult{
+			TxType: "Lock",
+			Tx:     dummyLockTx,
+			TxHash: "123abc34099f",
+		}))
+
+	svc.Route(svc.POST("/tx/validationscript").To(routes.HandleValidationScript(cf)).
+		Doc("Runs a validation script against a sample transaction, without changing anything.").
+		Notes(`The body contains a base64-encoded chaincode script, and a transaction type and
+		transaction as for /tx/attach, which need not be signed. The script sees the current state
+		of the transaction's source account and the transaction as though it had been signed by the
+		validation keys whose bits are set in signatures. If validation_keys is set, it replaces the
+		account's validation keys, so that a script can be tried with the keys it will be installed
+		with. The response has the script's exit code, which must be 0 for the transaction to be
+		accepted, any error it raised, and a trace of the stack before each instruction.
+
+		/tx/build refuses a ChangeValidation whose script would reject the owner's next
+		ChangeValidation signed by all the new validation keys.`).
+		Operation("TxValidationScript").
+		Consumes(JSON).
+		Reads(routes.ValidationScriptRequest{
+			Script:     []byte{0xa0, 0x00, 0x20, 0x88},
+			TxType:     "Lock",
+			Signatures: 1,
+		}).
+		Produces(JSON).
+		Writes(query.ValidationScriptResponse{
+			Trace: []query.VMStep{{
+				Offset:      2,
+				Instruction: "zero",
+				Stack:       []string{"1"},
+			}},
+		}))
+
 	svc.Route(svc.POST("/tx/prevalidate/:txtype").To(routes.HandlePrevalidateTx(cf)).
 		Doc("Prevalidates a transaction (tells if it would be accepted and what the transaction fee will be.").
 		Notes("Transactions consist of JSON for any defined transaction type (see submit).").
@@ -535,6 +925,41 @@ func New(cf cfg.Cfg) *boneful.Service {
 		Produces(JSON).
 		Writes(dummySubmitResult))
 
+	tracker := routes.NewTxTracker()
+
+	svc.Route(svc.POST("/tx/submitasync/:txtype").To(routes.HandleSubmitTxAsync(cf, tracker)).
+		Doc("Submits a transaction without waiting for it to be committed.").
+		Notes(`Returns the tx hash as soon as the node has checked the transaction and accepted it
+		into its mempool, with http status 202. If the node rejects the transaction, the status
+		is 400 and the log explains why. Use /tx/status to follow the transaction from there.
+
+		Transactions consist of JSON for any defined transaction type (see submit).`).
+		Operation("TxSubmitAsync").
+		Consumes(JSON).
+		Reads(dummyLockTx).
+		Produces(JSON).
+		Writes(dummySubmitResult))
+
+	svc.Route(svc.GET("/tx/status/:txhash").To(routes.HandleTxStatus(cf, tracker)).
+		Doc("Returns the status of a submitted transaction: pending, committed, rejected, or dropped.").
+		Notes(`A pending transaction is waiting in the mempool. A committed transaction reports the
+		height and offset at which it was included in a block, and its fee and SIB. A rejected
+		transaction reports the node's CheckTx log. A dropped transaction passed CheckTx, but has
+		since left the mempool without being committed; it may be resubmitted. Rejected and
+		dropped transactions are only known for an hour after they were submitted through
+		/tx/submitasync. Unknown transactions return http status 404.`).
+		Param(pathParameter("txhash", "Hash of the transaction.").DataType("string").Required(true)).
+		Operation("TxStatus").
+		Produces(JSON).
+		Writes(routes.TxStatus{
+			TxHash:      "123abc34099f",
+			Status:      routes.TxStatusCommitted,
+			BlockHeight: 1234,
+			TxOffset:    3,
+			Fee:         100,
+			SIB:         10,
+		}))
+
 	svc.Route(svc.GET("/version").To(routes.HandleVersion(cf)).
 		Doc("Delivers version information").
 		Operation("Version").
@@ -544,7 +969,16 @@ func New(cf cfg.Cfg) *boneful.Service {
 			NdauSha:     "23abc35",
 			Network:     "mainnet",
 		}))
-	return svc
+
+	svc.Route(svc.GET("/openapi.json").To(svc.HandleOpenAPI()).
+		Doc("Returns an OpenAPI 3 document describing this service.").
+		Notes(`The document is generated from the same route definitions as this documentation,
+		and includes request and response schemas for every ndau transaction type. It is intended
+		for generating typed API clients.`).
+		Operation("OpenAPI").
+		Produces(JSON))
+
+	return svc.Service
 }
 
 // Add call to get list of nodes
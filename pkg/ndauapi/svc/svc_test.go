@@ -10,11 +10,17 @@ package svc
 // - -- --- ---- -----
 
 import (
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
+	"github.com/ndau/ndau/pkg/ndau"
 	"github.com/ndau/ndau/pkg/ndauapi/cfg"
+	"github.com/ndau/ndau/pkg/ndauapi/routes"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRouting(t *testing.T) {
@@ -30,24 +36,34 @@ func TestRouting(t *testing.T) {
 		rt{"GET", "/account/history/123456", "/account/history/:address"},
 		rt{"GET", "/account/list", "/account/list"},
 		rt{"GET", "/account/currencyseats", "/account/currencyseats"},
+		rt{"GET", "/account/currencyseats/details", "/account/currencyseats/details"},
 		rt{"GET", "/block/before/123", "/block/before/:height"},
 		rt{"GET", "/block/hash/abc123", "/block/hash/:blockhash"},
 		rt{"GET", "/block/height/10234", "/block/height/:height"},
 		rt{"GET", "/block/range/123/143", "/block/range/:first/:last"},
 		rt{"GET", "/block/daterange/x/y", "/block/daterange/:first/:last"},
 		rt{"GET", "/block/transactions/555", "/block/transactions/:height"},
+		rt{"POST", "/debug/vm/txfees", "/debug/vm/:vm"},
 		rt{"GET", "/node/status", "/node/status"},
 		rt{"GET", "/node/health", "/node/health"},
 		rt{"GET", "/node/net", "/node/net"},
 		rt{"GET", "/node/genesis", "/node/genesis"},
 		rt{"GET", "/node/abci", "/node/abci"},
 		rt{"GET", "/node/consensus", "/node/consensus"},
+		rt{"GET", "/node/mempool", "/node/mempool"},
 		rt{"GET", "/node/nodes", "/node/nodes"},
 		rt{"GET", "/node/registerednodes", "/node/registerednodes"},
+		rt{"GET", "/node/rewards/123456", "/node/rewards/:address"},
 		rt{"GET", "/node/ad349f", "/node/:id"},
 		rt{"POST", "/price/target/history", "/price/target/history"},
 		rt{"POST", "/price/market/history", "/price/market/history"},
+		rt{"POST", "/price/nav/history", "/price/nav/history"},
+		rt{"POST", "/price/sib/history", "/price/sib/history"},
 		rt{"GET", "/price/current", "/price/current"},
+		rt{"POST", "/state/supply/history", "/state/supply/history"},
+		rt{"POST", "/state/unlocks", "/state/unlocks"},
+		rt{"GET", "/stream/events", "/stream/events"},
+		rt{"GET", "/stream/ws", "/stream/ws"},
 		rt{"GET", "/system/all", "/system/all"},
 		rt{"GET", "/system/get/foo,bar", "/system/get/:sysvars"},
 		rt{"POST", "/system/set/foo", "/system/set/:sysvar"},
@@ -55,9 +71,15 @@ func TestRouting(t *testing.T) {
 		rt{"POST", "/system/eai/rate", "/system/eai/rate"},
 		rt{"GET", "/transaction/detail/5469abfed", "/transaction/detail/:txhash"},
 		rt{"GET", "/transaction/before/5469abfed", "/transaction/before/:txhash"},
+		rt{"POST", "/tx/build/lock", "/tx/build/:txtype"},
+		rt{"POST", "/tx/attach", "/tx/attach"},
 		rt{"POST", "/tx/prevalidate/lock", "/tx/prevalidate/:txtype"},
+		rt{"POST", "/tx/validationscript", "/tx/validationscript"},
 		rt{"POST", "/tx/submit/transfer", "/tx/submit/:txtype"},
+		rt{"POST", "/tx/submitasync/transfer", "/tx/submitasync/:txtype"},
+		rt{"GET", "/tx/status/5469abfed", "/tx/status/:txhash"},
 		rt{"GET", "/version", "/version"},
+		rt{"GET", "/openapi.json", "/openapi.json"},
 	}
 
 	cf := cfg.Cfg{}
@@ -71,3 +93,69 @@ func TestRouting(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenAPI(t *testing.T) {
+	mux := New(cfg.Cfg{}).Mux()
+
+	req := httptest.NewRequest("GET", "/openapi.json", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var doc openAPIDocument
+	err := json.Unmarshal(w.Body.Bytes(), &doc)
+	require.NoError(t, err)
+	require.Equal(t, "3.0.3", doc.OpenAPI)
+
+	// Path parameters are written the OpenAPI way.
+	require.Contains(t, doc.Paths, "/account/account/{address}")
+	require.Contains(t, doc.Paths["/tx/submit/{txtype}"], "post")
+	require.NotNil(t, doc.Paths["/tx/submit/{txtype}"]["post"].RequestBody)
+
+	// Every tx type has a schema.
+	for _, tx := range ndau.TxIDs {
+		name := "ndau." + reflect.TypeOf(tx).Elem().Name()
+		assert.Contains(t, doc.Components.Schemas, name)
+	}
+	require.Contains(t, doc.Components.Schemas, transactionSchema)
+
+	// Every path parameter is in its path.
+	for p, ops := range doc.Paths {
+		for method, op := range ops {
+			for _, param := range op.Parameters {
+				if param.In == "path" {
+					assert.Contains(t, p, "{"+param.Name+"}", "%s %s", method, p)
+				}
+			}
+		}
+	}
+}
+
+func TestOpenAPIPathParams(t *testing.T) {
+	svc := newDocumentedService()
+	r := svc.GET("/account/votes/:address/:date").
+		Param(pathParameter("address", "The address").DataType("string")).
+		Param(pathParameter("DATE", "The date").DataType("string"))
+	_, _, err := r.operationOf(newSchemaBuilder())
+	require.Error(t, err)
+
+	r = svc.GET("/account/votes/:address/:date").
+		Param(pathParameter("address", "The address").DataType("string")).
+		Param(pathParameter("date", "The date").DataType("string"))
+	p, op, err := r.operationOf(newSchemaBuilder())
+	require.NoError(t, err)
+	require.Equal(t, "/account/votes/{address}/{date}", p)
+	require.Len(t, op.Parameters, 2)
+}
+
+func TestSchemaNameCollision(t *testing.T) {
+	sb := newSchemaBuilder()
+	status := reflect.TypeOf(routes.TxStatus{})
+	require.Equal(t, "routes.TxStatus", sb.schemaName(status))
+	require.Equal(t, "routes.TxStatus", sb.schemaName(status))
+
+	// a type from another package called routes, with the same name
+	sb = newSchemaBuilder()
+	sb.types["routes.TxStatus"] = reflect.TypeOf(struct{}{})
+	require.Equal(t, "github.com.ndau.ndau.pkg.ndauapi.routes.TxStatus", sb.schemaName(status))
+}
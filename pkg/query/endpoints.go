@@ -11,19 +11,31 @@ package query
 
 // These constants define the endpoints at which the Tm RPC will forward requests
 const (
-	AccountEndpoint        = "/account"
-	AccountHistoryEndpoint = "/accounthistory"
-	AccountListEndpoint    = "/accountlist"
-	DateRangeEndpoint      = "/daterange"
-	DelegatesEndpoint      = "/delegates"
-	NodesEndpoint          = "/nodes"
-	PrevalidateEndpoint    = "/prevalidate"
-	PriceTargetEndpoint    = "/price/target"
-	PriceMarketEndpoint    = "/price/market"
-	SearchEndpoint         = "/search"
-	SIBEndpoint            = "/sib"
-	SummaryEndpoint        = "/summary"
-	SysvarHistoryEndpoint  = "/sysvarhistory"
-	SysvarsEndpoint        = "/sysvars"
-	VersionEndpoint        = "/version"
+	AccountEndpoint          = "/account"
+	AccountsEndpoint         = "/accounts"
+	AccountHistoryEndpoint   = "/accounthistory"
+	AccountListEndpoint      = "/accountlist"
+	CurrencySeatsEndpoint    = "/currencyseats"
+	DateRangeEndpoint        = "/daterange"
+	DebugVMEndpoint          = "/debug/vm"
+	DelegatesEndpoint        = "/delegates"
+	FeatureEndpoint          = "/feature"
+	IndexStatusEndpoint      = "/indexstatus"
+	NodesEndpoint            = "/nodes"
+	NodeRewardsEndpoint      = "/noderewards"
+	PrevalidateEndpoint      = "/prevalidate"
+	PriceTargetEndpoint      = "/price/target"
+	PriceMarketEndpoint      = "/price/market"
+	PriceNAVEndpoint         = "/price/nav"
+	PriceSIBEndpoint         = "/price/sib"
+	SearchEndpoint           = "/search"
+	SIBEndpoint              = "/sib"
+	SummaryEndpoint          = "/summary"
+	SupplyHistoryEndpoint    = "/supply/history"
+	SysvarHistoryEndpoint    = "/sysvarhistory"
+	SysvarsEndpoint          = "/sysvars"
+	TxSourceEndpoint         = "/txsource"
+	UnlocksEndpoint          = "/unlocks"
+	ValidationScriptEndpoint = "/validationscript"
+	VersionEndpoint          = "/version"
 )
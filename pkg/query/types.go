@@ -14,6 +14,7 @@ import (
 	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/ndau/ndaumath/pkg/eai"
 	"github.com/ndau/ndaumath/pkg/pricecurve"
+	"github.com/ndau/ndaumath/pkg/signature"
 	"github.com/ndau/ndaumath/pkg/types"
 )
 
@@ -99,3 +100,101 @@ type NodeExtra struct {
 
 // NodesResponse is the return value from the /nodes endpoint
 type NodesResponse map[string]NodeExtra
+
+// CurrencySeat describes an account which holds a currency seat
+type CurrencySeat struct {
+	Address  address.Address `json:"address"`
+	SeatDate types.Timestamp `json:"seatDate"`
+	Balance  types.Ndau      `json:"balance"`
+}
+
+// CurrencySeatsResponse is the return value from the /currencyseats endpoint
+//
+// Seats are sorted by seat date, oldest first, so the most senior N seats
+// are simply the first N.
+type CurrencySeatsResponse []CurrencySeat
+
+// AccountsRequest is the request value for the /accounts endpoint
+type AccountsRequest []address.Address
+
+// AccountResult is the data of a single account returned by the /accounts endpoint
+//
+// Accounts which don't exist are returned with their default data, and Exists false.
+type AccountResult struct {
+	Exists bool                `json:"exists"`
+	Data   backing.AccountData `json:"data"`
+}
+
+// AccountsResponse is the return value from the /accounts endpoint, keyed by address
+type AccountsResponse map[string]AccountResult
+
+// VMStep is the state of a chaincode VM just before it executes an instruction.
+//
+// Stack lists the stack's values from the top down.
+type VMStep struct {
+	Offset      int      `json:"offset"`
+	Instruction string   `json:"instruction"`
+	Stack       []string `json:"stack"`
+}
+
+// ValidationScriptRequest is the request value for the /validationscript endpoint
+//
+// Tx is a msgp-serialized tx, which is validated by Script against the current state
+// of its source account as though it had been signed by the validation keys whose bits
+// are set in Signatures. If ValidationKeys is set, it replaces the account's own
+// validation keys, so that a script can be checked together with the keys it will be
+// installed with.
+type ValidationScriptRequest struct {
+	Script         []byte                `json:"script"`
+	Tx             []byte                `json:"tx"`
+	Signatures     uint64                `json:"signatures"`
+	ValidationKeys []signature.PublicKey `json:"validation_keys"`
+}
+
+// ValidationScriptResponse is the return value from the /validationscript endpoint
+//
+// The script accepts the tx when it runs without error and exits with 0. Trace records
+// each step of the script's run, up to a limit.
+type ValidationScriptResponse struct {
+	ExitCode int64    `json:"exit_code"`
+	Error    string   `json:"error,omitempty"`
+	Trace    []VMStep `json:"trace"`
+}
+
+// Accepted is true when the script accepts the tx
+func (r ValidationScriptResponse) Accepted() bool {
+	return r.Error == "" && r.ExitCode == 0
+}
+
+// These constants name the VMs which the /debug/vm endpoint can re-run
+const (
+	VMTxValidation    = "txvalidation"
+	VMTxFees          = "txfees"
+	VMNodeGoodness    = "nodegoodness"
+	VMSIB             = "sib"
+	VMRulesValidation = "rulesvalidation"
+)
+
+// VMDebugRequest is the request value for the /debug/vm endpoint
+//
+// VM is one of the VM constants. Tx is a msgp-serialized tx, which the tx validation,
+// tx fee and rules validation VMs need; Node is the address of the node whose
+// goodness VM is wanted. The SIB VM needs neither: it uses the current prices.
+type VMDebugRequest struct {
+	VM   string `json:"vm"`
+	Tx   []byte `json:"tx,omitempty"`
+	Node string `json:"node,omitempty"`
+}
+
+// VMDebugResponse is the return value from the /debug/vm endpoint
+//
+// Disassembly lists the VM's program, one instruction per line. Trace records each
+// step of its run, up to a limit, and Stack lists the values it left on its stack from
+// the top down.
+type VMDebugResponse struct {
+	VM          string   `json:"vm"`
+	Disassembly []string `json:"disassembly"`
+	Trace       []VMStep `json:"trace"`
+	Stack       []string `json:"stack"`
+	Error       string   `json:"error,omitempty"`
+}
@@ -4,6 +4,7 @@ package query
 
 import (
 	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/signature"
 	"github.com/tinylib/msgp/msgp"
 )
 
@@ -115,31 +116,24 @@ func (z *AccountListQueryResponse) Msgsize() (s int) {
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *DelegateList) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *AccountResult) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// map header, size 2
-	// string "Node"
-	o = append(o, 0x82, 0xa4, 0x4e, 0x6f, 0x64, 0x65)
-	o, err = z.Node.MarshalMsg(o)
+	// string "Exists"
+	o = append(o, 0x82, 0xa6, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73)
+	o = msgp.AppendBool(o, z.Exists)
+	// string "Data"
+	o = append(o, 0xa4, 0x44, 0x61, 0x74, 0x61)
+	o, err = z.Data.MarshalMsg(o)
 	if err != nil {
-		err = msgp.WrapError(err, "Node")
+		err = msgp.WrapError(err, "Data")
 		return
 	}
-	// string "Delegated"
-	o = append(o, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Delegated)))
-	for za0001 := range z.Delegated {
-		o, err = z.Delegated[za0001].MarshalMsg(o)
-		if err != nil {
-			err = msgp.WrapError(err, "Delegated", za0001)
-			return
-		}
-	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *DelegateList) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *AccountResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -156,31 +150,18 @@ func (z *DelegateList) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Node":
-			bts, err = z.Node.UnmarshalMsg(bts)
+		case "Exists":
+			z.Exists, bts, err = msgp.ReadBoolBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Node")
+				err = msgp.WrapError(err, "Exists")
 				return
 			}
-		case "Delegated":
-			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "Data":
+			bts, err = z.Data.UnmarshalMsg(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Delegated")
+				err = msgp.WrapError(err, "Data")
 				return
 			}
-			if cap(z.Delegated) >= int(zb0002) {
-				z.Delegated = (z.Delegated)[:zb0002]
-			} else {
-				z.Delegated = make([]address.Address, zb0002)
-			}
-			for za0001 := range z.Delegated {
-				bts, err = z.Delegated[za0001].UnmarshalMsg(bts)
-				if err != nil {
-					err = msgp.WrapError(err, "Delegated", za0001)
-					return
-				}
-			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -194,209 +175,73 @@ func (z *DelegateList) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *DelegateList) Msgsize() (s int) {
-	s = 1 + 5 + z.Node.Msgsize() + 10 + msgp.ArrayHeaderSize
-	for za0001 := range z.Delegated {
-		s += z.Delegated[za0001].Msgsize()
-	}
+func (z *AccountResult) Msgsize() (s int) {
+	s = 1 + 7 + msgp.BoolSize + 5 + z.Data.Msgsize()
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z DelegatesResponse) MarshalMsg(b []byte) (o []byte, err error) {
+func (z AccountsRequest) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	o = msgp.AppendArrayHeader(o, uint32(len(z)))
 	for za0001 := range z {
-		// map header, size 2
-		// string "Node"
-		o = append(o, 0x82, 0xa4, 0x4e, 0x6f, 0x64, 0x65)
-		o, err = z[za0001].Node.MarshalMsg(o)
+		o, err = z[za0001].MarshalMsg(o)
 		if err != nil {
-			err = msgp.WrapError(err, za0001, "Node")
+			err = msgp.WrapError(err, za0001)
 			return
 		}
-		// string "Delegated"
-		o = append(o, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64)
-		o = msgp.AppendArrayHeader(o, uint32(len(z[za0001].Delegated)))
-		for za0002 := range z[za0001].Delegated {
-			o, err = z[za0001].Delegated[za0002].MarshalMsg(o)
-			if err != nil {
-				err = msgp.WrapError(err, za0001, "Delegated", za0002)
-				return
-			}
-		}
 	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *DelegatesResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
-	var zb0003 uint32
-	zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+func (z *AccountsRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0002 uint32
+	zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 	if err != nil {
 		err = msgp.WrapError(err)
 		return
 	}
-	if cap((*z)) >= int(zb0003) {
-		(*z) = (*z)[:zb0003]
+	if cap((*z)) >= int(zb0002) {
+		(*z) = (*z)[:zb0002]
 	} else {
-		(*z) = make(DelegatesResponse, zb0003)
+		(*z) = make(AccountsRequest, zb0002)
 	}
 	for zb0001 := range *z {
-		var field []byte
-		_ = field
-		var zb0004 uint32
-		zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+		bts, err = (*z)[zb0001].UnmarshalMsg(bts)
 		if err != nil {
 			err = msgp.WrapError(err, zb0001)
 			return
 		}
-		for zb0004 > 0 {
-			zb0004--
-			field, bts, err = msgp.ReadMapKeyZC(bts)
-			if err != nil {
-				err = msgp.WrapError(err, zb0001)
-				return
-			}
-			switch msgp.UnsafeString(field) {
-			case "Node":
-				bts, err = (*z)[zb0001].Node.UnmarshalMsg(bts)
-				if err != nil {
-					err = msgp.WrapError(err, zb0001, "Node")
-					return
-				}
-			case "Delegated":
-				var zb0005 uint32
-				zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
-				if err != nil {
-					err = msgp.WrapError(err, zb0001, "Delegated")
-					return
-				}
-				if cap((*z)[zb0001].Delegated) >= int(zb0005) {
-					(*z)[zb0001].Delegated = ((*z)[zb0001].Delegated)[:zb0005]
-				} else {
-					(*z)[zb0001].Delegated = make([]address.Address, zb0005)
-				}
-				for zb0002 := range (*z)[zb0001].Delegated {
-					bts, err = (*z)[zb0001].Delegated[zb0002].UnmarshalMsg(bts)
-					if err != nil {
-						err = msgp.WrapError(err, zb0001, "Delegated", zb0002)
-						return
-					}
-				}
-			default:
-				bts, err = msgp.Skip(bts)
-				if err != nil {
-					err = msgp.WrapError(err, zb0001)
-					return
-				}
-			}
-		}
 	}
 	o = bts
 	return
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z DelegatesResponse) Msgsize() (s int) {
+func (z AccountsRequest) Msgsize() (s int) {
 	s = msgp.ArrayHeaderSize
-	for zb0006 := range z {
-		s += 1 + 5 + z[zb0006].Node.Msgsize() + 10 + msgp.ArrayHeaderSize
-		for zb0007 := range z[zb0006].Delegated {
-			s += z[zb0006].Delegated[zb0007].Msgsize()
-		}
-	}
-	return
-}
-
-// MarshalMsg implements msgp.Marshaler
-func (z *NodeExtra) MarshalMsg(b []byte) (o []byte, err error) {
-	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
-	// string "Node"
-	o = append(o, 0x82, 0xa4, 0x4e, 0x6f, 0x64, 0x65)
-	o, err = z.Node.MarshalMsg(o)
-	if err != nil {
-		err = msgp.WrapError(err, "Node")
-		return
-	}
-	// string "Registration"
-	o = append(o, 0xac, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e)
-	o, err = z.Registration.MarshalMsg(o)
-	if err != nil {
-		err = msgp.WrapError(err, "Registration")
-		return
-	}
-	return
-}
-
-// UnmarshalMsg implements msgp.Unmarshaler
-func (z *NodeExtra) UnmarshalMsg(bts []byte) (o []byte, err error) {
-	var field []byte
-	_ = field
-	var zb0001 uint32
-	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
-	if err != nil {
-		err = msgp.WrapError(err)
-		return
-	}
-	for zb0001 > 0 {
-		zb0001--
-		field, bts, err = msgp.ReadMapKeyZC(bts)
-		if err != nil {
-			err = msgp.WrapError(err)
-			return
-		}
-		switch msgp.UnsafeString(field) {
-		case "Node":
-			bts, err = z.Node.UnmarshalMsg(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "Node")
-				return
-			}
-		case "Registration":
-			bts, err = z.Registration.UnmarshalMsg(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "Registration")
-				return
-			}
-		default:
-			bts, err = msgp.Skip(bts)
-			if err != nil {
-				err = msgp.WrapError(err)
-				return
-			}
-		}
+	for zb0003 := range z {
+		s += z[zb0003].Msgsize()
 	}
-	o = bts
-	return
-}
-
-// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *NodeExtra) Msgsize() (s int) {
-	s = 1 + 5 + z.Node.Msgsize() + 13 + z.Registration.Msgsize()
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z NodesResponse) MarshalMsg(b []byte) (o []byte, err error) {
+func (z AccountsResponse) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	o = msgp.AppendMapHeader(o, uint32(len(z)))
 	for za0001, za0002 := range z {
 		o = msgp.AppendString(o, za0001)
 		// map header, size 2
-		// string "Node"
-		o = append(o, 0x82, 0xa4, 0x4e, 0x6f, 0x64, 0x65)
-		o, err = za0002.Node.MarshalMsg(o)
-		if err != nil {
-			err = msgp.WrapError(err, za0001, "Node")
-			return
-		}
-		// string "Registration"
-		o = append(o, 0xac, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e)
-		o, err = za0002.Registration.MarshalMsg(o)
+		// string "Exists"
+		o = append(o, 0x82, 0xa6, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73)
+		o = msgp.AppendBool(o, za0002.Exists)
+		// string "Data"
+		o = append(o, 0xa4, 0x44, 0x61, 0x74, 0x61)
+		o, err = za0002.Data.MarshalMsg(o)
 		if err != nil {
-			err = msgp.WrapError(err, za0001, "Registration")
+			err = msgp.WrapError(err, za0001, "Data")
 			return
 		}
 	}
@@ -404,7 +249,7 @@ func (z NodesResponse) MarshalMsg(b []byte) (o []byte, err error) {
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *NodesResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *AccountsResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var zb0003 uint32
 	zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
 	if err != nil {
@@ -412,7 +257,7 @@ func (z *NodesResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 		return
 	}
 	if (*z) == nil {
-		(*z) = make(NodesResponse, zb0003)
+		(*z) = make(AccountsResponse, zb0003)
 	} else if len((*z)) > 0 {
 		for key := range *z {
 			delete((*z), key)
@@ -420,7 +265,7 @@ func (z *NodesResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	}
 	for zb0003 > 0 {
 		var zb0001 string
-		var zb0002 NodeExtra
+		var zb0002 AccountResult
 		zb0003--
 		zb0001, bts, err = msgp.ReadStringBytes(bts)
 		if err != nil {
@@ -443,16 +288,16 @@ func (z *NodesResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 			switch msgp.UnsafeString(field) {
-			case "Node":
-				bts, err = zb0002.Node.UnmarshalMsg(bts)
+			case "Exists":
+				zb0002.Exists, bts, err = msgp.ReadBoolBytes(bts)
 				if err != nil {
-					err = msgp.WrapError(err, zb0001, "Node")
+					err = msgp.WrapError(err, zb0001, "Exists")
 					return
 				}
-			case "Registration":
-				bts, err = zb0002.Registration.UnmarshalMsg(bts)
+			case "Data":
+				bts, err = zb0002.Data.UnmarshalMsg(bts)
 				if err != nil {
-					err = msgp.WrapError(err, zb0001, "Registration")
+					err = msgp.WrapError(err, zb0001, "Data")
 					return
 				}
 			default:
@@ -470,54 +315,47 @@ func (z *NodesResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z NodesResponse) Msgsize() (s int) {
+func (z AccountsResponse) Msgsize() (s int) {
 	s = msgp.MapHeaderSize
 	if z != nil {
 		for zb0005, zb0006 := range z {
 			_ = zb0006
-			s += msgp.StringPrefixSize + len(zb0005) + 1 + 5 + zb0006.Node.Msgsize() + 13 + zb0006.Registration.Msgsize()
+			s += msgp.StringPrefixSize + len(zb0005) + 1 + 7 + msgp.BoolSize + 5 + zb0006.Data.Msgsize()
 		}
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *SIBResponse) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *CurrencySeat) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
-	// string "SIB"
-	o = append(o, 0x84, 0xa3, 0x53, 0x49, 0x42)
-	o, err = z.SIB.MarshalMsg(o)
-	if err != nil {
-		err = msgp.WrapError(err, "SIB")
-		return
-	}
-	// string "TargetPrice"
-	o = append(o, 0xab, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65)
-	o, err = z.TargetPrice.MarshalMsg(o)
+	// map header, size 3
+	// string "Address"
+	o = append(o, 0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o, err = z.Address.MarshalMsg(o)
 	if err != nil {
-		err = msgp.WrapError(err, "TargetPrice")
+		err = msgp.WrapError(err, "Address")
 		return
 	}
-	// string "MarketPrice"
-	o = append(o, 0xab, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65)
-	o, err = z.MarketPrice.MarshalMsg(o)
+	// string "SeatDate"
+	o = append(o, 0xa8, 0x53, 0x65, 0x61, 0x74, 0x44, 0x61, 0x74, 0x65)
+	o, err = z.SeatDate.MarshalMsg(o)
 	if err != nil {
-		err = msgp.WrapError(err, "MarketPrice")
+		err = msgp.WrapError(err, "SeatDate")
 		return
 	}
-	// string "FloorPrice"
-	o = append(o, 0xaa, 0x46, 0x6c, 0x6f, 0x6f, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65)
-	o, err = z.FloorPrice.MarshalMsg(o)
+	// string "Balance"
+	o = append(o, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+	o, err = z.Balance.MarshalMsg(o)
 	if err != nil {
-		err = msgp.WrapError(err, "FloorPrice")
+		err = msgp.WrapError(err, "Balance")
 		return
 	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *SIBResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *CurrencySeat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -534,28 +372,22 @@ func (z *SIBResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "SIB":
-			bts, err = z.SIB.UnmarshalMsg(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "SIB")
-				return
-			}
-		case "TargetPrice":
-			bts, err = z.TargetPrice.UnmarshalMsg(bts)
+		case "Address":
+			bts, err = z.Address.UnmarshalMsg(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "TargetPrice")
+				err = msgp.WrapError(err, "Address")
 				return
 			}
-		case "MarketPrice":
-			bts, err = z.MarketPrice.UnmarshalMsg(bts)
+		case "SeatDate":
+			bts, err = z.SeatDate.UnmarshalMsg(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "MarketPrice")
+				err = msgp.WrapError(err, "SeatDate")
 				return
 			}
-		case "FloorPrice":
-			bts, err = z.FloorPrice.UnmarshalMsg(bts)
+		case "Balance":
+			bts, err = z.Balance.UnmarshalMsg(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "FloorPrice")
+				err = msgp.WrapError(err, "Balance")
 				return
 			}
 		default:
@@ -571,33 +403,856 @@ func (z *SIBResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *SIBResponse) Msgsize() (s int) {
-	s = 1 + 4 + z.SIB.Msgsize() + 12 + z.TargetPrice.Msgsize() + 12 + z.MarketPrice.Msgsize() + 11 + z.FloorPrice.Msgsize()
+func (z *CurrencySeat) Msgsize() (s int) {
+	s = 1 + 8 + z.Address.Msgsize() + 9 + z.SeatDate.Msgsize() + 8 + z.Balance.Msgsize()
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *SidechainTxExistsQuery) MarshalMsg(b []byte) (o []byte, err error) {
+func (z CurrencySeatsResponse) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "SidechainID"
-	o = append(o, 0x83, 0xab, 0x53, 0x69, 0x64, 0x65, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44)
-	o = msgp.AppendByte(o, z.SidechainID)
-	// string "Source"
+	o = msgp.AppendArrayHeader(o, uint32(len(z)))
+	for za0001 := range z {
+		// map header, size 3
+		// string "Address"
+		o = append(o, 0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+		o, err = z[za0001].Address.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, za0001, "Address")
+			return
+		}
+		// string "SeatDate"
+		o = append(o, 0xa8, 0x53, 0x65, 0x61, 0x74, 0x44, 0x61, 0x74, 0x65)
+		o, err = z[za0001].SeatDate.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, za0001, "SeatDate")
+			return
+		}
+		// string "Balance"
+		o = append(o, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+		o, err = z[za0001].Balance.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, za0001, "Balance")
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *CurrencySeatsResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0002 uint32
+	zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if cap((*z)) >= int(zb0002) {
+		(*z) = (*z)[:zb0002]
+	} else {
+		(*z) = make(CurrencySeatsResponse, zb0002)
+	}
+	for zb0001 := range *z {
+		var field []byte
+		_ = field
+		var zb0003 uint32
+		zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err, zb0001)
+			return
+		}
+		for zb0003 > 0 {
+			zb0003--
+			field, bts, err = msgp.ReadMapKeyZC(bts)
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			switch msgp.UnsafeString(field) {
+			case "Address":
+				bts, err = (*z)[zb0001].Address.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "Address")
+					return
+				}
+			case "SeatDate":
+				bts, err = (*z)[zb0001].SeatDate.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "SeatDate")
+					return
+				}
+			case "Balance":
+				bts, err = (*z)[zb0001].Balance.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "Balance")
+					return
+				}
+			default:
+				bts, err = msgp.Skip(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001)
+					return
+				}
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z CurrencySeatsResponse) Msgsize() (s int) {
+	s = msgp.ArrayHeaderSize
+	for zb0004 := range z {
+		s += 1 + 8 + z[zb0004].Address.Msgsize() + 9 + z[zb0004].SeatDate.Msgsize() + 8 + z[zb0004].Balance.Msgsize()
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *DelegateList) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Node"
+	o = append(o, 0x82, 0xa4, 0x4e, 0x6f, 0x64, 0x65)
+	o, err = z.Node.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Node")
+		return
+	}
+	// string "Delegated"
+	o = append(o, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Delegated)))
+	for za0001 := range z.Delegated {
+		o, err = z.Delegated[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Delegated", za0001)
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *DelegateList) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Node":
+			bts, err = z.Node.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Node")
+				return
+			}
+		case "Delegated":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Delegated")
+				return
+			}
+			if cap(z.Delegated) >= int(zb0002) {
+				z.Delegated = (z.Delegated)[:zb0002]
+			} else {
+				z.Delegated = make([]address.Address, zb0002)
+			}
+			for za0001 := range z.Delegated {
+				bts, err = z.Delegated[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Delegated", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *DelegateList) Msgsize() (s int) {
+	s = 1 + 5 + z.Node.Msgsize() + 10 + msgp.ArrayHeaderSize
+	for za0001 := range z.Delegated {
+		s += z.Delegated[za0001].Msgsize()
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z DelegatesResponse) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendArrayHeader(o, uint32(len(z)))
+	for za0001 := range z {
+		// map header, size 2
+		// string "Node"
+		o = append(o, 0x82, 0xa4, 0x4e, 0x6f, 0x64, 0x65)
+		o, err = z[za0001].Node.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, za0001, "Node")
+			return
+		}
+		// string "Delegated"
+		o = append(o, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64)
+		o = msgp.AppendArrayHeader(o, uint32(len(z[za0001].Delegated)))
+		for za0002 := range z[za0001].Delegated {
+			o, err = z[za0001].Delegated[za0002].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, za0001, "Delegated", za0002)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *DelegatesResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0003 uint32
+	zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if cap((*z)) >= int(zb0003) {
+		(*z) = (*z)[:zb0003]
+	} else {
+		(*z) = make(DelegatesResponse, zb0003)
+	}
+	for zb0001 := range *z {
+		var field []byte
+		_ = field
+		var zb0004 uint32
+		zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err, zb0001)
+			return
+		}
+		for zb0004 > 0 {
+			zb0004--
+			field, bts, err = msgp.ReadMapKeyZC(bts)
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			switch msgp.UnsafeString(field) {
+			case "Node":
+				bts, err = (*z)[zb0001].Node.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "Node")
+					return
+				}
+			case "Delegated":
+				var zb0005 uint32
+				zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "Delegated")
+					return
+				}
+				if cap((*z)[zb0001].Delegated) >= int(zb0005) {
+					(*z)[zb0001].Delegated = ((*z)[zb0001].Delegated)[:zb0005]
+				} else {
+					(*z)[zb0001].Delegated = make([]address.Address, zb0005)
+				}
+				for zb0002 := range (*z)[zb0001].Delegated {
+					bts, err = (*z)[zb0001].Delegated[zb0002].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "Delegated", zb0002)
+						return
+					}
+				}
+			default:
+				bts, err = msgp.Skip(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001)
+					return
+				}
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z DelegatesResponse) Msgsize() (s int) {
+	s = msgp.ArrayHeaderSize
+	for zb0006 := range z {
+		s += 1 + 5 + z[zb0006].Node.Msgsize() + 10 + msgp.ArrayHeaderSize
+		for zb0007 := range z[zb0006].Delegated {
+			s += z[zb0006].Delegated[zb0007].Msgsize()
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *NodeExtra) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Node"
+	o = append(o, 0x82, 0xa4, 0x4e, 0x6f, 0x64, 0x65)
+	o, err = z.Node.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Node")
+		return
+	}
+	// string "Registration"
+	o = append(o, 0xac, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	o, err = z.Registration.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Registration")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *NodeExtra) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Node":
+			bts, err = z.Node.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Node")
+				return
+			}
+		case "Registration":
+			bts, err = z.Registration.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Registration")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *NodeExtra) Msgsize() (s int) {
+	s = 1 + 5 + z.Node.Msgsize() + 13 + z.Registration.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z NodesResponse) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendMapHeader(o, uint32(len(z)))
+	for za0001, za0002 := range z {
+		o = msgp.AppendString(o, za0001)
+		// map header, size 2
+		// string "Node"
+		o = append(o, 0x82, 0xa4, 0x4e, 0x6f, 0x64, 0x65)
+		o, err = za0002.Node.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, za0001, "Node")
+			return
+		}
+		// string "Registration"
+		o = append(o, 0xac, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+		o, err = za0002.Registration.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, za0001, "Registration")
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *NodesResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0003 uint32
+	zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if (*z) == nil {
+		(*z) = make(NodesResponse, zb0003)
+	} else if len((*z)) > 0 {
+		for key := range *z {
+			delete((*z), key)
+		}
+	}
+	for zb0003 > 0 {
+		var zb0001 string
+		var zb0002 NodeExtra
+		zb0003--
+		zb0001, bts, err = msgp.ReadStringBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		var field []byte
+		_ = field
+		var zb0004 uint32
+		zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err, zb0001)
+			return
+		}
+		for zb0004 > 0 {
+			zb0004--
+			field, bts, err = msgp.ReadMapKeyZC(bts)
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			switch msgp.UnsafeString(field) {
+			case "Node":
+				bts, err = zb0002.Node.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "Node")
+					return
+				}
+			case "Registration":
+				bts, err = zb0002.Registration.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "Registration")
+					return
+				}
+			default:
+				bts, err = msgp.Skip(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001)
+					return
+				}
+			}
+		}
+		(*z)[zb0001] = zb0002
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z NodesResponse) Msgsize() (s int) {
+	s = msgp.MapHeaderSize
+	if z != nil {
+		for zb0005, zb0006 := range z {
+			_ = zb0006
+			s += msgp.StringPrefixSize + len(zb0005) + 1 + 5 + zb0006.Node.Msgsize() + 13 + zb0006.Registration.Msgsize()
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SIBResponse) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "SIB"
+	o = append(o, 0x84, 0xa3, 0x53, 0x49, 0x42)
+	o, err = z.SIB.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "SIB")
+		return
+	}
+	// string "TargetPrice"
+	o = append(o, 0xab, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65)
+	o, err = z.TargetPrice.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TargetPrice")
+		return
+	}
+	// string "MarketPrice"
+	o = append(o, 0xab, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65)
+	o, err = z.MarketPrice.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MarketPrice")
+		return
+	}
+	// string "FloorPrice"
+	o = append(o, 0xaa, 0x46, 0x6c, 0x6f, 0x6f, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65)
+	o, err = z.FloorPrice.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "FloorPrice")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SIBResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "SIB":
+			bts, err = z.SIB.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SIB")
+				return
+			}
+		case "TargetPrice":
+			bts, err = z.TargetPrice.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TargetPrice")
+				return
+			}
+		case "MarketPrice":
+			bts, err = z.MarketPrice.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MarketPrice")
+				return
+			}
+		case "FloorPrice":
+			bts, err = z.FloorPrice.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FloorPrice")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SIBResponse) Msgsize() (s int) {
+	s = 1 + 4 + z.SIB.Msgsize() + 12 + z.TargetPrice.Msgsize() + 12 + z.MarketPrice.Msgsize() + 11 + z.FloorPrice.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SidechainTxExistsQuery) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "SidechainID"
+	o = append(o, 0x83, 0xab, 0x53, 0x69, 0x64, 0x65, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44)
+	o = msgp.AppendByte(o, z.SidechainID)
+	// string "Source"
 	o = append(o, 0xa6, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65)
 	o, err = z.Source.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "Source")
 		return
 	}
-	// string "TxHash"
-	o = append(o, 0xa6, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68)
-	o = msgp.AppendString(o, z.TxHash)
+	// string "TxHash"
+	o = append(o, 0xa6, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68)
+	o = msgp.AppendString(o, z.TxHash)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SidechainTxExistsQuery) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "SidechainID":
+			z.SidechainID, bts, err = msgp.ReadByteBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SidechainID")
+				return
+			}
+		case "Source":
+			bts, err = z.Source.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Source")
+				return
+			}
+		case "TxHash":
+			z.TxHash, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TxHash")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SidechainTxExistsQuery) Msgsize() (s int) {
+	s = 1 + 12 + msgp.ByteSize + 7 + z.Source.Msgsize() + 7 + msgp.StringPrefixSize + len(z.TxHash)
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Summary) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 7
+	// string "BlockHeight"
+	o = append(o, 0x87, 0xab, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.BlockHeight)
+	// string "TotalNdau"
+	o = append(o, 0xa9, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x64, 0x61, 0x75)
+	o, err = z.TotalNdau.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalNdau")
+		return
+	}
+	// string "NumAccounts"
+	o = append(o, 0xab, 0x4e, 0x75, 0x6d, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73)
+	o = msgp.AppendInt(o, z.NumAccounts)
+	// string "TotalRFE"
+	o = append(o, 0xa8, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x46, 0x45)
+	o, err = z.TotalRFE.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalRFE")
+		return
+	}
+	// string "TotalIssue"
+	o = append(o, 0xaa, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x73, 0x73, 0x75, 0x65)
+	o, err = z.TotalIssue.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalIssue")
+		return
+	}
+	// string "TotalBurned"
+	o = append(o, 0xab, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x75, 0x72, 0x6e, 0x65, 0x64)
+	o, err = z.TotalBurned.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalBurned")
+		return
+	}
+	// string "TotalCirculation"
+	o = append(o, 0xb0, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x69, 0x72, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	o, err = z.TotalCirculation.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalCirculation")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Summary) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "BlockHeight":
+			z.BlockHeight, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BlockHeight")
+				return
+			}
+		case "TotalNdau":
+			bts, err = z.TotalNdau.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalNdau")
+				return
+			}
+		case "NumAccounts":
+			z.NumAccounts, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NumAccounts")
+				return
+			}
+		case "TotalRFE":
+			bts, err = z.TotalRFE.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalRFE")
+				return
+			}
+		case "TotalIssue":
+			bts, err = z.TotalIssue.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalIssue")
+				return
+			}
+		case "TotalBurned":
+			bts, err = z.TotalBurned.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalBurned")
+				return
+			}
+		case "TotalCirculation":
+			bts, err = z.TotalCirculation.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalCirculation")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Summary) Msgsize() (s int) {
+	s = 1 + 12 + msgp.Uint64Size + 10 + z.TotalNdau.Msgsize() + 12 + msgp.IntSize + 9 + z.TotalRFE.Msgsize() + 11 + z.TotalIssue.Msgsize() + 12 + z.TotalBurned.Msgsize() + 17 + z.TotalCirculation.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SysvarHistoricalValue) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Height"
+	o = append(o, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendUint64(o, z.Height)
+	// string "Value"
+	o = append(o, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	o = msgp.AppendBytes(o, z.Value)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SysvarHistoricalValue) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Height":
+			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Value":
+			z.Value, bts, err = msgp.ReadBytesBytes(bts, z.Value)
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SysvarHistoricalValue) Msgsize() (s int) {
+	s = 1 + 7 + msgp.Uint64Size + 6 + msgp.BytesPrefixSize + len(z.Value)
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SysvarHistoryResponse) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "History"
+	o = append(o, 0x81, 0xa7, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.History)))
+	for za0001 := range z.History {
+		// map header, size 2
+		// string "Height"
+		o = append(o, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+		o = msgp.AppendUint64(o, z.History[za0001].Height)
+		// string "Value"
+		o = append(o, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+		o = msgp.AppendBytes(o, z.History[za0001].Value)
+	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *SidechainTxExistsQuery) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *SysvarHistoryResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -614,23 +1269,53 @@ func (z *SidechainTxExistsQuery) UnmarshalMsg(bts []byte) (o []byte, err error)
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "SidechainID":
-			z.SidechainID, bts, err = msgp.ReadByteBytes(bts)
+		case "History":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "SidechainID")
+				err = msgp.WrapError(err, "History")
 				return
 			}
-		case "Source":
-			bts, err = z.Source.UnmarshalMsg(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "Source")
-				return
+			if cap(z.History) >= int(zb0002) {
+				z.History = (z.History)[:zb0002]
+			} else {
+				z.History = make([]SysvarHistoricalValue, zb0002)
 			}
-		case "TxHash":
-			z.TxHash, bts, err = msgp.ReadStringBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "TxHash")
-				return
+			for za0001 := range z.History {
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "History", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "History", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Height":
+						z.History[za0001].Height, bts, err = msgp.ReadUint64Bytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "History", za0001, "Height")
+							return
+						}
+					case "Value":
+						z.History[za0001].Value, bts, err = msgp.ReadBytesBytes(bts, z.History[za0001].Value)
+						if err != nil {
+							err = msgp.WrapError(err, "History", za0001, "Value")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "History", za0001)
+							return
+						}
+					}
+				}
 			}
 		default:
 			bts, err = msgp.Skip(bts)
@@ -645,61 +1330,222 @@ func (z *SidechainTxExistsQuery) UnmarshalMsg(bts []byte) (o []byte, err error)
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *SidechainTxExistsQuery) Msgsize() (s int) {
-	s = 1 + 12 + msgp.ByteSize + 7 + z.Source.Msgsize() + 7 + msgp.StringPrefixSize + len(z.TxHash)
+func (z *SysvarHistoryResponse) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize
+	for za0001 := range z.History {
+		s += 1 + 7 + msgp.Uint64Size + 6 + msgp.BytesPrefixSize + len(z.History[za0001].Value)
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *Summary) MarshalMsg(b []byte) (o []byte, err error) {
+func (z SysvarsRequest) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 7
-	// string "BlockHeight"
-	o = append(o, 0x87, 0xab, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendUint64(o, z.BlockHeight)
-	// string "TotalNdau"
-	o = append(o, 0xa9, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x64, 0x61, 0x75)
-	o, err = z.TotalNdau.MarshalMsg(o)
-	if err != nil {
-		err = msgp.WrapError(err, "TotalNdau")
-		return
+	o = msgp.AppendArrayHeader(o, uint32(len(z)))
+	for za0001 := range z {
+		o = msgp.AppendString(o, z[za0001])
 	}
-	// string "NumAccounts"
-	o = append(o, 0xab, 0x4e, 0x75, 0x6d, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73)
-	o = msgp.AppendInt(o, z.NumAccounts)
-	// string "TotalRFE"
-	o = append(o, 0xa8, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x46, 0x45)
-	o, err = z.TotalRFE.MarshalMsg(o)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SysvarsRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0002 uint32
+	zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 	if err != nil {
-		err = msgp.WrapError(err, "TotalRFE")
+		err = msgp.WrapError(err)
 		return
 	}
-	// string "TotalIssue"
-	o = append(o, 0xaa, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x73, 0x73, 0x75, 0x65)
-	o, err = z.TotalIssue.MarshalMsg(o)
-	if err != nil {
-		err = msgp.WrapError(err, "TotalIssue")
-		return
+	if cap((*z)) >= int(zb0002) {
+		(*z) = (*z)[:zb0002]
+	} else {
+		(*z) = make(SysvarsRequest, zb0002)
 	}
-	// string "TotalBurned"
-	o = append(o, 0xab, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x75, 0x72, 0x6e, 0x65, 0x64)
-	o, err = z.TotalBurned.MarshalMsg(o)
+	for zb0001 := range *z {
+		(*z)[zb0001], bts, err = msgp.ReadStringBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err, zb0001)
+			return
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z SysvarsRequest) Msgsize() (s int) {
+	s = msgp.ArrayHeaderSize
+	for zb0003 := range z {
+		s += msgp.StringPrefixSize + len(z[zb0003])
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z SysvarsResponse) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendMapHeader(o, uint32(len(z)))
+	for za0001, za0002 := range z {
+		o = msgp.AppendString(o, za0001)
+		o = msgp.AppendBytes(o, za0002)
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SysvarsResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0003 uint32
+	zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
 	if err != nil {
-		err = msgp.WrapError(err, "TotalBurned")
+		err = msgp.WrapError(err)
 		return
 	}
-	// string "TotalCirculation"
-	o = append(o, 0xb0, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x69, 0x72, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e)
-	o, err = z.TotalCirculation.MarshalMsg(o)
+	if (*z) == nil {
+		(*z) = make(SysvarsResponse, zb0003)
+	} else if len((*z)) > 0 {
+		for key := range *z {
+			delete((*z), key)
+		}
+	}
+	for zb0003 > 0 {
+		var zb0001 string
+		var zb0002 []byte
+		zb0003--
+		zb0001, bts, err = msgp.ReadStringBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		zb0002, bts, err = msgp.ReadBytesBytes(bts, zb0002)
+		if err != nil {
+			err = msgp.WrapError(err, zb0001)
+			return
+		}
+		(*z)[zb0001] = zb0002
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z SysvarsResponse) Msgsize() (s int) {
+	s = msgp.MapHeaderSize
+	if z != nil {
+		for zb0004, zb0005 := range z {
+			_ = zb0005
+			s += msgp.StringPrefixSize + len(zb0004) + msgp.BytesPrefixSize + len(zb0005)
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *VMDebugRequest) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "VM"
+	o = append(o, 0x83, 0xa2, 0x56, 0x4d)
+	o = msgp.AppendString(o, z.VM)
+	// string "Tx"
+	o = append(o, 0xa2, 0x54, 0x78)
+	o = msgp.AppendBytes(o, z.Tx)
+	// string "Node"
+	o = append(o, 0xa4, 0x4e, 0x6f, 0x64, 0x65)
+	o = msgp.AppendString(o, z.Node)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *VMDebugRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
 	if err != nil {
-		err = msgp.WrapError(err, "TotalCirculation")
+		err = msgp.WrapError(err)
 		return
 	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "VM":
+			z.VM, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "VM")
+				return
+			}
+		case "Tx":
+			z.Tx, bts, err = msgp.ReadBytesBytes(bts, z.Tx)
+			if err != nil {
+				err = msgp.WrapError(err, "Tx")
+				return
+			}
+		case "Node":
+			z.Node, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Node")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *VMDebugRequest) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.VM) + 3 + msgp.BytesPrefixSize + len(z.Tx) + 5 + msgp.StringPrefixSize + len(z.Node)
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *VMDebugResponse) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "VM"
+	o = append(o, 0x85, 0xa2, 0x56, 0x4d)
+	o = msgp.AppendString(o, z.VM)
+	// string "Disassembly"
+	o = append(o, 0xab, 0x44, 0x69, 0x73, 0x61, 0x73, 0x73, 0x65, 0x6d, 0x62, 0x6c, 0x79)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Disassembly)))
+	for za0001 := range z.Disassembly {
+		o = msgp.AppendString(o, z.Disassembly[za0001])
+	}
+	// string "Trace"
+	o = append(o, 0xa5, 0x54, 0x72, 0x61, 0x63, 0x65)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Trace)))
+	for za0002 := range z.Trace {
+		o, err = z.Trace[za0002].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Trace", za0002)
+			return
+		}
+	}
+	// string "Stack"
+	o = append(o, 0xa5, 0x53, 0x74, 0x61, 0x63, 0x6b)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Stack)))
+	for za0003 := range z.Stack {
+		o = msgp.AppendString(o, z.Stack[za0003])
+	}
+	// string "Error"
+	o = append(o, 0xa5, 0x45, 0x72, 0x72, 0x6f, 0x72)
+	o = msgp.AppendString(o, z.Error)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *Summary) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *VMDebugResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -716,46 +1562,73 @@ func (z *Summary) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "BlockHeight":
-			z.BlockHeight, bts, err = msgp.ReadUint64Bytes(bts)
+		case "VM":
+			z.VM, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "BlockHeight")
+				err = msgp.WrapError(err, "VM")
 				return
 			}
-		case "TotalNdau":
-			bts, err = z.TotalNdau.UnmarshalMsg(bts)
+		case "Disassembly":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "TotalNdau")
+				err = msgp.WrapError(err, "Disassembly")
 				return
 			}
-		case "NumAccounts":
-			z.NumAccounts, bts, err = msgp.ReadIntBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "NumAccounts")
-				return
+			if cap(z.Disassembly) >= int(zb0002) {
+				z.Disassembly = (z.Disassembly)[:zb0002]
+			} else {
+				z.Disassembly = make([]string, zb0002)
 			}
-		case "TotalRFE":
-			bts, err = z.TotalRFE.UnmarshalMsg(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "TotalRFE")
-				return
+			for za0001 := range z.Disassembly {
+				z.Disassembly[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Disassembly", za0001)
+					return
+				}
 			}
-		case "TotalIssue":
-			bts, err = z.TotalIssue.UnmarshalMsg(bts)
+		case "Trace":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "TotalIssue")
+				err = msgp.WrapError(err, "Trace")
 				return
 			}
-		case "TotalBurned":
-			bts, err = z.TotalBurned.UnmarshalMsg(bts)
+			if cap(z.Trace) >= int(zb0003) {
+				z.Trace = (z.Trace)[:zb0003]
+			} else {
+				z.Trace = make([]VMStep, zb0003)
+			}
+			for za0002 := range z.Trace {
+				bts, err = z.Trace[za0002].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Trace", za0002)
+					return
+				}
+			}
+		case "Stack":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "TotalBurned")
+				err = msgp.WrapError(err, "Stack")
 				return
 			}
-		case "TotalCirculation":
-			bts, err = z.TotalCirculation.UnmarshalMsg(bts)
+			if cap(z.Stack) >= int(zb0004) {
+				z.Stack = (z.Stack)[:zb0004]
+			} else {
+				z.Stack = make([]string, zb0004)
+			}
+			for za0003 := range z.Stack {
+				z.Stack[za0003], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Stack", za0003)
+					return
+				}
+			}
+		case "Error":
+			z.Error, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "TotalCirculation")
+				err = msgp.WrapError(err, "Error")
 				return
 			}
 		default:
@@ -771,26 +1644,44 @@ func (z *Summary) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *Summary) Msgsize() (s int) {
-	s = 1 + 12 + msgp.Uint64Size + 10 + z.TotalNdau.Msgsize() + 12 + msgp.IntSize + 9 + z.TotalRFE.Msgsize() + 11 + z.TotalIssue.Msgsize() + 12 + z.TotalBurned.Msgsize() + 17 + z.TotalCirculation.Msgsize()
+func (z *VMDebugResponse) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.VM) + 12 + msgp.ArrayHeaderSize
+	for za0001 := range z.Disassembly {
+		s += msgp.StringPrefixSize + len(z.Disassembly[za0001])
+	}
+	s += 6 + msgp.ArrayHeaderSize
+	for za0002 := range z.Trace {
+		s += z.Trace[za0002].Msgsize()
+	}
+	s += 6 + msgp.ArrayHeaderSize
+	for za0003 := range z.Stack {
+		s += msgp.StringPrefixSize + len(z.Stack[za0003])
+	}
+	s += 6 + msgp.StringPrefixSize + len(z.Error)
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *SysvarHistoricalValue) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *VMStep) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
-	// string "Height"
-	o = append(o, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendUint64(o, z.Height)
-	// string "Value"
-	o = append(o, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
-	o = msgp.AppendBytes(o, z.Value)
+	// map header, size 3
+	// string "Offset"
+	o = append(o, 0x83, 0xa6, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74)
+	o = msgp.AppendInt(o, z.Offset)
+	// string "Instruction"
+	o = append(o, 0xab, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Instruction)
+	// string "Stack"
+	o = append(o, 0xa5, 0x53, 0x74, 0x61, 0x63, 0x6b)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Stack)))
+	for za0001 := range z.Stack {
+		o = msgp.AppendString(o, z.Stack[za0001])
+	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *SysvarHistoricalValue) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *VMStep) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -807,18 +1698,37 @@ func (z *SysvarHistoricalValue) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Height":
-			z.Height, bts, err = msgp.ReadUint64Bytes(bts)
+		case "Offset":
+			z.Offset, bts, err = msgp.ReadIntBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Height")
+				err = msgp.WrapError(err, "Offset")
 				return
 			}
-		case "Value":
-			z.Value, bts, err = msgp.ReadBytesBytes(bts, z.Value)
+		case "Instruction":
+			z.Instruction, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Value")
+				err = msgp.WrapError(err, "Instruction")
+				return
+			}
+		case "Stack":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Stack")
 				return
 			}
+			if cap(z.Stack) >= int(zb0002) {
+				z.Stack = (z.Stack)[:zb0002]
+			} else {
+				z.Stack = make([]string, zb0002)
+			}
+			for za0001 := range z.Stack {
+				z.Stack[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Stack", za0001)
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -832,32 +1742,42 @@ func (z *SysvarHistoricalValue) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *SysvarHistoricalValue) Msgsize() (s int) {
-	s = 1 + 7 + msgp.Uint64Size + 6 + msgp.BytesPrefixSize + len(z.Value)
+func (z *VMStep) Msgsize() (s int) {
+	s = 1 + 7 + msgp.IntSize + 12 + msgp.StringPrefixSize + len(z.Instruction) + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Stack {
+		s += msgp.StringPrefixSize + len(z.Stack[za0001])
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *SysvarHistoryResponse) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *ValidationScriptRequest) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
-	// string "History"
-	o = append(o, 0x81, 0xa7, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.History)))
-	for za0001 := range z.History {
-		// map header, size 2
-		// string "Height"
-		o = append(o, 0x82, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-		o = msgp.AppendUint64(o, z.History[za0001].Height)
-		// string "Value"
-		o = append(o, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
-		o = msgp.AppendBytes(o, z.History[za0001].Value)
+	// map header, size 4
+	// string "Script"
+	o = append(o, 0x84, 0xa6, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74)
+	o = msgp.AppendBytes(o, z.Script)
+	// string "Tx"
+	o = append(o, 0xa2, 0x54, 0x78)
+	o = msgp.AppendBytes(o, z.Tx)
+	// string "Signatures"
+	o = append(o, 0xaa, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73)
+	o = msgp.AppendUint64(o, z.Signatures)
+	// string "ValidationKeys"
+	o = append(o, 0xae, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.ValidationKeys)))
+	for za0001 := range z.ValidationKeys {
+		o, err = z.ValidationKeys[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "ValidationKeys", za0001)
+			return
+		}
 	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *SysvarHistoryResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *ValidationScriptRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -874,53 +1794,42 @@ func (z *SysvarHistoryResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "History":
+		case "Script":
+			z.Script, bts, err = msgp.ReadBytesBytes(bts, z.Script)
+			if err != nil {
+				err = msgp.WrapError(err, "Script")
+				return
+			}
+		case "Tx":
+			z.Tx, bts, err = msgp.ReadBytesBytes(bts, z.Tx)
+			if err != nil {
+				err = msgp.WrapError(err, "Tx")
+				return
+			}
+		case "Signatures":
+			z.Signatures, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Signatures")
+				return
+			}
+		case "ValidationKeys":
 			var zb0002 uint32
 			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "History")
+				err = msgp.WrapError(err, "ValidationKeys")
 				return
 			}
-			if cap(z.History) >= int(zb0002) {
-				z.History = (z.History)[:zb0002]
+			if cap(z.ValidationKeys) >= int(zb0002) {
+				z.ValidationKeys = (z.ValidationKeys)[:zb0002]
 			} else {
-				z.History = make([]SysvarHistoricalValue, zb0002)
+				z.ValidationKeys = make([]signature.PublicKey, zb0002)
 			}
-			for za0001 := range z.History {
-				var zb0003 uint32
-				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			for za0001 := range z.ValidationKeys {
+				bts, err = z.ValidationKeys[za0001].UnmarshalMsg(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "History", za0001)
+					err = msgp.WrapError(err, "ValidationKeys", za0001)
 					return
 				}
-				for zb0003 > 0 {
-					zb0003--
-					field, bts, err = msgp.ReadMapKeyZC(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "History", za0001)
-						return
-					}
-					switch msgp.UnsafeString(field) {
-					case "Height":
-						z.History[za0001].Height, bts, err = msgp.ReadUint64Bytes(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "History", za0001, "Height")
-							return
-						}
-					case "Value":
-						z.History[za0001].Value, bts, err = msgp.ReadBytesBytes(bts, z.History[za0001].Value)
-						if err != nil {
-							err = msgp.WrapError(err, "History", za0001, "Value")
-							return
-						}
-					default:
-						bts, err = msgp.Skip(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "History", za0001)
-							return
-						}
-					}
-				}
 			}
 		default:
 			bts, err = msgp.Skip(bts)
@@ -935,111 +1844,103 @@ func (z *SysvarHistoryResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *SysvarHistoryResponse) Msgsize() (s int) {
-	s = 1 + 8 + msgp.ArrayHeaderSize
-	for za0001 := range z.History {
-		s += 1 + 7 + msgp.Uint64Size + 6 + msgp.BytesPrefixSize + len(z.History[za0001].Value)
+func (z *ValidationScriptRequest) Msgsize() (s int) {
+	s = 1 + 7 + msgp.BytesPrefixSize + len(z.Script) + 3 + msgp.BytesPrefixSize + len(z.Tx) + 11 + msgp.Uint64Size + 15 + msgp.ArrayHeaderSize
+	for za0001 := range z.ValidationKeys {
+		s += z.ValidationKeys[za0001].Msgsize()
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z SysvarsRequest) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *ValidationScriptResponse) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	o = msgp.AppendArrayHeader(o, uint32(len(z)))
-	for za0001 := range z {
-		o = msgp.AppendString(o, z[za0001])
-	}
-	return
-}
-
-// UnmarshalMsg implements msgp.Unmarshaler
-func (z *SysvarsRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
-	var zb0002 uint32
-	zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
-	if err != nil {
-		err = msgp.WrapError(err)
-		return
-	}
-	if cap((*z)) >= int(zb0002) {
-		(*z) = (*z)[:zb0002]
-	} else {
-		(*z) = make(SysvarsRequest, zb0002)
-	}
-	for zb0001 := range *z {
-		(*z)[zb0001], bts, err = msgp.ReadStringBytes(bts)
+	// map header, size 3
+	// string "ExitCode"
+	o = append(o, 0x83, 0xa8, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65)
+	o = msgp.AppendInt64(o, z.ExitCode)
+	// string "Error"
+	o = append(o, 0xa5, 0x45, 0x72, 0x72, 0x6f, 0x72)
+	o = msgp.AppendString(o, z.Error)
+	// string "Trace"
+	o = append(o, 0xa5, 0x54, 0x72, 0x61, 0x63, 0x65)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Trace)))
+	for za0001 := range z.Trace {
+		o, err = z.Trace[za0001].MarshalMsg(o)
 		if err != nil {
-			err = msgp.WrapError(err, zb0001)
+			err = msgp.WrapError(err, "Trace", za0001)
 			return
 		}
 	}
-	o = bts
-	return
-}
-
-// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z SysvarsRequest) Msgsize() (s int) {
-	s = msgp.ArrayHeaderSize
-	for zb0003 := range z {
-		s += msgp.StringPrefixSize + len(z[zb0003])
-	}
-	return
-}
-
-// MarshalMsg implements msgp.Marshaler
-func (z SysvarsResponse) MarshalMsg(b []byte) (o []byte, err error) {
-	o = msgp.Require(b, z.Msgsize())
-	o = msgp.AppendMapHeader(o, uint32(len(z)))
-	for za0001, za0002 := range z {
-		o = msgp.AppendString(o, za0001)
-		o = msgp.AppendBytes(o, za0002)
-	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *SysvarsResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
-	var zb0003 uint32
-	zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+func (z *ValidationScriptResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
 	if err != nil {
 		err = msgp.WrapError(err)
 		return
 	}
-	if (*z) == nil {
-		(*z) = make(SysvarsResponse, zb0003)
-	} else if len((*z)) > 0 {
-		for key := range *z {
-			delete((*z), key)
-		}
-	}
-	for zb0003 > 0 {
-		var zb0001 string
-		var zb0002 []byte
-		zb0003--
-		zb0001, bts, err = msgp.ReadStringBytes(bts)
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
 		if err != nil {
 			err = msgp.WrapError(err)
 			return
 		}
-		zb0002, bts, err = msgp.ReadBytesBytes(bts, zb0002)
-		if err != nil {
-			err = msgp.WrapError(err, zb0001)
-			return
+		switch msgp.UnsafeString(field) {
+		case "ExitCode":
+			z.ExitCode, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ExitCode")
+				return
+			}
+		case "Error":
+			z.Error, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Error")
+				return
+			}
+		case "Trace":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Trace")
+				return
+			}
+			if cap(z.Trace) >= int(zb0002) {
+				z.Trace = (z.Trace)[:zb0002]
+			} else {
+				z.Trace = make([]VMStep, zb0002)
+			}
+			for za0001 := range z.Trace {
+				bts, err = z.Trace[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Trace", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
 		}
-		(*z)[zb0001] = zb0002
 	}
 	o = bts
 	return
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z SysvarsResponse) Msgsize() (s int) {
-	s = msgp.MapHeaderSize
-	if z != nil {
-		for zb0004, zb0005 := range z {
-			_ = zb0005
-			s += msgp.StringPrefixSize + len(zb0004) + msgp.BytesPrefixSize + len(zb0005)
-		}
+func (z *ValidationScriptResponse) Msgsize() (s int) {
+	s = 1 + 9 + msgp.Int64Size + 6 + msgp.StringPrefixSize + len(z.Error) + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Trace {
+		s += z.Trace[za0001].Msgsize()
 	}
 	return
 }
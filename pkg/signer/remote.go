@@ -0,0 +1,152 @@
+package signer
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/pkg/errors"
+)
+
+// These are the paths of the remote signer protocol.
+//
+// GET KeysPath returns a KeysResponse listing the keys the signer holds.
+// POST SignPath with a SignRequest returns a SignResponse, or an ErrorResponse
+// with a non-200 status.
+const (
+	KeysPath = "/keys"
+	SignPath = "/sign"
+)
+
+// unixScheme prefixes the addresses of signers listening on Unix sockets.
+const unixScheme = "unix://"
+
+// KeysResponse lists the public keys a remote signer can sign with.
+type KeysResponse struct {
+	Keys []signature.PublicKey `json:"keys"`
+}
+
+// SignRequest asks a remote signer to sign Data with each of Keys.
+type SignRequest struct {
+	Keys []signature.PublicKey `json:"keys"`
+	Data []byte                `json:"data"`
+}
+
+// SignResponse contains one signature per requested key, in request order.
+type SignResponse struct {
+	Signatures []signature.Signature `json:"signatures"`
+}
+
+// ErrorResponse is returned by a remote signer which refuses a request.
+type ErrorResponse struct {
+	Msg string `json:"msg"`
+}
+
+// Remote is a Signer which asks a signing service to sign with keys it never exports.
+type Remote struct {
+	Keys []signature.PublicKey
+
+	url  string
+	http *http.Client
+}
+
+// NewRemote connects to the signing service at addr, which signs with the given keys.
+//
+// addr is either an HTTP URL, or unix:// followed by the path of a Unix socket.
+func NewRemote(addr string, keys ...signature.PublicKey) *Remote {
+	r := &Remote{
+		Keys: keys,
+		url:  strings.TrimRight(addr, "/"),
+		http: &http.Client{Timeout: 30 * time.Second},
+	}
+	if strings.HasPrefix(addr, unixScheme) {
+		socket := strings.TrimPrefix(addr, unixScheme)
+		r.url = "http://signer"
+		r.http.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}
+	}
+	return r
+}
+
+func (r *Remote) do(req *http.Request, resp interface{}) error {
+	response, err := r.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "contacting remote signer")
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return errors.Wrap(err, "reading remote signer response")
+	}
+	if response.StatusCode != http.StatusOK {
+		var er ErrorResponse
+		if json.Unmarshal(body, &er) == nil && er.Msg != "" {
+			return fmt.Errorf("remote signer: %s", er.Msg)
+		}
+		return fmt.Errorf("remote signer: %s", response.Status)
+	}
+	return errors.Wrap(json.Unmarshal(body, resp), "decoding remote signer response")
+}
+
+// Available lists the keys the signing service holds.
+func (r *Remote) Available() ([]signature.PublicKey, error) {
+	req, err := http.NewRequest(http.MethodGet, r.url+KeysPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var kr KeysResponse
+	err = r.do(req, &kr)
+	return kr.Keys, err
+}
+
+// Sign implements Signer
+//
+// Each signature is checked before it is returned.
+func (r *Remote) Sign(data []byte) ([]signature.Signature, error) {
+	body, err := json.Marshal(SignRequest{Keys: r.Keys, Data: data})
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding sign request")
+	}
+	req, err := http.NewRequest(http.MethodPost, r.url+SignPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var sr SignResponse
+	err = r.do(req, &sr)
+	if err != nil {
+		return nil, err
+	}
+	if len(sr.Signatures) != len(r.Keys) {
+		return nil, fmt.Errorf("remote signer returned %d signatures for %d keys", len(sr.Signatures), len(r.Keys))
+	}
+	for i, sig := range sr.Signatures {
+		if !r.Keys[i].Verify(data, sig) {
+			return nil, fmt.Errorf("remote signer returned a bad signature for key %d", i)
+		}
+	}
+	return sr.Signatures, nil
+}
+
+var _ Signer = (*Remote)(nil)
@@ -0,0 +1,198 @@
+package signer
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/pkg/errors"
+)
+
+// maxSignRequest limits the size of sign requests; signable bytes are small.
+const maxSignRequest = 1 << 20
+
+// A Keyring holds private keys by their public keys.
+type Keyring struct {
+	public  []signature.PublicKey
+	private map[string]signature.PrivateKey
+}
+
+// NewKeyring creates an empty keyring.
+func NewKeyring() *Keyring {
+	return &Keyring{
+		private: make(map[string]signature.PrivateKey),
+	}
+}
+
+// Add puts a keypair in the keyring.
+func (k *Keyring) Add(public signature.PublicKey, private signature.PrivateKey) error {
+	text, err := public.MarshalText()
+	if err != nil {
+		return errors.Wrap(err, "encoding public key")
+	}
+	if _, ok := k.private[string(text)]; !ok {
+		k.public = append(k.public, public)
+	}
+	k.private[string(text)] = private
+	return nil
+}
+
+// Len is the number of keys in the keyring.
+func (k *Keyring) Len() int {
+	return len(k.public)
+}
+
+// Signer returns a Signer for the given keys, which must all be in the keyring.
+func (k *Keyring) Signer(keys ...signature.PublicKey) (Local, error) {
+	out := make(Local, 0, len(keys))
+	for _, public := range keys {
+		text, err := public.MarshalText()
+		if err != nil {
+			return nil, errors.Wrap(err, "encoding public key")
+		}
+		private, ok := k.private[string(text)]
+		if !ok {
+			return nil, fmt.Errorf("no such key: %s", text)
+		}
+		out = append(out, private)
+	}
+	return out, nil
+}
+
+// Server is the reference implementation of the remote signer protocol.
+type Server struct {
+	keyring *Keyring
+	mux     *http.ServeMux
+}
+
+// NewServer creates a Server which signs with the keys in the keyring.
+func NewServer(keyring *Keyring) *Server {
+	s := &Server{
+		keyring: keyring,
+		mux:     http.NewServeMux(),
+	}
+	s.mux.HandleFunc(KeysPath, s.handleKeys)
+	s.mux.HandleFunc(SignPath, s.handleSign)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func respond(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (s *Server) handleKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respond(w, http.StatusMethodNotAllowed, ErrorResponse{Msg: "GET required"})
+		return
+	}
+	keys := append([]signature.PublicKey{}, s.keyring.public...)
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	respond(w, http.StatusOK, KeysResponse{Keys: keys})
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respond(w, http.StatusMethodNotAllowed, ErrorResponse{Msg: "POST required"})
+		return
+	}
+	var req SignRequest
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSignRequest)).Decode(&req)
+	if err != nil {
+		respond(w, http.StatusBadRequest, ErrorResponse{Msg: "could not decode request: " + err.Error()})
+		return
+	}
+	if len(req.Keys) == 0 {
+		respond(w, http.StatusBadRequest, ErrorResponse{Msg: "at least one key is required"})
+		return
+	}
+	signer, err := s.keyring.Signer(req.Keys...)
+	if err != nil {
+		respond(w, http.StatusNotFound, ErrorResponse{Msg: err.Error()})
+		return
+	}
+	sigs, err := signer.Sign(req.Data)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, ErrorResponse{Msg: err.Error()})
+		return
+	}
+	respond(w, http.StatusOK, SignResponse{Signatures: sigs})
+}
+
+// Listen listens at addr, in the same form as NewRemote accepts: an HTTP URL or
+// host:port, or unix:// followed by the path of a Unix socket.
+//
+// The protocol has no authentication: anything which can connect can sign. A Unix
+// socket is created accessible only to its owner, and TCP addresses are refused
+// unless they are on the loopback interface.
+func Listen(addr string) (net.Listener, error) {
+	if strings.HasPrefix(addr, unixScheme) {
+		socket := strings.TrimPrefix(addr, unixScheme)
+		// clean up after a previous run which didn't exit cleanly
+		if _, err := os.Stat(socket); err == nil {
+			os.Remove(socket)
+		}
+		l, err := net.Listen("unix", socket)
+		if err != nil {
+			return nil, err
+		}
+		err = os.Chmod(socket, 0600)
+		if err != nil {
+			l.Close()
+			return nil, errors.Wrap(err, "restricting socket permissions")
+		}
+		return l, nil
+	}
+	if u, err := url.Parse(addr); err == nil && u.Host != "" {
+		addr = u.Host
+	}
+	err := checkLoopback(addr)
+	if err != nil {
+		return nil, err
+	}
+	return net.Listen("tcp", addr)
+}
+
+// checkLoopback returns an error unless every address host resolves to is a loopback address.
+//
+// An empty host listens on every interface, so it is refused too.
+func checkLoopback(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return errors.Wrap(err, "parsing listen address")
+	}
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil && host != "" {
+		ips, err = net.LookupIP(host)
+		if err != nil {
+			return errors.Wrap(err, "resolving listen address")
+		}
+	}
+	for _, ip := range ips {
+		if ip == nil || !ip.IsLoopback() {
+			return fmt.Errorf("refusing to listen on %s: anything which can connect can sign, so TCP listeners must be on the loopback interface", addr)
+		}
+	}
+	return nil
+}
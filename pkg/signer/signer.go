@@ -0,0 +1,74 @@
+package signer
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"errors"
+	"fmt"
+
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndaumath/pkg/signature"
+)
+
+// A Signer signs data with one or more keys, without necessarily holding them.
+//
+// Sign returns one signature per key, in the signer's key order.
+type Signer interface {
+	Sign(data []byte) ([]signature.Signature, error)
+}
+
+// Local is a Signer which holds its private keys in memory.
+type Local []signature.PrivateKey
+
+// NewLocal creates a Signer from private keys.
+func NewLocal(keys ...signature.PrivateKey) Local {
+	return Local(keys)
+}
+
+// Sign implements Signer
+func (l Local) Sign(data []byte) ([]signature.Signature, error) {
+	sigs := make([]signature.Signature, 0, len(l))
+	for _, key := range l {
+		if len(key.KeyBytes()) == 0 {
+			return nil, errors.New("private key unavailable")
+		}
+		sigs = append(sigs, key.Sign(data))
+	}
+	return sigs, nil
+}
+
+var _ Signer = (Local)(nil)
+
+// SignTx signs a transaction, adding the signatures to it.
+func SignTx(tx metatx.Transactable, s Signer) error {
+	stx, ok := tx.(ndau.Signable)
+	if !ok {
+		return fmt.Errorf("%s txs cannot be signed", metatx.NameOf(tx))
+	}
+	sigs, err := s.Sign(tx.SignableBytes())
+	if err != nil {
+		return err
+	}
+	stx.ExtendSignatures(sigs)
+	return nil
+}
+
+// SignOne returns a single signature of data, for txs which are signed by exactly one key.
+func SignOne(data []byte, s Signer) (signature.Signature, error) {
+	sigs, err := s.Sign(data)
+	if err != nil {
+		return signature.Signature{}, err
+	}
+	if len(sigs) != 1 {
+		return signature.Signature{}, fmt.Errorf("expected 1 signature; got %d", len(sigs))
+	}
+	return sigs[0], nil
+}
@@ -0,0 +1,94 @@
+package signer
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func keypairs(t *testing.T, n int) ([]signature.PublicKey, []signature.PrivateKey) {
+	publics := make([]signature.PublicKey, 0, n)
+	privates := make([]signature.PrivateKey, 0, n)
+	for i := 0; i < n; i++ {
+		public, private, err := signature.Generate(signature.Ed25519, nil)
+		require.NoError(t, err)
+		publics = append(publics, public)
+		privates = append(privates, private)
+	}
+	return publics, privates
+}
+
+func TestLocalSigns(t *testing.T) {
+	publics, privates := keypairs(t, 2)
+	data := []byte("signable bytes")
+
+	sigs, err := NewLocal(privates...).Sign(data)
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+	for i := range sigs {
+		require.True(t, publics[i].Verify(data, sigs[i]))
+	}
+}
+
+func TestRemoteRoundTrip(t *testing.T) {
+	publics, privates := keypairs(t, 3)
+	keyring := NewKeyring()
+	for i := range publics {
+		require.NoError(t, keyring.Add(publics[i], privates[i]))
+	}
+	server := httptest.NewServer(NewServer(keyring))
+	defer server.Close()
+
+	available, err := NewRemote(server.URL).Available()
+	require.NoError(t, err)
+	require.Len(t, available, 3)
+
+	// request a subset, out of keyring order
+	remote := NewRemote(server.URL, publics[2], publics[0])
+	data := []byte("signable bytes")
+	sigs, err := remote.Sign(data)
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+	require.True(t, publics[2].Verify(data, sigs[0]))
+	require.True(t, publics[0].Verify(data, sigs[1]))
+}
+
+func TestRemoteUnknownKey(t *testing.T) {
+	publics, privates := keypairs(t, 2)
+	keyring := NewKeyring()
+	require.NoError(t, keyring.Add(publics[0], privates[0]))
+	server := httptest.NewServer(NewServer(keyring))
+	defer server.Close()
+
+	_, err := NewRemote(server.URL, publics[1]).Sign([]byte("data"))
+	require.Error(t, err)
+}
+
+func TestListenLoopbackOnly(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:0", "http://127.0.0.1:0", "[::1]:0", "localhost:0"} {
+		l, err := Listen(addr)
+		if err == nil {
+			l.Close()
+		}
+		// ::1 may be unavailable, but a listener must never be refused for being non-loopback
+		if err != nil {
+			require.NotContains(t, err.Error(), "refusing", addr)
+		}
+	}
+	for _, addr := range []string{":0", "0.0.0.0:0", "[::]:0", "http://0.0.0.0:0", "192.0.2.1:0"} {
+		_, err := Listen(addr)
+		require.Error(t, err, addr)
+		require.Contains(t, err.Error(), "refusing", addr)
+	}
+}
@@ -12,12 +12,18 @@ package config
 import (
 	"fmt"
 
+	"github.com/ndau/ndau/pkg/signer"
 	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/ndau/ndaumath/pkg/key"
 	"github.com/ndau/ndaumath/pkg/signature"
 	"github.com/pkg/errors"
 )
 
+// ErrNoPrivateKey is returned when a validation key is needed to sign, but only its public
+// half is known: the account has it on the blockchain, but it wasn't derived from the
+// account's recovery phrase.
+var ErrNoPrivateKey = errors.New("private half of validation key unknown")
+
 // An Account contains the data necessary to interact with an account:
 //
 // ownership keys, validation keys if assigned, an account nickname, and an address
@@ -28,6 +34,11 @@ type Account struct {
 	Ownership        Keypair         `toml:"ownership"`
 	Validation       []Keypair       `toml:"validation"`
 	ValidationScript chaincode       `toml:"validation_script"`
+
+	// the encrypted private keys, if the config is encrypted;
+	// locked is set while they are not decrypted
+	sealed string
+	locked bool
 }
 
 func (a *Account) String() string {
@@ -50,19 +61,51 @@ func (a *Account) ValidationPublic() []signature.PublicKey {
 }
 
 // ValidationPrivate constructs a list of all private validation keys
+//
+// It returns nil if the account's keys are encrypted and not decrypted;
+// ValidationPrivateE says so instead.
 func (a *Account) ValidationPrivate() []signature.PrivateKey {
-	pks := make([]signature.PrivateKey, 0, len(a.Validation))
-	for _, kp := range a.Validation {
-		pks = append(pks, kp.Private)
-	}
+	pks, _ := a.ValidationPrivateE()
 	return pks
 }
 
 // ValidationPrivateK constructs a list of all private validation keys which have
 // their bits set, treating `keys` as a bitset with the lowest bit corresponding
 // to the 0 index of the list of validation keys.
+//
+// It returns nil if the account's keys are encrypted and not decrypted;
+// ValidationPrivateKE says so instead.
 func (a *Account) ValidationPrivateK(keys int) []signature.PrivateKey {
-	return FilterK(a.ValidationPrivate(), keys)
+	pks, _ := a.ValidationPrivateKE(keys)
+	return pks
+}
+
+// ValidationPrivateE constructs a list of all private validation keys
+//
+// It returns ErrLocked if the account's keys are encrypted and not decrypted, and
+// ErrNoPrivateKey if a key's private half isn't known.
+func (a *Account) ValidationPrivateE() ([]signature.PrivateKey, error) {
+	return a.ValidationPrivateKE(-1)
+}
+
+// ValidationPrivateKE is ValidationPrivateK, but returns ErrLocked if the account's
+// keys are encrypted and not decrypted, and ErrNoPrivateKey if the private half of
+// a selected key isn't known.
+func (a *Account) ValidationPrivateKE(keys int) ([]signature.PrivateKey, error) {
+	if a.locked {
+		return nil, ErrLocked
+	}
+	pks := make([]signature.PrivateKey, 0, len(a.Validation))
+	for _, kp := range a.Validation {
+		pks = append(pks, kp.Private)
+	}
+	pks = FilterK(pks, keys)
+	for _, pk := range pks {
+		if len(pk.KeyBytes()) == 0 {
+			return nil, errors.Wrap(ErrNoPrivateKey, a.Name)
+		}
+	}
+	return pks, nil
 }
 
 // FilterK filters a list of private keys by k, treating k as a bitset.
@@ -142,7 +185,7 @@ func (a *Account) nextValidationPath() *string {
 		ValidationPathFormat,
 		AccountListOffset,
 		ValidationKeyOffset,
-		AccountStartNumber,
+		account,
 		key+1,
 	)
 	return &h
@@ -153,6 +196,9 @@ func (a *Account) nextValidationPath() *string {
 // It does not actually add it to the keys list--that may be contraindicated
 // by errors further on.
 func (a *Account) MakeValidationKey(path *string) (newKeys *Keypair, err error) {
+	if a.locked {
+		return nil, ErrLocked
+	}
 	newKeys = &Keypair{}
 	if a.Root == nil {
 		// it's probably a non-hd key, so just proceed on that assumption
@@ -198,3 +244,25 @@ func (a *Account) MakeValidationKey(path *string) (newKeys *Keypair, err error)
 
 	return
 }
+
+// ValidationSigner returns a signer for the validation keys selected by the bitset
+// keys, as for ValidationPrivateK.
+//
+// The keys must be in the config; Config.ValidationSigner also works with a remote signer.
+func (a *Account) ValidationSigner(keys int) (signer.Signer, error) {
+	pks, err := a.ValidationPrivateKE(keys)
+	if err != nil {
+		return nil, err
+	}
+	return signer.NewLocal(pks...), nil
+}
+
+// OwnershipSigner returns a signer for the account's ownership key.
+//
+// The key must be in the config; Config.OwnershipSigner also works with a remote signer.
+func (a *Account) OwnershipSigner() (signer.Signer, error) {
+	if a.locked {
+		return nil, ErrLocked
+	}
+	return signer.NewLocal(a.Ownership.Private), nil
+}
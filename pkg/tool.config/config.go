@@ -69,14 +69,23 @@ func GetConfigPath() string {
 }
 
 // Config represents all data from `ndautool.toml`
+//
+// Signer, if set, is the address of a remote signer which holds the private keys of the
+// config's accounts, in the form signer.NewRemote accepts. See ValidationSigner.
 type Config struct {
 	Node        string              `toml:"node"`
+	Signer      string              `toml:"signer"`
 	Accounts    map[string]*Account `toml:"accounts"`
 	RFE         *SysAccount         `toml:"rfe"`
 	NNR         *SysAccount         `toml:"nnr"`
 	CVC         *SysAccount         `toml:"cvc"`
 	RecordPrice *SysAccount         `toml:"record_price"`
 	SetSysvar   *SysAccount         `toml:"set_sysvar"`
+
+	// keystore is set when private keys are stored encrypted;
+	// key is set when they have been decrypted
+	keystore *Keystore
+	key      []byte
 }
 
 // NewConfig creates a new configuration with the given address
@@ -123,7 +132,15 @@ func LoadDefault(configPath string) (*Config, error) {
 }
 
 // Save the current configuration
+//
+// If the config is encrypted, private keys are saved encrypted. Keys which were
+// added since the config was loaded can only be saved while it is unlocked.
 func (c *Config) Save() error {
+	return c.SaveTo(GetConfigPath())
+}
+
+// SaveTo saves the current configuration to the given path
+func (c *Config) SaveTo(cp string) error {
 	tc, err := c.toToml()
 	if err != nil {
 		return err
@@ -132,7 +149,6 @@ func (c *Config) Save() error {
 	if err := toml.NewEncoder(buf).Encode(tc); err != nil {
 		return err
 	}
-	cp := GetConfigPath()
 	dir, _ := filepath.Split(cp)
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return err
@@ -321,26 +337,62 @@ func (c Config) toToml() (tomlConfig, error) {
 	}
 	sort.Slice(tacs, func(i, j int) bool { return tacs[i].Name < tacs[j].Name })
 
-	return tomlConfig{
-		Node:        c.Node,
-		Accounts:    tacs,
-		RFE:         c.RFE,
-		NNR:         c.NNR,
-		CVC:         c.CVC,
-		RecordPrice: c.RecordPrice,
-		SetSysvar:   c.SetSysvar,
-	}, nil
+	if c.keystore == nil {
+		return tomlConfig{
+			Node:        c.Node,
+			Signer:      c.Signer,
+			Accounts:    tacs,
+			RFE:         c.RFE,
+			NNR:         c.NNR,
+			CVC:         c.CVC,
+			RecordPrice: c.RecordPrice,
+			SetSysvar:   c.SetSysvar,
+		}, nil
+	}
+
+	// keep private keys out of encrypted configs
+	tc := tomlConfig{
+		Node:           c.Node,
+		Signer:         c.Signer,
+		Keystore:       c.keystore,
+		SealedAccounts: make([]sealedAccount, 0, len(tacs)),
+		SealedSys:      make(map[string]sealedSysAccount),
+	}
+	for i := range tacs {
+		sa, err := c.sealedAccount(c.Accounts[tacs[i].Address.String()])
+		if err != nil {
+			return tc, err
+		}
+		tc.SealedAccounts = append(tc.SealedAccounts, sa)
+	}
+	for name, sa := range c.sysAccounts() {
+		if *sa == nil {
+			continue
+		}
+		sealed, err := c.sealSysAccount(*sa)
+		if err != nil {
+			return tc, errors.Wrap(err, name)
+		}
+		tc.SealedSys[name] = sealedSysAccount{Address: (*sa).Address, Public: (*sa).Public, Sealed: sealed}
+	}
+	return tc, nil
 }
 
 // Config represents all data from `ndautool.toml`
 type tomlConfig struct {
 	Node        string      `toml:"node"`
+	Signer      string      `toml:"signer"`
 	Accounts    []Account   `toml:"accounts"`
 	RFE         *SysAccount `toml:"rfe"`
 	NNR         *SysAccount `toml:"nnr"`
 	CVC         *SysAccount `toml:"cvc"`
 	RecordPrice *SysAccount `toml:"record_price"`
 	SetSysvar   *SysAccount `toml:"set_sysvar"`
+
+	// encrypted configs store only public data in the fields above
+	Keystore       *Keystore                   `toml:"keystore"`
+	SealedAccounts []sealedAccount             `toml:"sealed_accounts"`
+	SealedSys      map[string]sealedSysAccount `toml:"sealed_sys"`
 }
 
 func (tc tomlConfig) toConfig() (*Config, error) {
@@ -356,15 +408,36 @@ func (tc tomlConfig) toConfig() (*Config, error) {
 		}
 	}
 
-	return &Config{
+	for _, sa := range tc.SealedAccounts {
+		acct := sa.toAccount()
+		acts[acct.Address.String()] = &acct
+		if acct.Name != "" {
+			acts[acct.Name] = &acct
+		}
+	}
+
+	c := &Config{
 		Node:        tc.Node,
+		Signer:      tc.Signer,
 		Accounts:    acts,
 		RFE:         tc.RFE,
 		NNR:         tc.NNR,
 		CVC:         tc.CVC,
 		RecordPrice: tc.RecordPrice,
 		SetSysvar:   tc.SetSysvar,
-	}, nil
+		keystore:    tc.Keystore,
+	}
+
+	sysaccts := c.sysAccounts()
+	for name, ssa := range tc.SealedSys {
+		sa, ok := sysaccts[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown system account: %s", name)
+		}
+		*sa = &SysAccount{Address: ssa.Address, Public: ssa.Public, sealed: ssa.Sealed, locked: ssa.Sealed != ""}
+	}
+
+	return c, nil
 }
 
 // UpdateFrom updates the config file given the path to the associated data file
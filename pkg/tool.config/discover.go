@@ -0,0 +1,201 @@
+package config
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/key"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/ndau/ndaumath/pkg/words"
+	"github.com/pkg/errors"
+)
+
+// DefaultGapLimit is the number of consecutive unused account paths after which
+// discovery stops, as in BIP-44.
+const DefaultGapLimit = 20
+
+// An AccountLookup fetches the on-chain data of a batch of addresses.
+//
+// It is normally a thin wrapper around tool.GetAccounts.
+type AccountLookup func(addrs []address.Address) (query.AccountsResponse, error)
+
+// deriveKeypair derives the keypair at path from the root key
+func deriveKeypair(ekey *key.ExtendedKey, path string) (Keypair, error) {
+	kp := Keypair{Path: &path}
+	prive, err := ekey.DeriveFrom("/", path)
+	if err != nil {
+		return kp, errors.Wrap(err, "deriving child private key")
+	}
+	privs, err := prive.SPrivKey()
+	if err != nil {
+		return kp, errors.Wrap(err, "converting child private key to ndau fmt")
+	}
+	kp.Private = *privs
+	pube, err := prive.Public()
+	if err != nil {
+		return kp, errors.Wrap(err, "converting child private key to public")
+	}
+	pubs, err := pube.SPubKey()
+	if err != nil {
+		return kp, errors.Wrap(err, "converting child public key to ndau fmt")
+	}
+	kp.Public = *pubs
+	return kp, nil
+}
+
+// DiscoverAccounts recovers every used account derived from a phrase.
+//
+// Account paths are derived in order from AccountStartNumber and checked on-chain
+// in batches of gap; scanning stops after gap consecutive accounts which don't
+// exist. Each account found which is not already in the config is added with the
+// name prefix-n, where n is its index in the account path.
+//
+// An account's validation keys are recovered by deriving successive validation
+// paths until every on-chain validation key has been matched, or gap consecutive
+// paths match none. Keys which were not derived from the phrase keep their place in
+// the list with only their public half, so that key indices still match the
+// account's validation script.
+//
+// If gap is 0, DefaultGapLimit is used. The accounts added are returned.
+func (c *Config) DiscoverAccounts(
+	prefix string, phrase []string, lang string, gap uint64, lookup AccountLookup,
+) ([]*Account, error) {
+	if gap == 0 {
+		gap = DefaultGapLimit
+	}
+
+	seed, err := words.ToBytes(lang, phrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "recovering root account")
+	}
+	ekey, err := key.NewMaster(seed)
+	if err != nil {
+		return nil, errors.Wrap(err, "recovering root key")
+	}
+	rootPath := "/"
+	root := Keypair{Path: &rootPath}
+	private, err := ekey.SPrivKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "converting root private key to ndau format")
+	}
+	root.Private = *private
+	publice, err := ekey.Public()
+	if err != nil {
+		return nil, errors.Wrap(err, "recovering root public key")
+	}
+	public, err := publice.SPubKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "converting root public key to ndau format")
+	}
+	root.Public = *public
+
+	added := make([]*Account, 0)
+	lastUsed := uint64(AccountStartNumber) - 1
+	for next := uint64(AccountStartNumber); next <= lastUsed+gap; next += gap {
+		batch := make([]Account, 0, gap)
+		addrs := make([]address.Address, 0, gap)
+		for n := next; n < next+gap; n++ {
+			ownership, err := deriveKeypair(ekey, fmt.Sprintf(AccountPathFormat, AccountListOffset, n))
+			if err != nil {
+				return added, errors.Wrapf(err, "deriving account %d", n)
+			}
+			addr, err := address.Generate(address.KindUser, ownership.Public.KeyBytes())
+			if err != nil {
+				return added, errors.Wrapf(err, "generating address for account %d", n)
+			}
+			rootCopy := root
+			batch = append(batch, Account{
+				Name:      fmt.Sprintf("%s-%d", prefix, n),
+				Address:   addr,
+				Root:      &rootCopy,
+				Ownership: ownership,
+			})
+			addrs = append(addrs, addr)
+		}
+
+		found, err := lookup(addrs)
+		if err != nil {
+			return added, errors.Wrap(err, "looking up accounts")
+		}
+
+		for i := range batch {
+			acct := batch[i]
+			result, ok := found[acct.Address.String()]
+			if !ok || !result.Exists {
+				continue
+			}
+			lastUsed = next + uint64(i)
+			if _, known := c.Accounts[acct.Address.String()]; known {
+				continue
+			}
+			if _, taken := c.Accounts[acct.Name]; taken {
+				return added, errors.New("account already exists: " + acct.Name)
+			}
+			err = acct.recoverValidationKeys(result.Data.ValidationKeys, gap)
+			if err != nil {
+				return added, errors.Wrapf(err, "recovering validation keys of %s", acct.Name)
+			}
+			acct.ValidationScript = chaincode(result.Data.ValidationScript)
+			c.SetAccount(acct)
+			added = append(added, c.Accounts[acct.Address.String()])
+		}
+	}
+	return added, nil
+}
+
+// recoverValidationKeys matches an account's on-chain validation keys with keys
+// derived at its successive validation paths
+//
+// Keys which match none keep their places, so that key bitsets still select the keys
+// they do on the blockchain, but only their public halves are known; asking to sign
+// with them returns ErrNoPrivateKey.
+func (a *Account) recoverValidationKeys(keys []signature.PublicKey, gap uint64) error {
+	a.Validation = make([]Keypair, len(keys))
+	for i, public := range keys {
+		a.Validation[i].Public = public
+	}
+	missing := len(keys)
+
+	for miss := uint64(0); missing > 0 && miss < gap; {
+		// highestValidationPath only counts matched keys, so this walks the
+		// paths after the last match
+		account, highest := a.highestValidationPath()
+		path := fmt.Sprintf(
+			ValidationPathFormat,
+			AccountListOffset,
+			ValidationKeyOffset,
+			account,
+			highest+miss+1,
+		)
+		kp, err := a.MakeValidationKey(&path)
+		if err != nil {
+			return err
+		}
+		matched := false
+		for i := range a.Validation {
+			if a.Validation[i].Path == nil && bytes.Equal(a.Validation[i].Public.KeyBytes(), kp.Public.KeyBytes()) {
+				a.Validation[i] = *kp
+				missing--
+				matched = true
+				break
+			}
+		}
+		if matched {
+			miss = 0
+		} else {
+			miss++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package config
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ndau/ndau/pkg/ndau/backing"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/key"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/ndau/ndaumath/pkg/words"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const testLang = "en"
+
+// testPhrase returns a fixed recovery phrase
+func testPhrase(t *testing.T) []string {
+	seed := make([]byte, 16)
+	for i := range seed {
+		seed[i] = byte(i + 1)
+	}
+	phrase, err := words.FromBytes(testLang, seed)
+	require.NoError(t, err)
+	return phrase
+}
+
+// testChain is an AccountLookup backed by a map of the accounts which exist.
+// It records the batches it was asked to look up.
+type testChain struct {
+	accounts map[string]backing.AccountData
+	batches  [][]address.Address
+}
+
+func (tc *testChain) lookup(addrs []address.Address) (query.AccountsResponse, error) {
+	tc.batches = append(tc.batches, addrs)
+	out := make(query.AccountsResponse)
+	for _, addr := range addrs {
+		data, exists := tc.accounts[addr.String()]
+		out[addr.String()] = query.AccountResult{Exists: exists, Data: data}
+	}
+	return out, nil
+}
+
+// phraseAddress derives the address of account n of the phrase
+func phraseAddress(t *testing.T, phrase []string, n uint64) address.Address {
+	seed, err := words.ToBytes(testLang, phrase)
+	require.NoError(t, err)
+	ekey, err := key.NewMaster(seed)
+	require.NoError(t, err)
+	kp, err := deriveKeypair(ekey, fmt.Sprintf(AccountPathFormat, AccountListOffset, n))
+	require.NoError(t, err)
+	addr, err := address.Generate(address.KindUser, kp.Public.KeyBytes())
+	require.NoError(t, err)
+	return addr
+}
+
+func TestDiscoverStopsAtGapLimit(t *testing.T) {
+	phrase := testPhrase(t)
+	const gap = 5
+	chain := &testChain{accounts: make(map[string]backing.AccountData)}
+	for _, n := range []uint64{1, 3, 8, 20} {
+		chain.accounts[phraseAddress(t, phrase, n).String()] = backing.AccountData{}
+	}
+
+	c := NewConfig(DefaultAddress)
+	added, err := c.DiscoverAccounts("found", phrase, testLang, gap, chain.lookup)
+	require.NoError(t, err)
+
+	// 8 is within gap of 3, but 20 is too far past 8 to be found
+	names := make([]string, 0, len(added))
+	for _, acct := range added {
+		names = append(names, acct.Name)
+	}
+	require.Equal(t, []string{"found-1", "found-3", "found-8"}, names)
+	_, ok := c.Accounts[phraseAddress(t, phrase, 20).String()]
+	require.False(t, ok)
+
+	// accounts 1-5 and 6-10 each found something; 11-15 found nothing, so we stopped
+	require.Equal(t, 3, len(chain.batches))
+	for _, batch := range chain.batches {
+		require.Equal(t, gap, len(batch))
+	}
+}
+
+func TestDiscoverSkipsKnownAccounts(t *testing.T) {
+	phrase := testPhrase(t)
+	chain := &testChain{accounts: make(map[string]backing.AccountData)}
+	for _, n := range []uint64{1, 2} {
+		chain.accounts[phraseAddress(t, phrase, n).String()] = backing.AccountData{}
+	}
+
+	// RecoverAccount recovers account 1 of the phrase
+	c := NewConfig(DefaultAddress)
+	require.NoError(t, c.RecoverAccount("mine", phrase, testLang))
+	mine := c.Accounts["mine"]
+
+	added, err := c.DiscoverAccounts("found", phrase, testLang, 0, chain.lookup)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(added))
+	require.Equal(t, "found-2", added[0].Name)
+	require.Equal(t, mine, c.Accounts[mine.Address.String()])
+	_, ok := c.Accounts["found-1"]
+	require.False(t, ok)
+
+	// an unknown account can't take the name of another
+	c = NewConfig(DefaultAddress)
+	require.NoError(t, c.CreateAccount("found-1", false))
+	_, err = c.DiscoverAccounts("found", phrase, testLang, 0, chain.lookup)
+	require.Error(t, err)
+}
+
+func TestDiscoverRecoversValidationKeys(t *testing.T) {
+	phrase := testPhrase(t)
+	const gap = 3
+
+	// derive validation keys of account 1 as the wallet which made them would have
+	c := NewConfig(DefaultAddress)
+	require.NoError(t, c.RecoverAccount("wallet", phrase, testLang))
+	wallet := c.Accounts["wallet"]
+	derived := make(map[uint64]*Keypair)
+	for _, n := range []uint64{1, 3} {
+		path := fmt.Sprintf(
+			ValidationPathFormat, AccountListOffset, ValidationKeyOffset, AccountStartNumber, n,
+		)
+		kp, err := wallet.MakeValidationKey(&path)
+		require.NoError(t, err)
+		derived[n] = kp
+	}
+	// and one which came from somewhere else entirely
+	foreign, _, err := signature.Generate(signature.Ed25519, nil)
+	require.NoError(t, err)
+
+	chain := &testChain{accounts: map[string]backing.AccountData{
+		wallet.Address.String(): backing.AccountData{
+			ValidationKeys: []signature.PublicKey{derived[3].Public, foreign, derived[1].Public},
+		},
+	}}
+
+	c = NewConfig(DefaultAddress)
+	added, err := c.DiscoverAccounts("found", phrase, testLang, gap, chain.lookup)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(added))
+	acct := added[0]
+	require.Equal(t, wallet.Address, acct.Address)
+
+	// every key keeps its on-chain index; only the derived ones have private halves
+	require.Equal(t, 3, len(acct.Validation))
+	for i, want := range []*Keypair{derived[3], nil, derived[1]} {
+		got := acct.Validation[i]
+		if want == nil {
+			require.Nil(t, got.Path)
+			require.Equal(t, foreign.KeyBytes(), got.Public.KeyBytes())
+			continue
+		}
+		require.NotNil(t, got.Path)
+		require.Equal(t, *want.Path, *got.Path)
+		require.Equal(t, want.Private.KeyBytes(), got.Private.KeyBytes())
+	}
+
+	// so the account can't sign with the foreign key, but can with the others
+	_, err = c.ValidationSigner(acct, -1)
+	require.Equal(t, ErrNoPrivateKey, errors.Cause(err))
+	s, err := c.ValidationSigner(acct, 1|4)
+	require.NoError(t, err)
+	data := []byte("signed data")
+	sigs, err := s.Sign(data)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(sigs))
+	require.True(t, derived[3].Public.Verify(data, sigs[0]))
+	require.True(t, derived[1].Public.Verify(data, sigs[1]))
+
+	// nor does it offer the foreign key to a signing service
+	keyring, err := c.Keyring()
+	require.NoError(t, err)
+	_, err = keyring.Signer(foreign)
+	require.Error(t, err)
+	_, err = keyring.Signer(derived[1].Public, derived[3].Public)
+	require.NoError(t, err)
+}
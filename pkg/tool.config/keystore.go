@@ -0,0 +1,542 @@
+package config
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ndau/ndau/pkg/signer"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters for new keystores
+//
+// These are the parameters recommended for interactive logins as of 2017.
+const (
+	keystoreN      = 1 << 15
+	keystoreR      = 8
+	keystoreP      = 1
+	keystoreKeyLen = 32 // AES-256
+	keystoreSalt   = 32
+)
+
+// keystoreCheck is sealed with the keystore key so that a wrong passphrase is
+// detected before any account is opened.
+const keystoreCheck = "ndautool keystore"
+
+// ErrLocked is returned when private keys are needed but the keystore is locked.
+var ErrLocked = errors.New("keystore is locked")
+
+// A Keystore describes how the private keys in a config are encrypted.
+//
+// The encryption key is derived from a passphrase with scrypt. Each account's
+// private keys are sealed separately with AES-GCM, using the account's address as
+// additional data, so sealed keys can't be swapped between accounts.
+type Keystore struct {
+	Salt  string `toml:"salt"`
+	N     int    `toml:"n"`
+	R     int    `toml:"r"`
+	P     int    `toml:"p"`
+	Check string `toml:"check"`
+}
+
+// newKeystore creates a keystore for the given passphrase, returning it and its key
+func newKeystore(passphrase string) (*Keystore, []byte, error) {
+	salt := make([]byte, keystoreSalt)
+	_, err := io.ReadFull(rand.Reader, salt)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "generating keystore salt")
+	}
+	ks := &Keystore{
+		Salt: base64.StdEncoding.EncodeToString(salt),
+		N:    keystoreN,
+		R:    keystoreR,
+		P:    keystoreP,
+	}
+	key, err := ks.derive(passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	ks.Check, err = seal(key, keystoreCheck, []byte(keystoreCheck))
+	if err != nil {
+		return nil, nil, err
+	}
+	return ks, key, nil
+}
+
+// derive computes the keystore key from a passphrase
+func (ks *Keystore) derive(passphrase string) ([]byte, error) {
+	salt, err := base64.StdEncoding.DecodeString(ks.Salt)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding keystore salt")
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, ks.N, ks.R, ks.P, keystoreKeyLen)
+	return key, errors.Wrap(err, "deriving keystore key")
+}
+
+// open derives the keystore key from a passphrase, and checks that it is correct
+func (ks *Keystore) open(passphrase string) ([]byte, error) {
+	key, err := ks.derive(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	check, err := unseal(key, keystoreCheck, ks.Check)
+	if err != nil || string(check) != keystoreCheck {
+		return nil, errors.New("incorrect passphrase")
+	}
+	return key, nil
+}
+
+// seal encrypts data with the keystore key, bound to the given additional data
+func seal(key []byte, ad string, data []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return "", errors.Wrap(err, "generating nonce")
+	}
+	sealed := gcm.Seal(nonce, nonce, data, []byte(ad))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// unseal decrypts data sealed by seal
+func unseal(key []byte, ad string, text string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding sealed data")
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("sealed data too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	data, err := gcm.Open(nil, nonce, ciphertext, []byte(ad))
+	return data, errors.Wrap(err, "decrypting sealed data")
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating cipher")
+	}
+	gcm, err := cipher.NewGCM(block)
+	return gcm, errors.Wrap(err, "creating cipher")
+}
+
+// accountSecrets are the private keys of an Account
+type accountSecrets struct {
+	Root       *signature.PrivateKey  `json:"root,omitempty"`
+	Ownership  signature.PrivateKey   `json:"ownership"`
+	Validation []signature.PrivateKey `json:"validation"`
+}
+
+// seal encrypts the account's private keys
+func (a *Account) seal(key []byte) (string, error) {
+	if a.locked {
+		return "", errors.Wrap(ErrLocked, "sealing keys of "+a.Name)
+	}
+	// keys whose private halves are unknown keep their places
+	secrets := accountSecrets{
+		Ownership:  a.Ownership.Private,
+		Validation: make([]signature.PrivateKey, 0, len(a.Validation)),
+	}
+	for _, kp := range a.Validation {
+		secrets.Validation = append(secrets.Validation, kp.Private)
+	}
+	if a.Root != nil {
+		secrets.Root = &a.Root.Private
+	}
+	data, err := json.Marshal(secrets)
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling keys of "+a.Name)
+	}
+	return seal(key, a.Address.String(), data)
+}
+
+// unseal restores the account's private keys
+func (a *Account) unseal(key []byte) error {
+	data, err := unseal(key, a.Address.String(), a.sealed)
+	if err != nil {
+		return errors.Wrap(err, "unsealing keys of "+a.Name)
+	}
+	var secrets accountSecrets
+	err = json.Unmarshal(data, &secrets)
+	if err != nil {
+		return errors.Wrap(err, "unmarshaling keys of "+a.Name)
+	}
+	if len(secrets.Validation) != len(a.Validation) {
+		return errors.New("sealed validation keys don't match public keys of " + a.Name)
+	}
+	if a.Root != nil && secrets.Root != nil {
+		a.Root.Private = *secrets.Root
+	}
+	a.Ownership.Private = secrets.Ownership
+	for i := range a.Validation {
+		a.Validation[i].Private = secrets.Validation[i]
+	}
+	a.locked = false
+	return nil
+}
+
+// lock forgets the account's private keys
+func (a *Account) lock() {
+	if a.Root != nil {
+		a.Root.Private = signature.PrivateKey{}
+	}
+	a.Ownership.Private = signature.PrivateKey{}
+	for i := range a.Validation {
+		a.Validation[i].Private = signature.PrivateKey{}
+	}
+	a.locked = true
+}
+
+// seal encrypts the system account's private keys
+func (sa *SysAccount) seal(key []byte) (string, error) {
+	data, err := json.Marshal(sa.Keys)
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling system account keys")
+	}
+	return seal(key, sa.Address.String(), data)
+}
+
+// unseal restores the system account's private keys
+func (sa *SysAccount) unseal(key []byte) error {
+	data, err := unseal(key, sa.Address.String(), sa.sealed)
+	if err != nil {
+		return errors.Wrap(err, "unsealing system account keys")
+	}
+	var keys []signature.PrivateKey
+	err = json.Unmarshal(data, &keys)
+	if err != nil {
+		return errors.Wrap(err, "unmarshaling system account keys")
+	}
+	sa.Keys = keys
+	sa.locked = false
+	return nil
+}
+
+// sysAccounts lists the system accounts in a config by the names under which they are stored
+func (c *Config) sysAccounts() map[string]**SysAccount {
+	return map[string]**SysAccount{
+		"rfe":          &c.RFE,
+		"nnr":          &c.NNR,
+		"cvc":          &c.CVC,
+		"record_price": &c.RecordPrice,
+		"set_sysvar":   &c.SetSysvar,
+	}
+}
+
+// IsEncrypted is true when the config's private keys are stored in a keystore.
+func (c *Config) IsEncrypted() bool {
+	return c.keystore != nil
+}
+
+// IsLocked is true when the config's private keys are encrypted and not currently available.
+func (c *Config) IsLocked() bool {
+	return c.keystore != nil && c.key == nil
+}
+
+// Encrypt stores the config's private keys in a new keystore protected by the passphrase.
+//
+// This is how plaintext configs are migrated, and also how the passphrase is changed:
+// the keystore must be unlocked to do so. The keys are encrypted when the config is saved.
+func (c *Config) Encrypt(passphrase string) error {
+	if c.IsLocked() {
+		return ErrLocked
+	}
+	if passphrase == "" {
+		return errors.New("passphrase must not be empty")
+	}
+	ks, key, err := newKeystore(passphrase)
+	if err != nil {
+		return err
+	}
+	c.keystore = ks
+	c.key = key
+	return nil
+}
+
+// Unlock decrypts the config's private keys with the passphrase.
+func (c *Config) Unlock(passphrase string) error {
+	if c.keystore == nil {
+		return errors.New("config is not encrypted")
+	}
+	key, err := c.keystore.open(passphrase)
+	if err != nil {
+		return err
+	}
+	for _, acct := range c.GetAccounts() {
+		if acct.sealed == "" {
+			continue
+		}
+		err = acct.unseal(key)
+		if err != nil {
+			return err
+		}
+	}
+	for _, sa := range c.sysAccounts() {
+		if *sa == nil || (*sa).sealed == "" {
+			continue
+		}
+		err = (*sa).unseal(key)
+		if err != nil {
+			return err
+		}
+	}
+	c.key = key
+	return nil
+}
+
+// Lock forgets the config's decrypted private keys.
+//
+// Keys which were added or changed since the config was last saved are sealed first,
+// so that saving the locked config keeps them. If that's impossible, nothing is forgotten.
+func (c *Config) Lock() error {
+	if c.keystore == nil {
+		return nil
+	}
+	for _, acct := range c.GetAccounts() {
+		if !acct.locked {
+			_, err := c.sealAccount(acct)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for name, sa := range c.sysAccounts() {
+		if *sa != nil && !(*sa).locked {
+			_, err := c.sealSysAccount(*sa)
+			if err != nil {
+				return errors.Wrap(err, name)
+			}
+		}
+	}
+
+	for _, acct := range c.GetAccounts() {
+		acct.lock()
+	}
+	for _, sa := range c.sysAccounts() {
+		if *sa != nil {
+			(*sa).Keys = nil
+			(*sa).locked = true
+		}
+	}
+	for i := range c.key {
+		c.key[i] = 0
+	}
+	c.key = nil
+	return nil
+}
+
+// sealAccount returns the sealed private keys of an account for saving.
+func (c *Config) sealAccount(acct *Account) (string, error) {
+	if acct.locked {
+		return acct.sealed, nil
+	}
+	if c.key == nil {
+		return "", errors.Wrap(ErrLocked, "cannot save new keys of "+acct.Name)
+	}
+	sealed, err := acct.seal(c.key)
+	if err != nil {
+		return "", err
+	}
+	acct.sealed = sealed
+	return sealed, nil
+}
+
+// sealSysAccount returns the sealed private keys of a system account for saving.
+func (c *Config) sealSysAccount(sa *SysAccount) (string, error) {
+	if sa.locked {
+		return sa.sealed, nil
+	}
+	if c.key == nil {
+		return "", errors.Wrap(ErrLocked, "cannot save new system account keys")
+	}
+	sealed, err := sa.seal(c.key)
+	if err != nil {
+		return "", err
+	}
+	sa.sealed = sealed
+	return sealed, nil
+}
+
+// A publicKeypair is a Keypair as saved in an encrypted config
+type publicKeypair struct {
+	Path   *string             `toml:"path"`
+	Public signature.PublicKey `toml:"public"`
+}
+
+// A sealedAccount is an Account as saved in an encrypted config
+type sealedAccount struct {
+	Name             string          `toml:"name"`
+	Address          address.Address `toml:"address"`
+	Root             *publicKeypair  `toml:"root"`
+	Ownership        publicKeypair   `toml:"ownership"`
+	Validation       []publicKeypair `toml:"validation"`
+	ValidationScript chaincode       `toml:"validation_script"`
+	Sealed           string          `toml:"sealed"`
+}
+
+// A sealedSysAccount is a SysAccount as saved in an encrypted config
+type sealedSysAccount struct {
+	Address address.Address       `toml:"address"`
+	Public  []signature.PublicKey `toml:"public"`
+	Sealed  string                `toml:"sealed"`
+}
+
+func (c *Config) sealedAccount(acct *Account) (sealedAccount, error) {
+	sealed, err := c.sealAccount(acct)
+	if err != nil {
+		return sealedAccount{}, err
+	}
+	sa := sealedAccount{
+		Name:             acct.Name,
+		Address:          acct.Address,
+		Ownership:        publicKeypair{Path: acct.Ownership.Path, Public: acct.Ownership.Public},
+		ValidationScript: acct.ValidationScript,
+		Sealed:           sealed,
+	}
+	if acct.Root != nil {
+		sa.Root = &publicKeypair{Path: acct.Root.Path, Public: acct.Root.Public}
+	}
+	for _, kp := range acct.Validation {
+		sa.Validation = append(sa.Validation, publicKeypair{Path: kp.Path, Public: kp.Public})
+	}
+	return sa, nil
+}
+
+func (sa sealedAccount) toAccount() Account {
+	acct := Account{
+		Name:             sa.Name,
+		Address:          sa.Address,
+		Ownership:        Keypair{Path: sa.Ownership.Path, Public: sa.Ownership.Public},
+		ValidationScript: sa.ValidationScript,
+		sealed:           sa.Sealed,
+		locked:           sa.Sealed != "",
+	}
+	if sa.Root != nil {
+		acct.Root = &Keypair{Path: sa.Root.Path, Public: sa.Root.Public}
+	}
+	for _, kp := range sa.Validation {
+		acct.Validation = append(acct.Validation, Keypair{Path: kp.Path, Public: kp.Public})
+	}
+	return acct
+}
+
+// Migrate encrypts the private keys of the plaintext config at configPath with the passphrase.
+//
+// The encrypted config is written alongside the original, and only replaces it once it
+// has been loaded back and unlocked with the passphrase to the same keys.
+func Migrate(configPath string, passphrase string) error {
+	config, err := Load(configPath)
+	if err != nil {
+		return err
+	}
+	if config.IsEncrypted() {
+		return errors.New("config is already encrypted")
+	}
+	err = config.Encrypt(passphrase)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(configPath), filepath.Base(configPath)+".*")
+	if err != nil {
+		return errors.Wrap(err, "creating temporary config")
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	// once it's been renamed, there's nothing left to remove
+	defer os.Remove(tmpPath)
+
+	err = config.SaveTo(tmpPath)
+	if err != nil {
+		return err
+	}
+	migrated, err := Load(tmpPath)
+	if err != nil {
+		return errors.Wrap(err, "loading encrypted config")
+	}
+	err = migrated.Unlock(passphrase)
+	if err != nil {
+		return errors.Wrap(err, "unlocking encrypted config")
+	}
+	for _, acct := range config.GetAccounts() {
+		macct, ok := migrated.Accounts[acct.Address.String()]
+		if !ok || !bytes.Equal(macct.Ownership.Private.KeyBytes(), acct.Ownership.Private.KeyBytes()) {
+			return errors.New("encrypted config does not match for " + acct.Name)
+		}
+	}
+
+	err = os.Rename(tmpPath, configPath)
+	return errors.Wrap(err, "replacing config")
+}
+
+// Keyring collects every account keypair in the config, for use by a signing service.
+//
+// System accounts are included when their public keys are known. Configs updated from
+// associated data which predates recording them must be updated again.
+func (c *Config) Keyring() (*signer.Keyring, error) {
+	if c.IsLocked() {
+		return nil, ErrLocked
+	}
+	keyring := signer.NewKeyring()
+	add := func(kp Keypair) error {
+		return keyring.Add(kp.Public, kp.Private)
+	}
+	for _, acct := range c.GetAccounts() {
+		if acct.Root != nil {
+			if err := add(*acct.Root); err != nil {
+				return nil, err
+			}
+		}
+		if err := add(acct.Ownership); err != nil {
+			return nil, err
+		}
+		for _, kp := range acct.Validation {
+			if len(kp.Private.KeyBytes()) == 0 {
+				// only its public half was recovered; there's nothing to sign with
+				continue
+			}
+			if err := add(kp); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for name, sa := range c.sysAccounts() {
+		if *sa == nil || len((*sa).Public) != len((*sa).Keys) {
+			continue
+		}
+		for i, private := range (*sa).Keys {
+			if err := keyring.Add((*sa).Public[i], private); err != nil {
+				return nil, errors.Wrap(err, name)
+			}
+		}
+	}
+	return keyring, nil
+}
@@ -0,0 +1,195 @@
+package config
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testPassphrase = "correct horse battery staple"
+
+// testConfig creates a plaintext config with an HD account which has a validation key,
+// and a non-HD account
+func testConfig(t *testing.T) *Config {
+	c := NewConfig(DefaultAddress)
+	require.NoError(t, c.CreateAccount("hd", true))
+	require.NoError(t, c.CreateAccount("plain", false))
+
+	acct := c.Accounts["hd"]
+	kp, err := acct.MakeValidationKey(nil)
+	require.NoError(t, err)
+	acct.Validation = append(acct.Validation, *kp)
+	return c
+}
+
+func tempConfigPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(t, err)
+	return filepath.Join(dir, "ndautool.toml"), func() { os.RemoveAll(dir) }
+}
+
+// requireSameKeys checks that every account of want has the same private keys in got
+func requireSameKeys(t *testing.T, want, got *Config) {
+	for _, acct := range want.GetAccounts() {
+		gacct, ok := got.Accounts[acct.Address.String()]
+		require.True(t, ok, "missing account %s", acct.Name)
+		require.Equal(t, acct.Ownership.Private.KeyBytes(), gacct.Ownership.Private.KeyBytes())
+		if acct.Root != nil {
+			require.Equal(t, acct.Root.Private.KeyBytes(), gacct.Root.Private.KeyBytes())
+		}
+		wvals, err := acct.ValidationPrivateE()
+		require.NoError(t, err)
+		gvals, err := gacct.ValidationPrivateE()
+		require.NoError(t, err)
+		require.Equal(t, len(wvals), len(gvals))
+		for i := range wvals {
+			require.Equal(t, wvals[i].KeyBytes(), gvals[i].KeyBytes())
+		}
+	}
+}
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	path, cleanup := tempConfigPath(t)
+	defer cleanup()
+
+	c := testConfig(t)
+	require.NoError(t, c.Encrypt(testPassphrase))
+	require.NoError(t, c.SaveTo(path))
+
+	// no private key is saved in the clear
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	for _, acct := range c.GetAccounts() {
+		private, err := acct.Ownership.Private.MarshalText()
+		require.NoError(t, err)
+		require.False(t, strings.Contains(string(data), string(private)))
+	}
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.True(t, loaded.IsEncrypted())
+	require.True(t, loaded.IsLocked())
+
+	// private keys aren't available until the config is unlocked
+	acct := loaded.Accounts["hd"]
+	_, err = acct.ValidationPrivateE()
+	require.Equal(t, ErrLocked, err)
+	require.Nil(t, acct.ValidationPrivate())
+	_, err = acct.ValidationSigner(-1)
+	require.Equal(t, ErrLocked, err)
+	_, err = acct.OwnershipSigner()
+	require.Equal(t, ErrLocked, err)
+	_, err = loaded.Keyring()
+	require.Equal(t, ErrLocked, err)
+
+	require.NoError(t, loaded.Unlock(testPassphrase))
+	require.False(t, loaded.IsLocked())
+	requireSameKeys(t, c, loaded)
+	_, err = loaded.Accounts["hd"].OwnershipSigner()
+	require.NoError(t, err)
+}
+
+func TestKeystoreWrongPassphrase(t *testing.T) {
+	path, cleanup := tempConfigPath(t)
+	defer cleanup()
+
+	c := testConfig(t)
+	require.NoError(t, c.Encrypt(testPassphrase))
+	require.NoError(t, c.SaveTo(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.Error(t, loaded.Unlock("wrong"))
+	require.True(t, loaded.IsLocked())
+	_, err = loaded.Accounts["plain"].OwnershipSigner()
+	require.Equal(t, ErrLocked, err)
+}
+
+func TestKeystoreSwappedSeals(t *testing.T) {
+	path, cleanup := tempConfigPath(t)
+	defer cleanup()
+
+	c := testConfig(t)
+	require.NoError(t, c.Encrypt(testPassphrase))
+	require.NoError(t, c.SaveTo(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	hd, plain := loaded.Accounts["hd"], loaded.Accounts["plain"]
+	hd.sealed, plain.sealed = plain.sealed, hd.sealed
+
+	// each account's keys are bound to its address
+	require.Error(t, loaded.Unlock(testPassphrase))
+	require.True(t, loaded.IsLocked())
+}
+
+func TestKeystoreLockSealsNewAccounts(t *testing.T) {
+	path, cleanup := tempConfigPath(t)
+	defer cleanup()
+
+	c := testConfig(t)
+	require.NoError(t, c.Encrypt(testPassphrase))
+	require.NoError(t, c.SaveTo(path))
+
+	// add an account, then lock without saving first
+	require.NoError(t, c.CreateAccount("new", false))
+	created := *c.Accounts["new"]
+	require.NoError(t, c.Lock())
+	require.True(t, c.IsLocked())
+	_, err := c.Accounts["new"].OwnershipSigner()
+	require.Equal(t, ErrLocked, err)
+
+	// the new account's keys were sealed, so they survive saving while locked
+	require.NoError(t, c.SaveTo(path))
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.NoError(t, loaded.Unlock(testPassphrase))
+	require.Equal(t,
+		created.Ownership.Private.KeyBytes(),
+		loaded.Accounts["new"].Ownership.Private.KeyBytes(),
+	)
+
+	// accounts added while locked can't be sealed, so the config refuses to forget them
+	require.NoError(t, loaded.Lock())
+	require.NoError(t, loaded.CreateAccount("later", false))
+	require.Error(t, loaded.Lock())
+	_, err = loaded.Accounts["later"].OwnershipSigner()
+	require.NoError(t, err)
+}
+
+func TestMigrate(t *testing.T) {
+	path, cleanup := tempConfigPath(t)
+	defer cleanup()
+
+	c := testConfig(t)
+	require.NoError(t, c.SaveTo(path))
+
+	require.NoError(t, Migrate(path, testPassphrase))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.True(t, loaded.IsEncrypted())
+	require.NoError(t, loaded.Unlock(testPassphrase))
+	requireSameKeys(t, c, loaded)
+
+	// nothing is left behind
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	// an encrypted config can't be migrated again
+	require.Error(t, Migrate(path, testPassphrase))
+}
@@ -0,0 +1,68 @@
+package config
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"github.com/ndau/ndau/pkg/signer"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/pkg/errors"
+)
+
+// ValidationSigner returns a signer for an account's validation keys selected by the
+// bitset keys, as for Account.ValidationPrivateK.
+//
+// If the config names a remote signer, it signs with them there, and the private keys
+// need not be in the config at all. Otherwise they must be, and the config unlocked.
+func (c *Config) ValidationSigner(acct *Account, keys int) (signer.Signer, error) {
+	if c.Signer == "" {
+		return acct.ValidationSigner(keys)
+	}
+	return signer.NewRemote(c.Signer, filterPublicK(acct.ValidationPublic(), keys)...), nil
+}
+
+// OwnershipSigner returns a signer for an account's ownership key.
+//
+// If the config names a remote signer, it signs with the key there.
+func (c *Config) OwnershipSigner(acct *Account) (signer.Signer, error) {
+	if c.Signer == "" {
+		return acct.OwnershipSigner()
+	}
+	return signer.NewRemote(c.Signer, acct.Ownership.Public), nil
+}
+
+// SysAccountSigner returns a signer for a system account's keys.
+//
+// If the config names a remote signer, it signs with the keys there; their public keys
+// must be known.
+func (c *Config) SysAccountSigner(sa *SysAccount) (signer.Signer, error) {
+	if c.Signer == "" {
+		return sa.Signer()
+	}
+	if len(sa.Public) == 0 {
+		return nil, errors.New("public keys of system account unknown; update from associated data again")
+	}
+	return signer.NewRemote(c.Signer, sa.Public...), nil
+}
+
+// filterPublicK filters a list of public keys by k, as FilterK does private keys.
+func filterPublicK(keys []signature.PublicKey, k int) []signature.PublicKey {
+	if k < 0 {
+		return keys
+	}
+
+	out := make([]signature.PublicKey, 0, len(keys))
+	for i := 0; k > 0 && i < len(keys); i++ {
+		if k&1 > 0 {
+			out = append(out, keys[i])
+		}
+		k = k >> 1
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package config
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ndau/ndau/pkg/signer"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	path, cleanup := tempConfigPath(t)
+	defer cleanup()
+
+	c := testConfig(t)
+	public, private, err := signature.Generate(signature.Ed25519, nil)
+	require.NoError(t, err)
+	c.RFE = &SysAccount{
+		Address: c.Accounts["plain"].Address,
+		Keys:    []signature.PrivateKey{private},
+		Public:  []signature.PublicKey{public},
+	}
+
+	// the signing service holds every key, system accounts included
+	keyring, err := c.Keyring()
+	require.NoError(t, err)
+	require.Equal(t, 5, keyring.Len())
+	server := httptest.NewServer(signer.NewServer(keyring))
+	defer server.Close()
+
+	require.NoError(t, c.Encrypt(testPassphrase))
+	require.NoError(t, c.SaveTo(path))
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.True(t, loaded.IsLocked())
+	acct := loaded.Accounts["hd"]
+
+	// without a signer, the keys are needed locally
+	_, err = loaded.ValidationSigner(acct, -1)
+	require.Equal(t, ErrLocked, err)
+	_, err = loaded.OwnershipSigner(acct)
+	require.Equal(t, ErrLocked, err)
+	_, err = loaded.SysAccountSigner(loaded.RFE)
+	require.Equal(t, ErrLocked, err)
+
+	// with one, they aren't
+	loaded.Signer = server.URL
+	require.NoError(t, loaded.SaveTo(path))
+	loaded, err = Load(path)
+	require.NoError(t, err)
+	require.Equal(t, server.URL, loaded.Signer)
+	acct = loaded.Accounts["hd"]
+	data := []byte("signable bytes")
+
+	s, err := loaded.ValidationSigner(acct, 1)
+	require.NoError(t, err)
+	sigs, err := s.Sign(data)
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	require.True(t, acct.Validation[0].Public.Verify(data, sigs[0]))
+
+	s, err = loaded.OwnershipSigner(acct)
+	require.NoError(t, err)
+	sig, err := signer.SignOne(data, s)
+	require.NoError(t, err)
+	require.True(t, acct.Ownership.Public.Verify(data, sig))
+
+	s, err = loaded.SysAccountSigner(loaded.RFE)
+	require.NoError(t, err)
+	sig, err = signer.SignOne(data, s)
+	require.NoError(t, err)
+	require.True(t, public.Verify(data, sig))
+}
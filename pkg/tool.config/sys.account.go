@@ -12,6 +12,7 @@ package config
 import (
 	"fmt"
 
+	"github.com/ndau/ndau/pkg/signer"
 	"github.com/ndau/ndaumath/pkg/address"
 	"github.com/ndau/ndaumath/pkg/signature"
 	generator "github.com/ndau/system_vars/pkg/genesis.generator"
@@ -26,6 +27,13 @@ import (
 type SysAccount struct {
 	Address address.Address        `toml:"address"`
 	Keys    []signature.PrivateKey `toml:"keys"`
+	// Public holds the public keys of Keys, in the same order, when they are known
+	Public []signature.PublicKey `toml:"public"`
+
+	// the encrypted private keys, if the config is encrypted;
+	// locked is set while they are not decrypted
+	sealed string
+	locked bool
 }
 
 // SysAccountFromAssc creates a SysAccount from the associated data
@@ -71,5 +79,31 @@ func SysAccountFromAssc(assc generator.Associated, acct sv.SysAcct) (sa *SysAcco
 		Address: addr,
 		Keys:    []signature.PrivateKey{privkey},
 	}
+
+	// older associated data doesn't record the public key
+	if pubkeyI, ok := assc[acct.Validation.Public]; ok {
+		pubkeyS, ok := pubkeyI.(string)
+		if !ok {
+			err = fmt.Errorf("assc: value of %s was not a string", acct.Validation.Public)
+			return
+		}
+		var pubkey signature.PublicKey
+		err = pubkey.UnmarshalText([]byte(pubkeyS))
+		if err != nil {
+			err = errors.Wrap(err, "unmarshalling public validation key of "+acct.Name)
+			return
+		}
+		sa.Public = []signature.PublicKey{pubkey}
+	}
 	return
 }
+
+// Signer returns a signer for the system account's keys, which must be in the config.
+//
+// Config.SysAccountSigner also works with keys held by a remote signer.
+func (sa *SysAccount) Signer() (signer.Signer, error) {
+	if sa.locked {
+		return nil, ErrLocked
+	}
+	return signer.NewLocal(sa.Keys...), nil
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/ndau/metanode/pkg/meta/app/code"
 	"github.com/ndau/ndau/pkg/ndau/backing"
 	"github.com/ndau/ndau/pkg/ndau/search"
 	"github.com/ndau/ndau/pkg/query"
@@ -41,6 +42,55 @@ func GetAccount(node client.ABCIClient, addr address.Address) (
 	return ad, res, err
 }
 
+// accountsPerQuery is the most addresses GetAccounts asks the node about at once.
+const accountsPerQuery = 100
+
+// GetAccounts gets the account data associated with each of the given addresses
+// in as few queries as possible
+//
+// The result is keyed by address; accounts which don't exist are returned
+// with their Exists flag unset. The addresses are looked up in chunks, so
+// that no single query can tie up the node for long; the raw result is that
+// of the last query.
+func GetAccounts(node client.ABCIClient, addrs []address.Address) (
+	query.AccountsResponse, *rpctypes.ResultABCIQuery, error,
+) {
+	accounts := make(query.AccountsResponse, len(addrs))
+	var res *rpctypes.ResultABCIQuery
+	for len(addrs) > 0 {
+		chunk := addrs
+		if len(chunk) > accountsPerQuery {
+			chunk = chunk[:accountsPerQuery]
+		}
+		addrs = addrs[len(chunk):]
+
+		req, err := query.AccountsRequest(chunk).MarshalMsg(nil)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "marshaling accounts query")
+		}
+
+		// perform the query
+		res, err = node.ABCIQuery(query.AccountsEndpoint, req)
+		if err != nil {
+			return nil, res, err
+		}
+		if code.ReturnCode(res.Response.Code) != code.OK {
+			return nil, res, errors.New(res.Response.Log)
+		}
+
+		// parse the response
+		found := make(query.AccountsResponse, len(chunk))
+		_, err = found.UnmarshalMsg(res.Response.GetValue())
+		if err != nil {
+			return nil, res, errors.Wrap(err, "GetAccounts")
+		}
+		for addr, result := range found {
+			accounts[addr] = result
+		}
+	}
+	return accounts, res, nil
+}
+
 // GetSequence gets the current sequence number of a particular account
 func GetSequence(node client.ABCIClient, addr address.Address) (uint64, error) {
 	acct, _, err := GetAccount(node, addr)
@@ -161,32 +211,37 @@ func GetAccountListBatch(node client.ABCIClient) ([]address.Address, error) {
 	return addrs, nil
 }
 
-// GetCurrencySeats gets a list of ndau currency seats
+// GetCurrencySeats gets a list of ndau currency seats, oldest first
 //
 // Currency seats are defined as those accounts containing more than 1000 ndau.
 func GetCurrencySeats(node client.ABCIClient) ([]address.Address, error) {
-	addrs, err := GetAccountListBatch(node)
+	seats, _, err := GetCurrencySeatDetails(node)
 	if err != nil {
 		return nil, errors.Wrap(err, "GetCurrencySeats")
 	}
-	seats := make(map[address.Address]*backing.AccountData)
-	for _, addr := range addrs {
-		data, _, err := GetAccount(node, addr)
-		if err != nil {
-			return nil, errors.Wrap(err, "GetCurrencySeats")
-		}
-		if data.CurrencySeatDate != nil {
-			seats[addr] = data
-		}
+	addrs := make([]address.Address, 0, len(seats))
+	for _, seat := range seats {
+		addrs = append(addrs, seat.Address)
 	}
-	seatAddrs := make([]address.Address, 0, len(seats))
-	for seat := range seats {
-		seatAddrs = append(seatAddrs, seat)
+	return addrs, nil
+}
+
+// GetCurrencySeatDetails gets the list of ndau currency seats, oldest first, with
+// the dates they were acquired and their accounts' balances
+func GetCurrencySeatDetails(node client.ABCIClient) (
+	query.CurrencySeatsResponse, *rpctypes.ResultABCIQuery, error,
+) {
+	// perform the query
+	res, err := node.ABCIQuery(query.CurrencySeatsEndpoint, nil)
+	if err != nil {
+		return nil, res, err
 	}
-	// sort seats by currency seat date, oldest first
-	// this way, getting the oldest `N` is as simple as slicing: `[:N]`
-	sort.Slice(seatAddrs, func(i, j int) bool {
-		return *seats[seatAddrs[i]].CurrencySeatDate < *seats[seatAddrs[j]].CurrencySeatDate
-	})
-	return seatAddrs, err
+	if code.ReturnCode(res.Response.Code) != code.OK {
+		return nil, res, errors.New(res.Response.Log)
+	}
+
+	// parse the response
+	seats := make(query.CurrencySeatsResponse, 0)
+	_, err = seats.UnmarshalMsg(res.Response.GetValue())
+	return seats, res, errors.Wrap(err, "GetCurrencySeatDetails")
 }
@@ -0,0 +1,40 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"testing"
+
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountsChunks(t *testing.T) {
+	const n = 2*accountsPerQuery + 1
+	addrs := make([]address.Address, 0, n)
+	for i := 0; i < n; i++ {
+		addrs = append(addrs, makeAddress(t))
+	}
+
+	node := &fakeNode{}
+	accounts, _, err := GetAccounts(node, addrs)
+	require.NoError(t, err)
+	require.Equal(t, []int{accountsPerQuery, accountsPerQuery, 1}, node.chunks)
+
+	// every address is answered, whichever chunk it was in
+	require.Equal(t, n, len(accounts))
+	for i, addr := range addrs {
+		result, ok := accounts[addr.String()]
+		require.True(t, ok, "missing %s", addr)
+		require.Equal(t, i%2 == 0, result.Exists)
+		require.Equal(t, types.Ndau(i), result.Data.Balance)
+	}
+}
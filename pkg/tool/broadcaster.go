@@ -103,3 +103,23 @@ func ResultLog(result interface{}) string {
 	}
 	return out
 }
+
+// ResultCode extracts the node's return code from the result of a broadcast
+//
+// For a commit, it is the CheckTx code if that failed, and the DeliverTx code otherwise.
+func ResultCode(result interface{}) uint32 {
+	switch x := result.(type) {
+	case *ctypes.ResultBroadcastTxCommit:
+		if x != nil {
+			if x.CheckTx.Code != 0 {
+				return x.CheckTx.Code
+			}
+			return x.DeliverTx.Code
+		}
+	case *ctypes.ResultBroadcastTx:
+		if x != nil {
+			return x.Code
+		}
+	}
+	return 0
+}
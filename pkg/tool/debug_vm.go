@@ -0,0 +1,61 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"github.com/ndau/metanode/pkg/meta/app/code"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/rpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// DebugVM re-runs one of the node's chaincode VMs at the current height, returning
+// its disassembly and a trace of its run
+//
+// vm is one of the query.VM constants. The tx validation, tx fee and rules validation
+// VMs run for tx; the goodness VM runs for node. Whichever isn't needed may be nil or
+// zero.
+func DebugVM(node client.ABCIClient, vm string, tx metatx.Transactable, nodeAddr address.Address) (
+	*query.VMDebugResponse, *rpctypes.ResultABCIQuery, error,
+) {
+	req := query.VMDebugRequest{
+		VM:   vm,
+		Node: nodeAddr.String(),
+	}
+	if tx != nil {
+		var err error
+		req.Tx, err = metatx.Marshal(tx, ndau.TxIDs)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "marshaling tx")
+		}
+	}
+	reqb, err := req.MarshalMsg(nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "marshaling vm debug query")
+	}
+
+	// perform the query
+	res, err := node.ABCIQuery(query.DebugVMEndpoint, reqb)
+	if err != nil {
+		return nil, res, err
+	}
+	if code.ReturnCode(res.Response.Code) != code.OK {
+		return nil, res, errors.New(res.Response.Log)
+	}
+
+	// parse the response
+	result := new(query.VMDebugResponse)
+	_, err = result.UnmarshalMsg(res.Response.GetValue())
+	return result, res, errors.Wrap(err, "DebugVM")
+}
@@ -0,0 +1,37 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+
+	"github.com/ndau/metanode/pkg/meta/app/code"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/rpc/client"
+)
+
+// IsFeatureActive reports whether the named feature is active on the connected node
+// at its current height.
+func IsFeatureActive(node client.ABCIClient, feature string) (bool, error) {
+	// perform the query
+	res, err := node.ABCIQuery(query.FeatureEndpoint, []byte(feature))
+	if err != nil {
+		return false, err
+	}
+	if code.ReturnCode(res.Response.Code) != code.OK {
+		return false, errors.New(res.Response.Log)
+	}
+
+	// parse the response
+	var active bool
+	_, err = fmt.Sscanf(res.Response.Info, query.FeatureInfoFmt, &active)
+	return active, errors.Wrap(err, "parsing feature status")
+}
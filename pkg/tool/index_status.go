@@ -0,0 +1,37 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"github.com/ndau/metanode/pkg/meta/app/code"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/rpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// IndexBehind reports whether the connected node's initial indexing is still catching up,
+// in which case searches may return incomplete results.
+func IndexBehind(node client.ABCIClient) (bool, *rpctypes.ResultABCIQuery, error) {
+	res, err := node.ABCIQuery(query.IndexStatusEndpoint, nil)
+	if err != nil {
+		return false, nil, err
+	}
+	if code.ReturnCode(res.Response.Code) != code.OK {
+		return false, res, errors.New(res.Response.Log)
+	}
+	return IsIndexBehind(res), res, nil
+}
+
+// IsIndexBehind reports whether the response to a search query says that the node's
+// initial indexing is still catching up.
+func IsIndexBehind(res *rpctypes.ResultABCIQuery) bool {
+	return res != nil && res.Response.Info == query.IndexBehindInfo
+}
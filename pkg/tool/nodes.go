@@ -10,9 +10,12 @@ package tool
 // - -- --- ---- -----
 
 import (
+	"encoding/json"
 	"fmt"
 	"sync"
 
+	"github.com/ndau/metanode/pkg/meta/app/code"
+	"github.com/ndau/ndau/pkg/ndau/search"
 	"github.com/ndau/ndau/pkg/query"
 	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/p2p"
@@ -93,3 +96,27 @@ func RegisteredNodes(node client.ABCIClient) (query.NodesResponse, error) {
 	}
 	return nresp, nil
 }
+
+// NodeRewardHistory returns the node reward nominations won by a given node,
+// and whether and when each was claimed
+func NodeRewardHistory(node client.ABCIClient, params search.NodeRewardHistoryParams) (
+	*search.NodeRewardHistoryResponse, error,
+) {
+	ps, err := json.Marshal(params)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling node reward history query params")
+	}
+	resp, err := node.ABCIQuery(query.NodeRewardsEndpoint, ps)
+	if err != nil {
+		return nil, errors.Wrap(err, "performing abci query")
+	}
+	if code.ReturnCode(resp.Response.Code) != code.OK {
+		return nil, errors.New(code.ReturnCode(resp.Response.Code).String() + ": " + resp.Response.Log)
+	}
+	nrhr := new(search.NodeRewardHistoryResponse)
+	err = nrhr.Unmarshal(string(resp.Response.GetValue()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling response")
+	}
+	return nrhr, nil
+}
@@ -0,0 +1,297 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/signer"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/rpc/client"
+)
+
+// PartialTxVersion is the version of the partially-signed tx format written by this package
+const PartialTxVersion = 1
+
+// A PartialTx is a portable, partially-signed transaction.
+//
+// It is created online, carried to each signer (which may be air-gapped), and
+// brought back for broadcast once enough signatures have been collected. Copies
+// signed independently can be merged.
+//
+// Body is the msgp-encoded tx without signatures. SignableBytes are recorded so that
+// an offline signer can see exactly what it signs, and so that a body which doesn't
+// match them is rejected. Signers lists the keys expected to sign; it may be empty
+// when they are not known, in which case any signature is accepted until the tx is
+// broadcast, when every signature is checked against the source's validation keys.
+type PartialTx struct {
+	Version       int                   `json:"version"`
+	TxType        string                `json:"tx_type"`
+	TxID          metatx.TxID           `json:"tx_id"`
+	Body          []byte                `json:"body"`
+	SignableBytes []byte                `json:"signable_bytes"`
+	Signers       []signature.PublicKey `json:"signers"`
+	Signatures    []signature.Signature `json:"signatures"`
+}
+
+// NewPartialTx creates a partially-signed tx from an unsigned tx
+//
+// signers are the keys expected to sign it; see RequiredSigners.
+func NewPartialTx(tx metatx.Transactable, signers ...signature.PublicKey) (*PartialTx, error) {
+	if stx, ok := tx.(ndau.Signeder); ok && len(stx.GetSignatures()) > 0 {
+		return nil, errors.New("tx is already signed")
+	}
+	if _, ok := tx.(ndau.Signable); !ok {
+		return nil, fmt.Errorf("%s txs cannot be signed", metatx.NameOf(tx))
+	}
+	id, err := metatx.TxIDOf(tx, ndau.TxIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting tx id")
+	}
+	body, err := metatx.Marshal(tx, ndau.TxIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling tx")
+	}
+	return &PartialTx{
+		Version:       PartialTxVersion,
+		TxType:        metatx.NameOf(tx),
+		TxID:          id,
+		Body:          body,
+		SignableBytes: tx.SignableBytes(),
+		Signers:       signers,
+	}, nil
+}
+
+// RequiredSigners looks up the validation keys of the source of a tx
+//
+// These are the keys which may sign it; the source's validation script
+// determines how many of them must.
+func RequiredSigners(node client.ABCIClient, tx metatx.Transactable) ([]signature.PublicKey, error) {
+	source, _, err := TxSource(node, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting tx source")
+	}
+	ad, _, err := GetAccount(node, source)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting source account")
+	}
+	return ad.ValidationKeys, nil
+}
+
+// ReadPartialTx reads a partially-signed tx, checking that it is internally consistent
+func ReadPartialTx(r io.Reader) (*PartialTx, error) {
+	p := new(PartialTx)
+	err := json.NewDecoder(r).Decode(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding partial tx")
+	}
+	if p.Version != PartialTxVersion {
+		return nil, fmt.Errorf("unsupported partial tx version %d", p.Version)
+	}
+	_, err = p.unsigned()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Write writes a partially-signed tx
+func (p *PartialTx) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// unsigned decodes the body, checking it against the recorded type and signable bytes
+func (p *PartialTx) unsigned() (metatx.Transactable, error) {
+	tx, err := metatx.Unmarshal(p.Body, ndau.TxIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding tx body")
+	}
+	id, err := metatx.TxIDOf(tx, ndau.TxIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting tx id")
+	}
+	if id != p.TxID || metatx.NameOf(tx) != p.TxType {
+		return nil, fmt.Errorf("tx body is a %s, not a %s", metatx.NameOf(tx), p.TxType)
+	}
+	if _, ok := tx.(ndau.Signable); !ok {
+		return nil, fmt.Errorf("%s txs cannot be signed", p.TxType)
+	}
+	if !bytes.Equal(tx.SignableBytes(), p.SignableBytes) {
+		return nil, errors.New("tx body does not match its signable bytes")
+	}
+	if stx, ok := tx.(ndau.Signeder); ok && len(stx.GetSignatures()) > 0 {
+		return nil, errors.New("tx body must not contain signatures")
+	}
+	return tx, nil
+}
+
+// signerOf returns the index in Signers of the key which made sig, or -1
+func (p *PartialTx) signerOf(sig signature.Signature) int {
+	return signerIn(p.Signers, p.SignableBytes, sig)
+}
+
+// signerIn returns the index in keys of the key which made sig of data, or -1
+func signerIn(keys []signature.PublicKey, data []byte, sig signature.Signature) int {
+	for i, key := range keys {
+		if key.Verify(data, sig) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Verify checks that every collected signature was made by one of keys
+func (p *PartialTx) Verify(keys []signature.PublicKey) error {
+	for i, sig := range p.Signatures {
+		if signerIn(keys, p.SignableBytes, sig) < 0 {
+			return fmt.Errorf("signature %d was not made by any of the source's validation keys", i)
+		}
+	}
+	return nil
+}
+
+// add appends signatures which are new and, if Signers are known, made by one of them
+func (p *PartialTx) add(sigs ...signature.Signature) error {
+	seen := make(map[string]struct{})
+	key := func(sig signature.Signature) (string, error) {
+		if len(p.Signers) > 0 {
+			i := p.signerOf(sig)
+			if i < 0 {
+				return "", errors.New("signature was not made by any expected signer")
+			}
+			return fmt.Sprint(i), nil
+		}
+		text, err := sig.MarshalText()
+		return string(text), err
+	}
+	for _, sig := range p.Signatures {
+		k, err := key(sig)
+		if err != nil {
+			return err
+		}
+		seen[k] = struct{}{}
+	}
+	for _, sig := range sigs {
+		k, err := key(sig)
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		p.Signatures = append(p.Signatures, sig)
+	}
+	return nil
+}
+
+// Sign adds the signer's signatures of the tx
+func (p *PartialTx) Sign(s signer.Signer) error {
+	sigs, err := s.Sign(p.SignableBytes)
+	if err != nil {
+		return errors.Wrap(err, "signing tx")
+	}
+	return p.add(sigs...)
+}
+
+// MergePartialTxs combines the signatures of copies of the same partially-signed tx
+func MergePartialTxs(base *PartialTx, others ...*PartialTx) (*PartialTx, error) {
+	out := *base
+	out.Signatures = append([]signature.Signature{}, base.Signatures...)
+	for _, other := range others {
+		if other.TxID != base.TxID || !bytes.Equal(other.SignableBytes, base.SignableBytes) {
+			return nil, errors.New("cannot merge partial txs for different txs")
+		}
+		err := out.add(other.Signatures...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &out, nil
+}
+
+// SignerStatus reports whether an expected signer has signed a partially-signed tx
+type SignerStatus struct {
+	Key    signature.PublicKey `json:"key"`
+	Signed bool                `json:"signed"`
+}
+
+// PartialTxInfo describes a partially-signed tx
+type PartialTxInfo struct {
+	TxType     string              `json:"tx_type"`
+	Tx         metatx.Transactable `json:"tx"`
+	Signers    []SignerStatus      `json:"signers"`
+	Signatures int                 `json:"signatures"`
+}
+
+// Inspect decodes a partially-signed tx and reports which expected signers have signed it
+func (p *PartialTx) Inspect() (*PartialTxInfo, error) {
+	tx, err := p.unsigned()
+	if err != nil {
+		return nil, err
+	}
+	info := PartialTxInfo{
+		TxType:     p.TxType,
+		Tx:         tx,
+		Signers:    make([]SignerStatus, len(p.Signers)),
+		Signatures: len(p.Signatures),
+	}
+	for i, key := range p.Signers {
+		info.Signers[i].Key = key
+	}
+	for _, sig := range p.Signatures {
+		if i := p.signerOf(sig); i >= 0 {
+			info.Signers[i].Signed = true
+		}
+	}
+	return &info, nil
+}
+
+// Tx returns the tx with all collected signatures applied
+func (p *PartialTx) Tx() (metatx.Transactable, error) {
+	tx, err := p.unsigned()
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Signatures) == 0 {
+		return nil, errors.New("tx has no signatures")
+	}
+	tx.(ndau.Signable).ExtendSignatures(p.Signatures)
+	return tx, nil
+}
+
+// BroadcastPartialTx broadcasts and commits a partially-signed tx with the signatures
+// collected so far
+//
+// The signatures are first checked against the source's current validation keys,
+// whether or not the partial tx lists its expected signers.
+func BroadcastPartialTx(node client.ABCIClient, p *PartialTx) (interface{}, error) {
+	tx, err := p.Tx()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := RequiredSigners(node, tx)
+	if err != nil {
+		return nil, err
+	}
+	err = p.Verify(keys)
+	if err != nil {
+		return nil, err
+	}
+	return SendCommit(node, tx)
+}
@@ -0,0 +1,169 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"bytes"
+	"testing"
+
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/signer"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/stretchr/testify/require"
+)
+
+type testKey struct {
+	public  signature.PublicKey
+	private signature.PrivateKey
+}
+
+func makeKeys(t *testing.T, n int) []testKey {
+	keys := make([]testKey, n)
+	for i := range keys {
+		public, private, err := signature.Generate(signature.Ed25519, nil)
+		require.NoError(t, err)
+		keys[i] = testKey{public, private}
+	}
+	return keys
+}
+
+func makeAddress(t *testing.T) address.Address {
+	key := makeKeys(t, 1)[0]
+	addr, err := address.Generate(address.KindUser, key.public.KeyBytes())
+	require.NoError(t, err)
+	return addr
+}
+
+// roundTrip writes a partial tx and reads it back, as when carrying it to a signer
+func roundTrip(t *testing.T, p *PartialTx) *PartialTx {
+	buf := new(bytes.Buffer)
+	require.NoError(t, p.Write(buf))
+	out, err := ReadPartialTx(buf)
+	require.NoError(t, err)
+	return out
+}
+
+func TestPartialTxRoundTrip(t *testing.T) {
+	keys := makeKeys(t, 3)
+	signers := []signature.PublicKey{keys[0].public, keys[1].public}
+	tx := ndau.NewTransfer(makeAddress(t), makeAddress(t), 100, 1)
+
+	p, err := NewPartialTx(tx, signers...)
+	require.NoError(t, err)
+	p = roundTrip(t, p)
+
+	// each signer signs its own copy
+	a, b := roundTrip(t, p), roundTrip(t, p)
+	require.NoError(t, a.Sign(signer.NewLocal(keys[0].private)))
+	require.NoError(t, b.Sign(signer.NewLocal(keys[1].private)))
+	a, b = roundTrip(t, a), roundTrip(t, b)
+
+	// signing twice adds nothing
+	require.NoError(t, a.Sign(signer.NewLocal(keys[0].private)))
+	require.Len(t, a.Signatures, 1)
+
+	// an unexpected signer is refused
+	require.Error(t, b.Sign(signer.NewLocal(keys[2].private)))
+	require.Len(t, b.Signatures, 1)
+
+	merged, err := MergePartialTxs(a, b, a)
+	require.NoError(t, err)
+	merged = roundTrip(t, merged)
+	require.Len(t, merged.Signatures, 2)
+
+	info, err := merged.Inspect()
+	require.NoError(t, err)
+	require.Equal(t, 2, info.Signatures)
+	for _, status := range info.Signers {
+		require.True(t, status.Signed)
+	}
+
+	signed, err := merged.Tx()
+	require.NoError(t, err)
+	expect := ndau.NewTransfer(tx.Source, tx.Destination, tx.Qty, tx.Sequence, keys[0].private, keys[1].private)
+	require.Equal(t, metatx.Hash(expect), metatx.Hash(signed))
+
+	// the inputs are left alone
+	require.Len(t, a.Signatures, 1)
+	require.Len(t, b.Signatures, 1)
+
+	t.Run("different txs", func(t *testing.T) {
+		other, err := NewPartialTx(ndau.NewTransfer(tx.Source, tx.Destination, 100, 2), signers...)
+		require.NoError(t, err)
+		_, err = MergePartialTxs(a, other)
+		require.Error(t, err)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		tampered := *p
+		body, err := metatx.Marshal(ndau.NewTransfer(tx.Source, tx.Destination, 1000, 1), ndau.TxIDs)
+		require.NoError(t, err)
+		tampered.Body = body
+		buf := new(bytes.Buffer)
+		require.NoError(t, tampered.Write(buf))
+		_, err = ReadPartialTx(buf)
+		require.Error(t, err)
+	})
+}
+
+func TestPartialTxUnsignable(t *testing.T) {
+	owner := makeKeys(t, 1)[0]
+	tx := ndau.NewSetValidation(makeAddress(t), owner.public, []signature.PublicKey{owner.public}, nil, 1)
+
+	_, err := NewPartialTx(tx)
+	require.Error(t, err)
+
+	// a hand-made partial tx is refused rather than panicking
+	id, err := metatx.TxIDOf(tx, ndau.TxIDs)
+	require.NoError(t, err)
+	body, err := metatx.Marshal(tx, ndau.TxIDs)
+	require.NoError(t, err)
+	p := &PartialTx{
+		Version:       PartialTxVersion,
+		TxType:        metatx.NameOf(tx),
+		TxID:          id,
+		Body:          body,
+		SignableBytes: tx.SignableBytes(),
+		Signatures:    []signature.Signature{owner.private.Sign(tx.SignableBytes())},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, p.Write(buf))
+	_, err = ReadPartialTx(buf)
+	require.Error(t, err)
+	_, err = p.Tx()
+	require.Error(t, err)
+}
+
+func TestBroadcastPartialTx(t *testing.T) {
+	keys := makeKeys(t, 2)
+	node := &fakeNode{
+		source:     makeAddress(t),
+		validation: []signature.PublicKey{keys[0].public},
+	}
+	tx := ndau.NewTransfer(node.source, makeAddress(t), 100, 1)
+
+	// no expected signers are listed, so any signature is collected
+	p, err := NewPartialTx(tx)
+	require.NoError(t, err)
+	require.NoError(t, p.Sign(signer.NewLocal(keys[1].private)))
+
+	// but one the source can't have made is refused at broadcast
+	_, err = BroadcastPartialTx(node, p)
+	require.Error(t, err)
+	require.Equal(t, 0, node.broadcasts)
+
+	p.Signatures = nil
+	require.NoError(t, p.Sign(signer.NewLocal(keys[0].private)))
+	_, err = BroadcastPartialTx(node, p)
+	require.NoError(t, err)
+	require.Equal(t, 1, node.broadcasts)
+}
@@ -1,6 +1,7 @@
 package tool
 
 import (
+	"github.com/ndau/metanode/pkg/meta/app/code"
 	srch "github.com/ndau/ndau/pkg/ndau/search"
 	"github.com/ndau/ndau/pkg/query"
 	"github.com/pkg/errors"
@@ -21,6 +22,9 @@ func priceHistory(
 	if err != nil {
 		return out, errors.Wrap(err, "performing query")
 	}
+	if code.ReturnCode(resp.Response.Code) != code.OK {
+		return out, errors.New(resp.Response.Log)
+	}
 	_, err = out.UnmarshalMsg(resp.Response.Value)
 	err = errors.Wrap(err, "unmarshaling response")
 	return out, err
@@ -41,3 +45,33 @@ func MarketPriceHistory(
 ) (srch.PriceQueryResults, error) {
 	return priceHistory(node, query.PriceMarketEndpoint, params)
 }
+
+// EndowmentNAVHistory returns historical data for the endowment NAV
+func EndowmentNAVHistory(
+	node client.ABCIClient,
+	params srch.PriceQueryParams,
+) (srch.PriceQueryResults, error) {
+	return priceHistory(node, query.PriceNAVEndpoint, params)
+}
+
+// SIBHistory returns historical data for the SIB rate and the floor price
+func SIBHistory(
+	node client.ABCIClient,
+	params srch.PriceQueryParams,
+) (srch.SIBQueryResults, error) {
+	var out srch.SIBQueryResults
+	pqpb, err := params.MarshalMsg(nil)
+	if err != nil {
+		return out, errors.Wrap(err, "marshaling params")
+	}
+	resp, err := node.ABCIQuery(query.PriceSIBEndpoint, pqpb)
+	if err != nil {
+		return out, errors.Wrap(err, "performing query")
+	}
+	if code.ReturnCode(resp.Response.Code) != code.OK {
+		return out, errors.New(resp.Response.Log)
+	}
+	_, err = out.UnmarshalMsg(resp.Response.Value)
+	err = errors.Wrap(err, "unmarshaling response")
+	return out, err
+}
@@ -0,0 +1,292 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/rpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// Tendermint never returns more than this many unconfirmed txs at once.
+const mempoolScanLimit = 100
+
+// ErrSequenceExhausted is returned when an account has so many txs in flight that
+// the next would exceed ndau.MaxSequenceIncrement.
+var ErrSequenceExhausted = errors.New("too many txs in flight for this account")
+
+// SetSequence sets the sequence of a tx.
+//
+// Every ndau tx with a sequence stores it in a field of that name.
+func SetSequence(tx metatx.Transactable, sequence uint64) error {
+	v := reflect.ValueOf(tx)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		field := v.FieldByName("Sequence")
+		if field.IsValid() && field.CanSet() && field.Kind() == reflect.Uint64 {
+			field.SetUint(sequence)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s has no sequence", metatx.NameOf(tx))
+}
+
+// A SequenceSource reports the sequence numbers an account has used: the sequence
+// of its last committed tx, and the highest sequence among its txs waiting in the
+// mempool, or 0 if there are none.
+type SequenceSource interface {
+	Sequences(addr address.Address) (committed, pending uint64, err error)
+}
+
+// A MempoolClient is a node client which can list unconfirmed txs
+type MempoolClient interface {
+	client.ABCIClient
+	UnconfirmedTxs(limit int) (*rpctypes.ResultUnconfirmedTxs, error)
+}
+
+// NodeSequences is a SequenceSource which asks a node directly
+type NodeSequences struct {
+	Node MempoolClient
+}
+
+// Sequences implements SequenceSource
+//
+// Only the first txs in the mempool are inspected; see mempoolScanLimit.
+func (n NodeSequences) Sequences(addr address.Address) (committed, pending uint64, err error) {
+	committed, err = GetSequence(n.Node, addr)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "getting committed sequence")
+	}
+	unconfirmed, err := n.Node.UnconfirmedTxs(mempoolScanLimit)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "getting unconfirmed txs")
+	}
+	for _, txb := range unconfirmed.Txs {
+		tx, err := metatx.Unmarshal(txb, ndau.TxIDs)
+		if err != nil {
+			// anything in the mempool passed CheckTx, so this shouldn't happen;
+			// either way, it's not one of ours
+			continue
+		}
+		seqr, ok := tx.(ndau.Sequencer)
+		if !ok || seqr.GetSequence() <= pending {
+			continue
+		}
+		source, _, err := TxSource(n.Node, tx)
+		if err != nil {
+			continue
+		}
+		if source == addr {
+			pending = seqr.GetSequence()
+		}
+	}
+	return committed, pending, nil
+}
+
+var _ SequenceSource = (*NodeSequences)(nil)
+
+// accountSequences is what a SequenceAllocator knows about one account
+type accountSequences struct {
+	lock        sync.Mutex
+	committed   uint64
+	next        uint64
+	outstanding map[uint64]struct{}
+	// the highest sequence confirmed, which the source may not see until it's committed
+	confirmed uint64
+	stale     bool
+
+	// closed when the sync in progress, if any, is done
+	syncing chan struct{}
+	syncErr error
+	// counts releases, so a sync can tell whether one happened while it was asking
+	releases uint64
+}
+
+// A SequenceAllocator hands out sequence numbers for txs which are submitted
+// concurrently from the same accounts.
+//
+// A sequence is reserved with Reserve, and then either confirmed once its tx has been
+// accepted, or released if the tx was rejected. Reservations are made locally; the
+// source is only consulted for an account's first reservation, after a rejection, and
+// when the account's txs in flight would otherwise exceed ndau.MaxSequenceIncrement.
+// Releasing a sequence doesn't make it available again: a tx with a lower sequence
+// than one already in flight would be rejected once the higher one is committed. It
+// does let the allocator resynchronize, so that the gap closes if nothing later was
+// in flight. Nor is a confirmed sequence handed out again, even while its tx is beyond
+// what the source can see.
+//
+// It is safe for concurrent use. Accounts are locked independently, and no lock is
+// held while the source is consulted, so a slow source only holds up reservations for
+// the account it's being asked about.
+type SequenceAllocator struct {
+	source   SequenceSource
+	lock     sync.Mutex
+	accounts map[string]*accountSequences
+}
+
+// NewSequenceAllocator creates a SequenceAllocator which learns the sequences already
+// used from source.
+func NewSequenceAllocator(source SequenceSource) *SequenceAllocator {
+	return &SequenceAllocator{
+		source:   source,
+		accounts: make(map[string]*accountSequences),
+	}
+}
+
+// account returns what is known about an account, if anything
+//
+// If create is set, an account not yet known is added, to be synced on first use.
+func (sa *SequenceAllocator) account(addr address.Address, create bool) *accountSequences {
+	sa.lock.Lock()
+	defer sa.lock.Unlock()
+	acct, ok := sa.accounts[addr.String()]
+	if !ok && create {
+		acct = &accountSequences{
+			outstanding: make(map[uint64]struct{}),
+			stale:       true,
+		}
+		sa.accounts[addr.String()] = acct
+	}
+	return acct
+}
+
+// sync updates an account from the source.
+//
+// It must be called with the account's lock held, and releases it while the source is
+// consulted. If the account is already being synced, it waits for that sync instead.
+func (sa *SequenceAllocator) sync(addr address.Address, acct *accountSequences) error {
+	if acct.syncing != nil {
+		done := acct.syncing
+		acct.lock.Unlock()
+		<-done
+		acct.lock.Lock()
+		return acct.syncErr
+	}
+
+	done := make(chan struct{})
+	acct.syncing = done
+	releases := acct.releases
+	acct.lock.Unlock()
+	committed, pending, err := sa.source.Sequences(addr)
+	acct.lock.Lock()
+	acct.syncing = nil
+	acct.syncErr = err
+	close(done)
+	if err != nil {
+		return err
+	}
+
+	acct.committed = committed
+	acct.next = committed + 1
+	if pending >= acct.next {
+		acct.next = pending + 1
+	}
+	for seq := range acct.outstanding {
+		if seq <= committed {
+			// it was committed, or can no longer be
+			delete(acct.outstanding, seq)
+		} else if seq >= acct.next {
+			acct.next = seq + 1
+		}
+	}
+	// a confirmed tx may be past the part of the mempool the source looked at
+	if acct.confirmed >= acct.next {
+		acct.next = acct.confirmed + 1
+	}
+	// what the source told us may predate a release made while we were asking
+	acct.stale = acct.releases != releases
+	return nil
+}
+
+// Reserve returns the next sequence to use for a tx from addr
+func (sa *SequenceAllocator) Reserve(addr address.Address) (uint64, error) {
+	acct := sa.account(addr, true)
+	acct.lock.Lock()
+	defer acct.lock.Unlock()
+
+	synced := false
+	for {
+		if !synced && !acct.stale && acct.next > acct.committed+ndau.MaxSequenceIncrement {
+			// txs may have been committed since we last looked
+			acct.stale = true
+		}
+		if !acct.stale {
+			break
+		}
+		err := sa.sync(addr, acct)
+		if err != nil {
+			return 0, errors.Wrap(err, "getting sequences")
+		}
+		synced = true
+	}
+	if acct.next > acct.committed+ndau.MaxSequenceIncrement {
+		return 0, ErrSequenceExhausted
+	}
+
+	seq := acct.next
+	acct.next++
+	acct.outstanding[seq] = struct{}{}
+	return seq, nil
+}
+
+// Confirm records that the tx using a reserved sequence was accepted
+func (sa *SequenceAllocator) Confirm(addr address.Address, seq uint64) {
+	if acct := sa.account(addr, false); acct != nil {
+		acct.lock.Lock()
+		defer acct.lock.Unlock()
+		delete(acct.outstanding, seq)
+		if seq > acct.confirmed {
+			acct.confirmed = seq
+		}
+	}
+}
+
+// Release records that the tx using a reserved sequence was rejected
+func (sa *SequenceAllocator) Release(addr address.Address, seq uint64) {
+	if acct := sa.account(addr, false); acct != nil {
+		acct.lock.Lock()
+		defer acct.lock.Unlock()
+		delete(acct.outstanding, seq)
+		acct.stale = true
+		acct.releases++
+	}
+}
+
+// Reset forgets everything known about an account
+func (sa *SequenceAllocator) Reset(addr address.Address) {
+	sa.lock.Lock()
+	defer sa.lock.Unlock()
+	delete(sa.accounts, addr.String())
+}
+
+// WithSequence reserves a sequence for a tx from addr and calls send with it,
+// confirming the sequence if send succeeds and releasing it otherwise
+func (sa *SequenceAllocator) WithSequence(addr address.Address, send func(seq uint64) error) error {
+	seq, err := sa.Reserve(addr)
+	if err != nil {
+		return err
+	}
+	err = send(seq)
+	if err != nil {
+		sa.Release(addr, seq)
+		return err
+	}
+	sa.Confirm(addr, seq)
+	return nil
+}
@@ -0,0 +1,226 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSequences is a SequenceSource whose answers the test controls
+type fakeSequences struct {
+	lock      sync.Mutex
+	committed map[string]uint64
+	pending   map[string]uint64
+	calls     map[string]int
+	// if set, Sequences waits on it before answering
+	block map[string]chan struct{}
+}
+
+func newFakeSequences() *fakeSequences {
+	return &fakeSequences{
+		committed: make(map[string]uint64),
+		pending:   make(map[string]uint64),
+		calls:     make(map[string]int),
+		block:     make(map[string]chan struct{}),
+	}
+}
+
+func (f *fakeSequences) Sequences(addr address.Address) (committed, pending uint64, err error) {
+	f.lock.Lock()
+	f.calls[addr.String()]++
+	block := f.block[addr.String()]
+	f.lock.Unlock()
+	if block != nil {
+		<-block
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.committed[addr.String()], f.pending[addr.String()], nil
+}
+
+func (f *fakeSequences) set(addr address.Address, committed, pending uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.committed[addr.String()] = committed
+	f.pending[addr.String()] = pending
+}
+
+func (f *fakeSequences) callsFor(addr address.Address) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.calls[addr.String()]
+}
+
+func TestSequenceAllocatorConcurrent(t *testing.T) {
+	addr := makeAddress(t)
+	source := newFakeSequences()
+	source.set(addr, 10, 12)
+	sa := NewSequenceAllocator(source)
+
+	const n = 50
+	seqs := make(chan uint64, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seq, err := sa.Reserve(addr)
+			require.NoError(t, err)
+			seqs <- seq
+		}()
+	}
+	wg.Wait()
+	close(seqs)
+
+	// every reservation is distinct, and follows what's pending
+	seen := make(map[uint64]bool)
+	for seq := range seqs {
+		require.False(t, seen[seq], "sequence %d reserved twice", seq)
+		seen[seq] = true
+	}
+	for seq := uint64(13); seq < 13+n; seq++ {
+		require.True(t, seen[seq], "sequence %d not reserved", seq)
+	}
+	require.Equal(t, 1, source.callsFor(addr))
+}
+
+func TestSequenceAllocatorSyncUnlocked(t *testing.T) {
+	slow, fast := makeAddress(t), makeAddress(t)
+	source := newFakeSequences()
+	unblock := make(chan struct{})
+	source.block[slow.String()] = unblock
+	sa := NewSequenceAllocator(source)
+
+	// two reservations for the slow account share a single sync
+	reserved := make(chan uint64, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			seq, err := sa.Reserve(slow)
+			require.NoError(t, err)
+			reserved <- seq
+		}()
+	}
+	for source.callsFor(slow) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// other accounts aren't held up
+	seq, err := sa.Reserve(fast)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), seq)
+	sa.Confirm(fast, seq)
+
+	close(unblock)
+	first, second := <-reserved, <-reserved
+	require.ElementsMatch(t, []uint64{1, 2}, []uint64{first, second})
+	require.Equal(t, 1, source.callsFor(slow))
+}
+
+func TestSequenceAllocatorRelease(t *testing.T) {
+	addr := makeAddress(t)
+	source := newFakeSequences()
+	sa := NewSequenceAllocator(source)
+
+	for expect := uint64(1); expect <= 3; expect++ {
+		seq, err := sa.Reserve(addr)
+		require.NoError(t, err)
+		require.Equal(t, expect, seq)
+	}
+	require.Equal(t, 1, source.callsFor(addr))
+
+	// 3 was rejected, and nothing after it was in flight: once 1 and 2 are committed,
+	// the gap closes
+	sa.Confirm(addr, 1)
+	sa.Confirm(addr, 2)
+	sa.Release(addr, 3)
+	source.set(addr, 2, 0)
+	seq, err := sa.Reserve(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), seq)
+	require.Equal(t, 2, source.callsFor(addr))
+
+	// 4 is still in flight when 3 is rejected, so 3 can't be reused
+	seq, err = sa.Reserve(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), seq)
+	sa.Release(addr, 3)
+	seq, err = sa.Reserve(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), seq)
+	require.Equal(t, 3, source.callsFor(addr))
+
+	// once reset, the account is synced afresh
+	sa.Reset(addr)
+	source.set(addr, 7, 0)
+	seq, err = sa.Reserve(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), seq)
+}
+
+func TestSequenceAllocatorExhausted(t *testing.T) {
+	addr := makeAddress(t)
+	source := newFakeSequences()
+	sa := NewSequenceAllocator(source)
+
+	for expect := uint64(1); expect <= ndau.MaxSequenceIncrement; expect++ {
+		seq, err := sa.Reserve(addr)
+		require.NoError(t, err)
+		require.Equal(t, expect, seq)
+	}
+	require.Equal(t, 1, source.callsFor(addr))
+
+	// nothing was committed in the meantime
+	_, err := sa.Reserve(addr)
+	require.Equal(t, ErrSequenceExhausted, err)
+	require.Equal(t, 2, source.callsFor(addr))
+
+	// until some are
+	source.set(addr, 10, ndau.MaxSequenceIncrement)
+	seq, err := sa.Reserve(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(ndau.MaxSequenceIncrement+1), seq)
+	require.Equal(t, 3, source.callsFor(addr))
+}
+
+func TestSequenceAllocatorConfirmedUnseen(t *testing.T) {
+	addr := makeAddress(t)
+	source := newFakeSequences()
+	sa := NewSequenceAllocator(source)
+
+	for expect := uint64(1); expect <= 3; expect++ {
+		seq, err := sa.Reserve(addr)
+		require.NoError(t, err)
+		require.Equal(t, expect, seq)
+	}
+	sa.Confirm(addr, 1)
+	sa.Confirm(addr, 2)
+	sa.Release(addr, 3)
+
+	// 2 was accepted, but the source doesn't see it: it's too far back in the mempool
+	source.set(addr, 0, 1)
+	seq, err := sa.Reserve(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), seq)
+	require.Equal(t, 2, source.callsFor(addr))
+
+	// once it's committed, the source is believed again
+	sa.Release(addr, 3)
+	source.set(addr, 2, 0)
+	seq, err = sa.Reserve(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), seq)
+	require.Equal(t, 3, source.callsFor(addr))
+}
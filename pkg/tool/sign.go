@@ -0,0 +1,36 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/signer"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/rpc/client"
+)
+
+// SignAndSend signs a transaction with the given signer, then broadcasts and commits it
+func SignAndSend(node client.ABCIClient, tx metatx.Transactable, s signer.Signer) (interface{}, error) {
+	err := signer.SignTx(tx, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "signing tx")
+	}
+	return SendCommit(node, tx)
+}
+
+// SignAndSendSync signs a transaction with the given signer, then broadcasts it with
+// Sync semantics
+func SignAndSendSync(node client.ABCIClient, tx metatx.Transactable, s signer.Signer) (interface{}, error) {
+	err := signer.SignTx(tx, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "signing tx")
+	}
+	return SendSync(node, tx)
+}
@@ -0,0 +1,83 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+
+	"github.com/ndau/ndau/pkg/ndau/backing"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/ndau/ndaumath/pkg/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/rpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// fakeNode is a node which the test configures. Anything else asked of it panics.
+//
+// Asked about a single account, it answers with an account having the validation keys.
+// Asked about many, every other account exists, with a balance of how many accounts
+// it had been asked about before; it records how many addresses each such query asked about.
+type fakeNode struct {
+	client.ABCIClient
+	source     address.Address // the source of every tx
+	validation []signature.PublicKey
+	broadcasts int
+	asked      int
+	chunks     []int
+}
+
+func (c *fakeNode) ABCIQuery(path string, data cmn.HexBytes) (*rpctypes.ResultABCIQuery, error) {
+	var resp abci.ResponseQuery
+	switch path {
+	case query.TxSourceEndpoint:
+		resp.Value = []byte(c.source.String())
+		resp.Info = fmt.Sprintf(query.TxSourceInfoFmt, 0)
+	case query.AccountEndpoint:
+		ad := backing.AccountData{ValidationKeys: c.validation}
+		value, err := ad.MarshalMsg(nil)
+		if err != nil {
+			return nil, err
+		}
+		resp.Value = value
+	case query.AccountsEndpoint:
+		var addrs query.AccountsRequest
+		_, err := addrs.UnmarshalMsg(data)
+		if err != nil {
+			return nil, err
+		}
+		c.chunks = append(c.chunks, len(addrs))
+
+		ar := make(query.AccountsResponse, len(addrs))
+		for _, addr := range addrs {
+			ar[addr.String()] = query.AccountResult{
+				Exists: c.asked%2 == 0,
+				Data:   backing.AccountData{Balance: types.Ndau(c.asked)},
+			}
+			c.asked++
+		}
+		resp.Value, err = ar.MarshalMsg(nil)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		panic("unexpected query: " + path)
+	}
+	return &rpctypes.ResultABCIQuery{Response: resp}, nil
+}
+
+func (c *fakeNode) BroadcastTxCommit(tx tmtypes.Tx) (*rpctypes.ResultBroadcastTxCommit, error) {
+	c.broadcasts++
+	return &rpctypes.ResultBroadcastTxCommit{}, nil
+}
@@ -0,0 +1,51 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"fmt"
+
+	"github.com/ndau/metanode/pkg/meta/app/code"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/ndau/ndaumath/pkg/address"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/rpc/client"
+)
+
+// TxSource gets the source address of a transaction, and the current sequence of that account
+//
+// The transaction need not be signed or have its sequence set.
+func TxSource(node client.ABCIClient, tx metatx.Transactable) (
+	source address.Address, sequence uint64, err error,
+) {
+	txb, err := metatx.Marshal(tx, ndau.TxIDs)
+	if err != nil {
+		return source, 0, errors.Wrap(err, "marshaling tx")
+	}
+
+	// perform the query
+	res, err := node.ABCIQuery(query.TxSourceEndpoint, txb)
+	if err != nil {
+		return source, 0, err
+	}
+	if code.ReturnCode(res.Response.Code) != code.OK {
+		return source, 0, errors.New(res.Response.Log)
+	}
+
+	// parse the response
+	source, err = address.Validate(string(res.Response.GetValue()))
+	if err != nil {
+		return source, 0, errors.Wrap(err, "validating source address")
+	}
+	_, err = fmt.Sscanf(res.Response.Info, query.TxSourceInfoFmt, &sequence)
+	return source, sequence, errors.Wrap(err, "parsing source sequence")
+}
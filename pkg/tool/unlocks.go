@@ -0,0 +1,41 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"github.com/ndau/metanode/pkg/meta/app/code"
+	srch "github.com/ndau/ndau/pkg/ndau/search"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/rpc/client"
+)
+
+// Unlocks returns the scheduled unlocks within a range of unlock times,
+// along with their per-day totals
+func Unlocks(
+	node client.ABCIClient,
+	params srch.UnlocksQueryParams,
+) (srch.UnlocksQueryResults, error) {
+	var out srch.UnlocksQueryResults
+	uqpb, err := params.MarshalMsg(nil)
+	if err != nil {
+		return out, errors.Wrap(err, "marshaling params")
+	}
+	resp, err := node.ABCIQuery(query.UnlocksEndpoint, uqpb)
+	if err != nil {
+		return out, errors.Wrap(err, "performing query")
+	}
+	if code.ReturnCode(resp.Response.Code) != code.OK {
+		return out, errors.New(code.ReturnCode(resp.Response.Code).String() + ": " + resp.Response.Log)
+	}
+	_, err = out.UnmarshalMsg(resp.Response.Value)
+	err = errors.Wrap(err, "unmarshaling response")
+	return out, err
+}
@@ -0,0 +1,106 @@
+package tool
+
+// ----- ---- --- -- -
+// Copyright 2019 Oneiro NA, Inc. All Rights Reserved.
+//
+// Licensed under the Apache License 2.0 (the "License").  You may not use
+// this file except in compliance with the License.  You can obtain a copy
+// in the file LICENSE in the source distribution or at
+// https://www.apache.org/licenses/LICENSE-2.0.txt
+// - -- --- ---- -----
+
+import (
+	"github.com/ndau/metanode/pkg/meta/app/code"
+	metatx "github.com/ndau/metanode/pkg/meta/transaction"
+	"github.com/ndau/ndau/pkg/ndau"
+	"github.com/ndau/ndau/pkg/query"
+	"github.com/ndau/ndaumath/pkg/signature"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/rpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// ErrLockout is the cause of the error returned when a validation script would
+// reject its owner's own txs
+var ErrLockout = errors.New("validation script would reject the owner's next tx")
+
+// DryRunValidationScript runs a validation script against a tx, without changing
+// anything.
+//
+// The script sees the current state of the tx's source account, and the tx as though
+// it had been signed by the validation keys whose bits are set in signatures. If keys
+// is not nil, it replaces the account's validation keys. The tx need not be signed.
+func DryRunValidationScript(
+	node client.ABCIClient,
+	script []byte,
+	tx metatx.Transactable,
+	signatures uint64,
+	keys []signature.PublicKey,
+) (*query.ValidationScriptResponse, *rpctypes.ResultABCIQuery, error) {
+	txb, err := metatx.Marshal(tx, ndau.TxIDs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "marshaling tx")
+	}
+	req, err := query.ValidationScriptRequest{
+		Script:         script,
+		Tx:             txb,
+		Signatures:     signatures,
+		ValidationKeys: keys,
+	}.MarshalMsg(nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "marshaling validation script query")
+	}
+
+	// perform the query
+	res, err := node.ABCIQuery(query.ValidationScriptEndpoint, req)
+	if err != nil {
+		return nil, res, err
+	}
+	if code.ReturnCode(res.Response.Code) != code.OK {
+		return nil, res, errors.New(res.Response.Log)
+	}
+
+	// parse the response
+	result := new(query.ValidationScriptResponse)
+	_, err = result.UnmarshalMsg(res.Response.GetValue())
+	return result, res, errors.Wrap(err, "DryRunValidationScript")
+}
+
+// ValidationProbe returns the tx used to check that the validation script set by tx
+// won't lock its owner out, and the signatures it is checked with.
+//
+// The owner must at least remain able to change their validation rules, so the probe
+// is the same ChangeValidation, one sequence later, signed by every new key.
+func ValidationProbe(tx *ndau.ChangeValidation) (metatx.Transactable, uint64) {
+	probe := ndau.NewChangeValidation(tx.Target, tx.NewKeys, tx.ValidationScript, tx.Sequence+1)
+	return probe, uint64(1)<<uint(len(tx.NewKeys)) - 1
+}
+
+// CheckChangeValidation refuses a ChangeValidation whose validation script would
+// reject the owner's next tx; see ValidationProbe.
+//
+// If the script would, the error's cause is ErrLockout, and the result explains why.
+// A tx which removes the validation script is always accepted.
+func CheckChangeValidation(node client.ABCIClient, tx *ndau.ChangeValidation) (*query.ValidationScriptResponse, error) {
+	if len(tx.ValidationScript) == 0 {
+		return nil, nil
+	}
+	probe, signatures := ValidationProbe(tx)
+	result, _, err := DryRunValidationScript(node, tx.ValidationScript, probe, signatures, tx.NewKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "running validation script")
+	}
+	return result, Lockout(result)
+}
+
+// Lockout returns an error whose cause is ErrLockout if the validation script
+// which produced result rejected its probe, and nil otherwise
+func Lockout(result *query.ValidationScriptResponse) error {
+	if result.Accepted() {
+		return nil
+	}
+	if result.Error != "" {
+		return errors.Wrap(ErrLockout, result.Error)
+	}
+	return errors.Wrapf(ErrLockout, "exit code %d", result.ExitCode)
+}